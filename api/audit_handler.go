@@ -0,0 +1,255 @@
+package api
+
+import (
+	"MatchSimulator_Insider/logging"
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// actorHeader, değişikliği yapan kişiyi bildiren istek başlığıdır. Gönderilmezse kayıt "anonymous" olarak tutulur.
+const actorHeader = "X-Actor"
+
+// maxActorLength, audit_log.actor sütununun uzunluğudur. VARCHAR(100) bayt değil karakter saydığından kısaltma da karakter üzerinden yapılır.
+const maxActorLength = 100
+
+// scoreAuditValue, bir maç skorunun denetim kaydındaki hâlidir. Oynanmamış maçta goller boştur.
+type scoreAuditValue struct {
+	HomeGoals *int `json:"home_goals"`
+	AwayGoals *int `json:"away_goals"`
+	IsForfeit bool `json:"is_forfeit,omitempty"`
+}
+
+// weekRevertAuditValue, geri alınan haftadaki bir maçın geri alınmadan önceki skorudur.
+type weekRevertAuditValue struct {
+	MatchID int `json:"match_id"`
+	scoreAuditValue
+}
+
+type AuditHandler struct {
+	auditService  abstracts.AuditService
+	leagueService abstracts.ILeagueService
+	matchService  abstracts.IMatchService
+}
+
+// NewAuditHandler, yeni bir AuditHandler örneği oluşturur.
+func NewAuditHandler(as abstracts.AuditService, ls abstracts.ILeagueService, ms abstracts.IMatchService) *AuditHandler {
+	return &AuditHandler{
+		auditService:  as,
+		leagueService: ls,
+		matchService:  ms,
+	}
+}
+
+// GetAuditLogHandler, denetim kayıtlarını eskiden yeniye listeler.
+// entity, entity_id, from ve to (RFC 3339, to hariç) sorgu parametreleri ile süzülebilir.
+func (h *AuditHandler) GetAuditLogHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	query := r.URL.Query()
+	var filter models.AuditFilter
+	switch entity := query.Get("entity"); entity {
+	case "", models.AuditEntityTeam, models.AuditEntityMatch, models.AuditEntityLeague:
+		filter.EntityType = entity
+	default:
+		respondWithError(w, http.StatusBadRequest, "Invalid entity: Must be team, match or league.")
+		return
+	}
+	if entityIDStr := query.Get("entity_id"); entityIDStr != "" {
+		entityID, err := strconv.Atoi(entityIDStr)
+		if err != nil || entityID < 1 {
+			respondWithError(w, http.StatusBadRequest, "Invalid entity_id: Must be a positive number.")
+			return
+		}
+		if filter.EntityType == "" {
+			respondWithError(w, http.StatusBadRequest, "entity_id needs an entity (team or match).")
+			return
+		}
+		filter.EntityID = entityID
+	}
+	for _, bound := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &filter.Since}, {"to", &filter.Until}} {
+		value := query.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid "+bound.name+": Must be an RFC 3339 time such as 2024-05-01T12:00:00Z.")
+			return
+		}
+		*bound.target = parsed
+	}
+	if !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		respondWithError(w, http.StatusBadRequest, "Invalid time range: from must be before to.")
+		return
+	}
+
+	entries, err := h.auditService.List(r.Context(), filter)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error retrieving audit log: "+err.Error())
+		return
+	}
+	if entries == nil {
+		entries = []models.AuditEntry{}
+	}
+	respondWithJSON(w, http.StatusOK, entries)
+}
+
+// RevertAuditEntryHandler, bir skor düzenlemesini veya hükmen kararı denetim kaydındaki eski skora geri döndürür.
+// Maç o değişiklikten sonra yeniden düzenlendiyse önce sonraki değişikliklerin geri alınması gerekir (409).
+func (h *AuditHandler) RevertAuditEntryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only POST method is supported for this endpoint.")
+		return
+	}
+	ctx := r.Context()
+	entryID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid audit entry ID: Must be a number.")
+		return
+	}
+	var reqBody RevertAuditEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil && !errors.Is(err, io.EOF) {
+		respondWithBodyError(w, "Invalid request body", err)
+		return
+	}
+	defer r.Body.Close()
+
+	entry, err := h.auditService.GetByID(ctx, entryID)
+	if err != nil {
+		respondWithServiceError(w, err, "Error retrieving audit entry")
+		return
+	}
+	if entry.Action != models.AuditActionScoreEdit && entry.Action != models.AuditActionForfeit {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Audit entry %d is a %s; only score edits and forfeits can be reverted.", entryID, entry.Action))
+		return
+	}
+	var before, after scoreAuditValue
+	if json.Unmarshal(entry.OldValue, &before) != nil || json.Unmarshal(entry.NewValue, &after) != nil {
+		respondWithErrorCode(w, http.StatusConflict, "cannot_revert", fmt.Sprintf("Audit entry %d does not record the score before and after the change.", entryID))
+		return
+	}
+
+	match, err := h.matchService.GetMatchByID(ctx, entry.EntityID)
+	if err != nil {
+		respondWithServiceError(w, err, "Error retrieving match")
+		return
+	}
+	current := scoreAuditValue{HomeGoals: match.HomeGoals, AwayGoals: match.AwayGoals, IsForfeit: match.IsForfeit}
+	if !sameScore(current, after) {
+		respondWithErrorCode(w, http.StatusConflict, "cannot_revert", fmt.Sprintf("Match ID %d has changed since audit entry %d; revert the later changes first.", match.ID, entryID))
+		return
+	}
+
+	// Maçın okunan sürümü beklenir; arada başka bir değişiklik olursa işlem 412 ile reddedilir
+	if before.HomeGoals == nil || before.AwayGoals == nil {
+		err = h.leagueService.RevertMatchResult(ctx, match.ID, match.Version)
+	} else {
+		err = h.leagueService.HandleMatchScoreEdit(ctx, match.ID, *before.HomeGoals, *before.AwayGoals, match.Version)
+		if err == nil && before.IsForfeit {
+			err = h.matchService.SetMatchForfeit(ctx, match.ID, true)
+		}
+	}
+	if err != nil {
+		respondWithServiceError(w, err, "Error reverting match score")
+		return
+	}
+
+	reason := strings.TrimSpace(reqBody.Reason)
+	if reason == "" {
+		reason = fmt.Sprintf("Revert of audit entry %d", entryID)
+	}
+	if !recordAudit(w, r, h.auditService, models.AuditEntry{
+		EntityType: models.AuditEntityMatch,
+		EntityID:   match.ID,
+		Action:     models.AuditActionScoreRevert,
+		OldValue:   auditValue(current),
+		NewValue:   auditValue(before),
+		Reason:     reason,
+	}) {
+		return
+	}
+
+	revertedMatch, err := h.matchService.GetMatchByID(ctx, match.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Score reverted but the match could not be retrieved: "+err.Error())
+		return
+	}
+	currentWeek, err := h.leagueService.GetCurrentWeek(ctx)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Score reverted but the current week could not be determined: "+err.Error())
+		return
+	}
+	leagueTable, err := h.leagueService.GetLeagueTable(ctx)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Score reverted but the league table could not be retrieved: "+err.Error())
+		return
+	}
+	logLeagueTable(ctx, "league table after reverting an audit entry", leagueTable, "audit_entry_id", entryID, logging.KeyMatchID, match.ID)
+	setVersionETag(w, matchVersions(*revertedMatch, leagueTable)...)
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message":               fmt.Sprintf("Match ID %d reverted to its score before audit entry %d.", match.ID, entryID),
+		"match":                 revertedMatch,
+		"current_playable_week": currentWeek,
+		"league_table":          leagueTable,
+	})
+}
+
+// sameScore, iki skorun (oynanmamış olma ve hükmen işareti dahil) aynı olup olmadığını söyler.
+func sameScore(a, b scoreAuditValue) bool {
+	sameGoals := func(x, y *int) bool {
+		return (x == nil && y == nil) || (x != nil && y != nil && *x == *y)
+	}
+	return sameGoals(a.HomeGoals, b.HomeGoals) && sameGoals(a.AwayGoals, b.AwayGoals) && a.IsForfeit == b.IsForfeit
+}
+
+// auditActor, isteği yapan kişiyi X-Actor başlığından okur.
+func auditActor(r *http.Request) string {
+	actor := strings.TrimSpace(r.Header.Get(actorHeader))
+	if actor == "" {
+		return "anonymous"
+	}
+	// Başlıkta geçersiz UTF-8 olabilir; PostgreSQL onu reddeder, kısaltma da çok baytlı bir karakteri ortadan bölmemelidir.
+	actor = strings.ToValidUTF8(actor, "\uFFFD")
+	if utf8.RuneCountInString(actor) > maxActorLength {
+		actor = string([]rune(actor)[:maxActorLength])
+	}
+	return actor
+}
+
+// auditValue, bir değeri denetim kaydına yazılacak JSON'a çevirir.
+func auditValue(value interface{}) json.RawMessage {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		slog.Error("audit value could not be encoded", "value", fmt.Sprintf("%+v", value), "error", err)
+		return nil
+	}
+	return encoded
+}
+
+// recordAudit, yapılan değişikliği isteği yapan kişiyle birlikte denetim kaydına yazar.
+// Kayıt yazılamazsa 500 döner ve false verir. PostgreSQL ve SQLite'ta istek transaction içinde çalıştığından
+// değişiklik de geri alınır; bellek deposunda transaction yoktur, değişiklik kayıtsız olarak kalır.
+func recordAudit(w http.ResponseWriter, r *http.Request, auditService abstracts.AuditService, entry models.AuditEntry) bool {
+	entry.Actor = auditActor(r)
+	entry.Reason = strings.TrimSpace(entry.Reason)
+	if _, err := auditService.Record(r.Context(), entry); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Change could not be recorded in the audit log: "+err.Error())
+		return false
+	}
+	return true
+}
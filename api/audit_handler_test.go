@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestAuditActor(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "Missing", header: "", want: "anonymous"},
+		{name: "Trimmed", header: "  alice  ", want: "alice"},
+		{name: "Long ASCII", header: strings.Repeat("a", maxActorLength+5), want: strings.Repeat("a", maxActorLength)},
+		// 99 ASCII bytes followed by multi-byte letters: a byte cut at 100 would split "ş".
+		{name: "Multi-byte At The Limit", header: strings.Repeat("a", maxActorLength-1) + "şğü", want: strings.Repeat("a", maxActorLength-1) + "ş"},
+		{name: "Counts Characters Not Bytes", header: strings.Repeat("ö", maxActorLength), want: strings.Repeat("ö", maxActorLength)},
+		{name: "Invalid UTF-8", header: "bob\xff", want: "bob�"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/reset-league", nil)
+			r.Header.Set(actorHeader, tt.header)
+			got := auditActor(r)
+			if got != tt.want {
+				t.Errorf("auditActor() = %q, want %q", got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("auditActor() = %q is not valid UTF-8", got)
+			}
+		})
+	}
+}
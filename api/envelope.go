@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// apiV1Prefix, sürümlü API'nin yol önekidir. Öneksiz eski rotalar geriye dönük uyumluluk için durur ama kullanımdan kalkmıştır.
+const apiV1Prefix = "/v1"
+
+// envelope, /v1 cevaplarının ortak gövdesidir. Başarılı cevaplarda Errors boş, hatalarda Data null olur.
+type envelope struct {
+	Data   json.RawMessage `json:"data"`
+	Meta   envelopeMeta    `json:"meta"`
+	Errors []envelopeError `json:"errors"`
+}
+
+// envelopeMeta, cevabın verisine ait olmayan bilgileri taşır.
+type envelopeMeta struct {
+	Message string `json:"message,omitempty"`
+	Count   *int   `json:"count,omitempty"` // Data bir liste ise eleman sayısı
+}
+
+// envelopeError, errorResponse'un /v1 karşılığıdır; kodlar aynıdır.
+type envelopeError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type v1RequestKey struct{}
+
+// isV1Request, isteğin /v1 rotasından geldiğini söyler. Eski rotalarla şekli farklı olan cevaplar bunu kullanır.
+func isV1Request(r *http.Request) bool {
+	v1, _ := r.Context().Value(v1RequestKey{}).(bool)
+	return v1
+}
+
+// withEnvelope, handler'ın cevabını tamponlayıp /v1 zarfına (data, meta, errors) sarar.
+// JSON olmayan cevaplar (304, HTML) olduğu gibi geçer; Location başlığı /v1 rotasını gösterecek şekilde düzeltilir.
+func withEnvelope(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		buffered := newBufferedResponse()
+		next(buffered, r.WithContext(context.WithValue(r.Context(), v1RequestKey{}, true)))
+
+		if location := buffered.header.Get("Location"); strings.HasPrefix(location, "/") && !strings.HasPrefix(location, apiV1Prefix+"/") {
+			buffered.header.Set("Location", apiV1Prefix+location)
+		}
+		body, ok := envelopeBody(buffered)
+		if !ok {
+			buffered.flushTo(w)
+			return
+		}
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "response could not be wrapped in the envelope", "method", r.Method, "path", r.URL.Path, "error", err)
+			buffered.flushTo(w)
+			return
+		}
+		buffered.header.Set("Content-Type", "application/json")
+		buffered.header.Del("Content-Length")
+		buffered.body.Reset()
+		buffered.body.Write(encoded)
+		buffered.flushTo(w)
+	}
+}
+
+// envelopeBody, tamponlanmış cevabı zarfa çevirir. Zarflanmayacak bir cevapsa false döner.
+func envelopeBody(buffered *bufferedResponse) (envelope, bool) {
+	raw := buffered.body.Bytes()
+	isJSON := strings.HasPrefix(buffered.header.Get("Content-Type"), "application/json") && json.Valid(raw)
+
+	if buffered.status >= http.StatusBadRequest {
+		apiErr := envelopeError{Code: errorCodeForStatus(buffered.status), Message: strings.TrimSpace(string(raw))}
+		var legacy errorResponse
+		if isJSON && json.Unmarshal(raw, &legacy) == nil && legacy.Code != "" {
+			apiErr = envelopeError{Code: legacy.Code, Message: legacy.Error}
+		}
+		return envelope{Data: json.RawMessage("null"), Errors: []envelopeError{apiErr}}, true
+	}
+	if !isJSON || len(raw) == 0 {
+		return envelope{}, false
+	}
+
+	body := envelope{Data: raw, Errors: []envelopeError{}}
+	// Eski cevaplardaki "message" alanı veriye değil meta'ya aittir.
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(raw, &fields) == nil {
+		if message, ok := fields["message"]; ok {
+			if json.Unmarshal(message, &body.Meta.Message) == nil {
+				delete(fields, "message")
+				body.Data = json.RawMessage("null")
+				if len(fields) > 0 {
+					if remaining, err := json.Marshal(fields); err == nil {
+						body.Data = remaining
+					}
+				}
+			}
+		}
+	}
+	var items []json.RawMessage
+	if json.Unmarshal(body.Data, &items) == nil && items != nil {
+		count := len(items)
+		body.Meta.Count = &count
+	}
+	return body, true
+}
+
+// respondWithErrorForPath, handler'lardan önce çalışan middleware'lerin hatalarını yazar: /v1 yollarında hata zarfı,
+// eski rotalarda errorResponse gövdesi döner.
+func respondWithErrorForPath(w http.ResponseWriter, r *http.Request, status int, message string) {
+	code := errorCodeForStatus(status)
+	if !strings.HasPrefix(r.URL.Path, apiV1Prefix+"/") {
+		respondWithErrorCode(w, status, code, message)
+		return
+	}
+	respondWithJSON(w, status, envelope{Data: json.RawMessage("null"), Errors: []envelopeError{{Code: code, Message: message}}})
+}
+
+// withDeprecation, öneksiz eski rotaların cevabına kullanımdan kalktığını ve yerine geçen /v1 rotasını bildiren başlıkları ekler.
+func withDeprecation(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+apiV1Prefix+r.URL.Path+`>; rel="successor-version"`)
+		next(w, r)
+	}
+}
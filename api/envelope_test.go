@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithEnvelope(t *testing.T) {
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		wantStatus   int
+		wantData     string
+		wantMessage  string
+		wantCount    int
+		wantErrCode  string
+		wantLocation string
+	}{
+		{
+			name: "List",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				respondWithJSON(w, http.StatusOK, []int{1, 2, 3})
+			},
+			wantStatus: http.StatusOK,
+			wantData:   `[1,2,3]`,
+			wantCount:  3,
+		},
+		{
+			name: "Message Moves To Meta",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				respondWithJSON(w, http.StatusOK, map[string]interface{}{"message": "Week 1 played successfully.", "played_week": 1})
+			},
+			wantStatus:  http.StatusOK,
+			wantData:    `{"played_week":1}`,
+			wantMessage: "Week 1 played successfully.",
+		},
+		{
+			name: "Message Only",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				respondWithJSON(w, http.StatusOK, map[string]string{"message": "League reset successfully."})
+			},
+			wantStatus:  http.StatusOK,
+			wantData:    `null`,
+			wantMessage: "League reset successfully.",
+		},
+		{
+			name: "Service Error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				respondWithErrorCode(w, http.StatusNotFound, "team_not_found", "team not found")
+			},
+			wantStatus:  http.StatusNotFound,
+			wantData:    `null`,
+			wantErrCode: "team_not_found",
+		},
+		{
+			name: "Plain Text Error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "Server Error", http.StatusInternalServerError)
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantData:    `null`,
+			wantErrCode: "internal_error",
+		},
+		{
+			name: "Location Points To V1",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Location", "/teams/5")
+				respondWithJSON(w, http.StatusCreated, map[string]interface{}{"message": "created", "team": map[string]int{"id": 5}})
+			},
+			wantStatus:   http.StatusCreated,
+			wantData:     `{"team":{"id":5}}`,
+			wantMessage:  "created",
+			wantLocation: "/v1/teams/5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			withEnvelope(tt.handler)(w, httptest.NewRequest(http.MethodGet, "/v1/test", nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var body envelope
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("could not decode envelope %q: %v", w.Body.String(), err)
+			}
+			if string(body.Data) != tt.wantData {
+				t.Errorf("data = %s, want %s", body.Data, tt.wantData)
+			}
+			if body.Meta.Message != tt.wantMessage {
+				t.Errorf("meta.message = %q, want %q", body.Meta.Message, tt.wantMessage)
+			}
+			if tt.wantCount > 0 && (body.Meta.Count == nil || *body.Meta.Count != tt.wantCount) {
+				t.Errorf("meta.count = %v, want %d", body.Meta.Count, tt.wantCount)
+			}
+			if body.Errors == nil {
+				t.Errorf("errors must always be a list, got null")
+			}
+			if tt.wantErrCode != "" && (len(body.Errors) != 1 || body.Errors[0].Code != tt.wantErrCode) {
+				t.Errorf("errors = %+v, want one with code %q", body.Errors, tt.wantErrCode)
+			}
+			if tt.wantErrCode == "" && len(body.Errors) != 0 {
+				t.Errorf("errors = %+v, want none", body.Errors)
+			}
+			if got := w.Header().Get("Location"); got != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", got, tt.wantLocation)
+			}
+		})
+	}
+}
+
+func TestWithEnvelope_PassesThroughNonJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	withEnvelope(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	})(w, httptest.NewRequest(http.MethodGet, "/v1/teams/1", nil))
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("got %d %q, want an empty 304", w.Code, w.Body.String())
+	}
+}
+
+func TestWithDeprecation(t *testing.T) {
+	w := httptest.NewRecorder()
+	withDeprecation(func(w http.ResponseWriter, r *http.Request) {
+		if isV1Request(r) {
+			t.Error("legacy request reported as /v1")
+		}
+		respondWithJSON(w, http.StatusOK, []int{})
+	})(w, httptest.NewRequest(http.MethodGet, "/teams/3", nil))
+	if got := w.Header().Get("Deprecation"); got != "true" {
+		t.Errorf("Deprecation = %q, want true", got)
+	}
+	if got, want := w.Header().Get("Link"), `</v1/teams/3>; rel="successor-version"`; got != want {
+		t.Errorf("Link = %q, want %q", got, want)
+	}
+	if w.Body.String() != "[]" {
+		t.Errorf("legacy body = %q, want it unwrapped", w.Body.String())
+	}
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// errorResponse, bütün hata cevaplarının ortak gövdesidir. Error okunabilir açıklama, Code istemcilerin
+// karşılaştırabileceği sabit bir koddur (örn. "team_not_found").
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// serviceErrors, servis katmanının sentinel hatalarını HTTP durumlarına ve hata kodlarına eşler.
+// Bir hata birden fazla sentinel sarıyorsa listede önce gelen kullanılır.
+var serviceErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{abstracts.ErrLeagueBusy, http.StatusConflict, "league_busy"},
+	{abstracts.ErrVersionConflict, http.StatusPreconditionFailed, "version_conflict"},
+	{abstracts.ErrTeamNotFound, http.StatusNotFound, "team_not_found"},
+	{abstracts.ErrMatchNotFound, http.StatusNotFound, "match_not_found"},
+	{abstracts.ErrAuditEntryNotFound, http.StatusNotFound, "audit_entry_not_found"},
+	{abstracts.ErrSeasonNotFound, http.StatusNotFound, "season_not_found"},
+	{abstracts.ErrInvalidStrength, http.StatusBadRequest, "invalid_strength"},
+	{abstracts.ErrDuplicateName, http.StatusConflict, "duplicate_name"},
+	{abstracts.ErrInvalidInput, http.StatusBadRequest, "invalid_input"},
+	{abstracts.ErrInvalidSnapshot, http.StatusBadRequest, "invalid_snapshot"},
+	{abstracts.ErrLeagueFinished, http.StatusConflict, "league_finished"},
+	{abstracts.ErrFixtureMissing, http.StatusConflict, "fixture_missing"},
+	{abstracts.ErrPredictionsUnavailable, http.StatusPreconditionFailed, "predictions_unavailable"},
+	{abstracts.ErrStageNotFinished, http.StatusConflict, "stage_not_finished"},
+	{abstracts.ErrNoTournament, http.StatusConflict, "no_tournament"},
+	{abstracts.ErrSeasonInProgress, http.StatusConflict, "season_in_progress"},
+	{abstracts.ErrCannotRevert, http.StatusConflict, "cannot_revert"},
+	// Sunucu kapanırken süren simülasyonlar iptal edilir; işlem geri alındığından istek tekrar denenebilir.
+	{context.Canceled, http.StatusServiceUnavailable, "request_cancelled"},
+}
+
+// respondWithServiceError, servis hatasını eşlendiği HTTP durumu ve koduyla döner.
+// Tanınmayan hatalar 500 olur; action mesajın başına eklenir (örn. "Error playing next week").
+func respondWithServiceError(w http.ResponseWriter, err error, action string) {
+	for _, mapping := range serviceErrors {
+		if errors.Is(err, mapping.err) {
+			respondWithErrorCode(w, mapping.status, mapping.code, err.Error())
+			return
+		}
+	}
+	respondWithErrorCode(w, http.StatusInternalServerError, errorCodeForStatus(http.StatusInternalServerError), action+": "+err.Error())
+}
+
+// errorCodeForStatus, servis hatasından gelmeyen cevaplar için HTTP durumundan bir kod üretir, örn. 400 -> "bad_request".
+func errorCodeForStatus(status int) string {
+	if status == http.StatusInternalServerError {
+		return "internal_error"
+	}
+	text := http.StatusText(status)
+	if text == "" {
+		return "error"
+	}
+	return strings.ToLower(strings.NewReplacer(" ", "_", "-", "_").Replace(text))
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRespondWithServiceError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+	}{
+		{
+			name:        "Wrapped Not Found",
+			err:         fmt.Errorf("TeamService.GetTeamByID: %w (ID: %d)", abstracts.ErrTeamNotFound, 7),
+			wantStatus:  http.StatusNotFound,
+			wantCode:    "team_not_found",
+			wantMessage: "TeamService.GetTeamByID: team not found (ID: 7)",
+		},
+		{
+			name:        "Invalid Strength",
+			err:         fmt.Errorf("TeamService.UpdateTeamStrength: %w: %d", abstracts.ErrInvalidStrength, 0),
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "invalid_strength",
+			wantMessage: "TeamService.UpdateTeamStrength: invalid strength value: 0",
+		},
+		{
+			name:        "League Finished",
+			err:         fmt.Errorf("LeagueService.PlayNextWeek: %w", abstracts.ErrLeagueFinished),
+			wantStatus:  http.StatusConflict,
+			wantCode:    "league_finished",
+			wantMessage: "LeagueService.PlayNextWeek: " + abstracts.ErrLeagueFinished.Error(),
+		},
+		{
+			name:        "Busy Wins Over Later Sentinels",
+			err:         fmt.Errorf("%w: %w", abstracts.ErrLeagueBusy, abstracts.ErrInvalidInput),
+			wantStatus:  http.StatusConflict,
+			wantCode:    "league_busy",
+			wantMessage: abstracts.ErrLeagueBusy.Error() + ": " + abstracts.ErrInvalidInput.Error(),
+		},
+		{
+			name:        "Cancelled By Shutdown",
+			err:         fmt.Errorf("LeagueService.PlayNextWeek: Simulation of week %d cancelled: %w", 3, context.Canceled),
+			wantStatus:  http.StatusServiceUnavailable,
+			wantCode:    "request_cancelled",
+			wantMessage: "LeagueService.PlayNextWeek: Simulation of week 3 cancelled: context canceled",
+		},
+		{
+			name:        "Unknown Error",
+			err:         errors.New("connection refused"),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    "internal_error",
+			wantMessage: "Error playing next week: connection refused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			respondWithServiceError(w, tt.err, "Error playing next week")
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var body errorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("could not decode error body %q: %v", w.Body.String(), err)
+			}
+			if body.Code != tt.wantCode || body.Error != tt.wantMessage {
+				t.Errorf("body = %+v, want code %q and error %q", body, tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestErrorCodeForStatus(t *testing.T) {
+	tests := map[int]string{
+		http.StatusBadRequest:           "bad_request",
+		http.StatusMethodNotAllowed:     "method_not_allowed",
+		http.StatusPreconditionRequired: "precondition_required",
+		http.StatusInternalServerError:  "internal_error",
+		599:                             "error",
+	}
+	for status, want := range tests {
+		if got := errorCodeForStatus(status); got != want {
+			t.Errorf("errorCodeForStatus(%d) = %q, want %q", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// versionETag, cevaba giren kayıtların sürümlerinden güçlü (strong) bir ETag üretir, örn. "3".
+// Cevap birden fazla kayıttan oluşuyorsa sürümler sırayla birleştirilir, örn. maç ve iki takımı için "3-12-9".
+func versionETag(versions ...int) string {
+	parts := make([]string, len(versions))
+	for i, version := range versions {
+		parts[i] = strconv.Itoa(version)
+	}
+	return `"` + strings.Join(parts, "-") + `"`
+}
+
+// setVersionETag, cevaba kayıtların güncel sürümlerini ETag olarak ekler.
+func setVersionETag(w http.ResponseWriter, versions ...int) {
+	w.Header().Set("ETag", versionETag(versions...))
+}
+
+// notModified, If-None-Match güncel ETag ile eşleşiyorsa 304 döner ve true verir.
+func notModified(w http.ResponseWriter, r *http.Request, versions ...int) bool {
+	ifNoneMatch := strings.TrimSpace(r.Header.Get("If-None-Match"))
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == versionETag(versions...) {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// parseIfMatch, If-Match başlığındaki sürümü döndürür. Başlık yoksa veya "*" ise 0 döner; bu durumda sürüm kontrolü yapılmaz.
+func parseIfMatch(r *http.Request) (int, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, nil
+	}
+	if strings.HasPrefix(ifMatch, "W/") {
+		return 0, fmt.Errorf("If-Match needs a strong ETag such as \"3\", got %s", ifMatch)
+	}
+	version, err := strconv.Atoi(strings.Trim(ifMatch, `"`))
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("If-Match must be a single ETag such as \"3\", got %s", ifMatch)
+	}
+	return version, nil
+}
+
+// parseMatchIfMatch, maç düzenlemelerinin If-Match başlığındaki maç sürümünü döndürür. GET /matches/{id}'nin
+// "3-12-9" biçimindeki ETag'i de kabul edilir; düzenleme yalnızca maç satırını değiştirdiği için takım sürümleri
+// karşılaştırılmaz. Tek sürümlü "3" biçimi eski istemciler için geçerliliğini korur.
+func parseMatchIfMatch(r *http.Request) (int, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	matchTag, teamTags, composite := strings.Cut(strings.Trim(ifMatch, `"`), "-")
+	if !composite || strings.HasPrefix(ifMatch, "W/") || strings.Contains(ifMatch, ",") {
+		return parseIfMatch(r)
+	}
+	version, err := strconv.Atoi(matchTag)
+	homeTag, awayTag, ok := strings.Cut(teamTags, "-")
+	_, homeErr := strconv.Atoi(homeTag)
+	_, awayErr := strconv.Atoi(awayTag)
+	if err != nil || version < 1 || !ok || homeErr != nil || awayErr != nil {
+		return 0, fmt.Errorf("If-Match must be a single ETag such as \"3-12-9\", got %s", ifMatch)
+	}
+	return version, nil
+}
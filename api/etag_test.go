@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header      string
+		wantVersion int
+		wantErr     bool
+	}{
+		{header: "", wantVersion: 0},
+		{header: "*", wantVersion: 0},
+		{header: `"3"`, wantVersion: 3},
+		{header: `W/"3"`, wantErr: true},
+		{header: `"abc"`, wantErr: true},
+		{header: `"0"`, wantErr: true},
+		{header: `"1", "2"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/matches/1", nil)
+			if tt.header != "" {
+				r.Header.Set("If-Match", tt.header)
+			}
+			version, err := parseIfMatch(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseIfMatch(%q) error = %v, wantErr %v", tt.header, err, tt.wantErr)
+			}
+			if version != tt.wantVersion {
+				t.Errorf("parseIfMatch(%q) = %d, want %d", tt.header, version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{header: "", want: false},
+		{header: `"4"`, want: true},
+		{header: `W/"4"`, want: true},
+		{header: `"3", "4"`, want: true},
+		{header: `"3"`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/teams/1", nil)
+			if tt.header != "" {
+				r.Header.Set("If-None-Match", tt.header)
+			}
+			recorder := httptest.NewRecorder()
+			if got := notModified(recorder, r, 4); got != tt.want {
+				t.Fatalf("notModified with If-None-Match %q = %v, want %v", tt.header, got, tt.want)
+			}
+			if tt.want && recorder.Code != http.StatusNotModified {
+				t.Errorf("Expected status 304, got %d", recorder.Code)
+			}
+		})
+	}
+}
+
+func TestParseMatchIfMatch(t *testing.T) {
+	tests := []struct {
+		header      string
+		wantVersion int
+		wantErr     bool
+	}{
+		{header: "", wantVersion: 0},
+		{header: "*", wantVersion: 0},
+		{header: `"3"`, wantVersion: 3},
+		{header: `"3-12-9"`, wantVersion: 3},
+		{header: `"3-12-0"`, wantVersion: 3},
+		{header: `W/"3-12-9"`, wantErr: true},
+		{header: `"3-12"`, wantErr: true},
+		{header: `"3-12-9-1"`, wantErr: true},
+		{header: `"0-12-9"`, wantErr: true},
+		{header: `"3-12-9", "4-12-9"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/matches/1", nil)
+			if tt.header != "" {
+				r.Header.Set("If-Match", tt.header)
+			}
+			version, err := parseMatchIfMatch(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMatchIfMatch(%q) error = %v, wantErr %v", tt.header, err, tt.wantErr)
+			}
+			if version != tt.wantVersion {
+				t.Errorf("parseMatchIfMatch(%q) = %d, want %d", tt.header, version, tt.wantVersion)
+			}
+		})
+	}
+}
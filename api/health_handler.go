@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// readinessCheckTimeout, tek bir hazırlık kontrolüne tanınan süredir; takılan bir veritabanı /readyz'i kilitlememelidir.
+const readinessCheckTimeout = 2 * time.Second
+
+// ReadinessCheck, /readyz'in çalıştırdığı adlı bir kontroldür (örn. veritabanı ping'i, bekleyen migration'lar).
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// healthResponse, /healthz ve /readyz cevabıdır. Checks, kontrol adından "ok" veya hata mesajına eşlenir.
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// HealthHandler, canlılık (/healthz) ve hazırlık (/readyz) uç noktalarını sunar.
+// Kapanış başladığında SetDraining çağrılır; böylece yük dengeleyici yeni istek göndermeyi bırakır.
+type HealthHandler struct {
+	checks   []ReadinessCheck
+	draining atomic.Bool
+}
+
+// NewHealthHandler, verilen hazırlık kontrolleriyle yeni bir HealthHandler örneği oluşturur.
+func NewHealthHandler(checks ...ReadinessCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+// SetDraining, sunucunun kapanmakta olduğunu işaretler; bundan sonra /readyz 503 döner.
+func (h *HealthHandler) SetDraining() {
+	h.draining.Store(true)
+}
+
+// GetHealthHandler, süreç istek karşılayabildiği sürece 200 döner. Bağımlılıkları kontrol etmez.
+func (h *HealthHandler) GetHealthHandler(w http.ResponseWriter, r *http.Request) {
+	respondWithJSON(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// GetReadyHandler, bütün hazırlık kontrolleri geçerse 200, biri bile başarısızsa veya sunucu kapanıyorsa 503 döner.
+func (h *HealthHandler) GetReadyHandler(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		respondWithJSON(w, http.StatusServiceUnavailable, healthResponse{Status: "draining"})
+		return
+	}
+	response := healthResponse{Status: "ready", Checks: make(map[string]string, len(h.checks))}
+	status := http.StatusOK
+	for _, check := range h.checks {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessCheckTimeout)
+		err := check.Check(ctx)
+		cancel()
+		if err != nil {
+			slog.WarnContext(r.Context(), "readiness check failed", "check", check.Name, "error", err)
+			response.Checks[check.Name] = err.Error()
+			response.Status = "not_ready"
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		response.Checks[check.Name] = "ok"
+	}
+	respondWithJSON(w, status, response)
+}
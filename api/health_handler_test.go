@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthHandler(t *testing.T) {
+	ok := ReadinessCheck{Name: "database", Check: func(ctx context.Context) error { return nil }}
+	pending := ReadinessCheck{Name: "migrations", Check: func(ctx context.Context) error { return errors.New("2 pending migrations") }}
+
+	tests := []struct {
+		name       string
+		checks     []ReadinessCheck
+		draining   bool
+		wantStatus int
+		wantBody   healthResponse
+	}{
+		{name: "No Checks", wantStatus: http.StatusOK, wantBody: healthResponse{Status: "ready", Checks: map[string]string{}}},
+		{name: "All Pass", checks: []ReadinessCheck{ok}, wantStatus: http.StatusOK, wantBody: healthResponse{Status: "ready", Checks: map[string]string{"database": "ok"}}},
+		{name: "One Fails", checks: []ReadinessCheck{ok, pending}, wantStatus: http.StatusServiceUnavailable,
+			wantBody: healthResponse{Status: "not_ready", Checks: map[string]string{"database": "ok", "migrations": "2 pending migrations"}}},
+		{name: "Draining", checks: []ReadinessCheck{ok}, draining: true, wantStatus: http.StatusServiceUnavailable, wantBody: healthResponse{Status: "draining"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHealthHandler(tt.checks...)
+			if tt.draining {
+				handler.SetDraining()
+			}
+
+			w := httptest.NewRecorder()
+			handler.GetHealthHandler(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			if w.Code != http.StatusOK {
+				t.Errorf("/healthz status = %d, want 200 regardless of readiness", w.Code)
+			}
+
+			w = httptest.NewRecorder()
+			handler.GetReadyHandler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("/readyz status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var got healthResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("could not decode %s: %v", w.Body.String(), err)
+			}
+			if got.Status != tt.wantBody.Status || len(got.Checks) != len(tt.wantBody.Checks) {
+				t.Fatalf("/readyz body = %+v, want %+v", got, tt.wantBody)
+			}
+			for name, want := range tt.wantBody.Checks {
+				if got.Checks[name] != want {
+					t.Errorf("check %s = %q, want %q", name, got.Checks[name], want)
+				}
+			}
+		})
+	}
+}
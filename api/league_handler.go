@@ -1,27 +1,29 @@
 package api
 
 import (
+	"MatchSimulator_Insider/logging"
 	"MatchSimulator_Insider/models"
 	"MatchSimulator_Insider/services/abstracts"
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"sort"
-	"strings"
 )
 
 type LeagueHandler struct {
 	leagueService abstracts.ILeagueService
 	teamService   abstracts.TeamService   
 	matchService  abstracts.IMatchService 
+	auditService  abstracts.AuditService
 }
 
 // NewLeagueHandler, yeni bir LeagueHandler örneği oluşturur.
-func NewLeagueHandler(ls abstracts.ILeagueService, ts abstracts.TeamService, ms abstracts.IMatchService) *LeagueHandler {
+func NewLeagueHandler(ls abstracts.ILeagueService, ts abstracts.TeamService, ms abstracts.IMatchService, as abstracts.AuditService) *LeagueHandler {
 	return &LeagueHandler{
 		leagueService: ls,
 		teamService:   ts,
 		matchService:  ms,
+		auditService:  as,
 	}
 }
 
@@ -38,13 +40,17 @@ func (h *LeagueHandler) GetLeagueTable(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(table) == 0 {
+	// /v1 her durumda liste döner; boş tablo mesajı yalnızca eski rotanın cevap şeklidir.
+	if len(table) == 0 && !isV1Request(r) {
 		respondWithJSON(w, http.StatusOK, map[string]interface{}{
 			"message": "League table is currently empty. Fixture might not be generated or no matches played yet.",
 			"table":   []models.Team{},
 		})
 		return
 	}
+	if table == nil {
+		table = []models.Team{}
+	}
 	respondWithJSON(w, http.StatusOK, table)
 }
 
@@ -58,24 +64,22 @@ func (h *LeagueHandler) PlayNextWeek(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	playedWeek, weekMatches, leagueTable, err := h.leagueService.PlayNextWeek(ctx)
 	if err != nil {
-		if strings.Contains(err.Error(), "Fikstür eksik") { // Varsayım: Hata mesajı bu şekilde olabilir.
-			respondWithError(w, http.StatusConflict, err.Error())
-			return
-		}
-		if playedWeek == 0 && (weekMatches == nil || len(weekMatches) == 0) {
-			finalTable, tableErr := h.leagueService.GetLeagueTable(ctx)
-			if tableErr == nil && finalTable != nil {
-				logLeagueTableToConsole(fmt.Sprintf("Final League Table (after trying to play next week, league ended)"), finalTable)
+		if errors.Is(err, abstracts.ErrLeagueFinished) {
+			if leagueTable != nil {
+				logLeagueTable(ctx, "final league table, the league has already finished", leagueTable)
+			}
+			// Eski rota, bitmiş ligde hâlâ 200 ve mesaj döner; 409 league_finished yalnızca /v1'e aittir.
+			if !isV1Request(r) {
+				respondWithJSON(w, http.StatusOK, map[string]string{"message": "League already completed or no matches to play."})
+				return
 			}
-			respondWithJSON(w, http.StatusOK, map[string]string{"message": "League already completed or no matches to play."})
-			return
 		}
-		respondWithError(w, http.StatusInternalServerError, "Error playing next week: "+err.Error())
+		respondWithServiceError(w, err, "Error playing next week")
 		return
 	}
 
 	if leagueTable != nil {
-		logLeagueTableToConsole(fmt.Sprintf("League Table after Week %d played via /next-week", playedWeek), leagueTable)
+		logLeagueTable(ctx, "league table after playing the next week", leagueTable, logging.KeyWeek, playedWeek)
 	}
 
 	response := struct {
@@ -89,11 +93,7 @@ func (h *LeagueHandler) PlayNextWeek(w http.ResponseWriter, r *http.Request) {
 		LeagueTable: leagueTable,
 	}
 
-	if playedWeek == 0 && (weekMatches == nil || len(weekMatches) == 0) {
-		response.Message = "League completed. No more weeks to play."
-	} else {
-		response.Message = fmt.Sprintf("Week %d played successfully.", playedWeek)
-	}
+	response.Message = fmt.Sprintf("Week %d played successfully.", playedWeek)
 	respondWithJSON(w, http.StatusOK, response)
 }
 
@@ -110,12 +110,7 @@ func (h *LeagueHandler) GetCurrentWeekInfo(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	leagueTable, tableErr := h.leagueService.GetLeagueTable(ctx)
-	if tableErr != nil {
-		log.Printf("GetCurrentWeekInfo: Error retrieving league table for logging: %v", tableErr)
-	} else if leagueTable != nil {
-		logLeagueTableToConsole(fmt.Sprintf("Current League Table when /current-week (next playable: %d) was called", currentWeek), leagueTable)
-	}
+	logLeagueTableFrom(ctx, h.leagueService, "league table when the current week was requested", logging.KeyWeek, currentWeek)
 
 	var message, leagueStatus string
 	if currentWeek == -1 {
@@ -149,13 +144,8 @@ func (h *LeagueHandler) GetPredictions(w http.ResponseWriter, r *http.Request) {
 	}
 	ctx := r.Context()
 
-	leagueTable, tableErr := h.leagueService.GetLeagueTable(ctx)
-	if tableErr != nil {
-		log.Printf("GetPredictions: Error retrieving league table for logging: %v", tableErr)
-	} else if leagueTable != nil {
-		logLeagueTableToConsole("League Table when /predictions was called", leagueTable)
-	}
-	
+	logLeagueTableFrom(ctx, h.leagueService, "league table when predictions were requested")
+
 	allTeams, err := h.teamService.GetAllTeams(ctx)
 	if err != nil || len(allTeams) == 0 {
 		respondWithError(w, http.StatusInternalServerError, "Could not retrieve team information for predictions or no teams exist.")
@@ -164,17 +154,19 @@ func (h *LeagueHandler) GetPredictions(w http.ResponseWriter, r *http.Request) {
 
 	predictionsByID, err := h.leagueService.GetChampionshipPredictions(ctx)
 	if err != nil {
-		if strings.Contains(err.Error(), "en az 4 hafta tamamlandıktan sonra") {
-			respondWithError(w, http.StatusPreconditionFailed, err.Error())
-			return
-		}
-		respondWithError(w, http.StatusInternalServerError, "Error retrieving championship predictions: "+err.Error())
+		respondWithServiceError(w, err, "Error retrieving championship predictions")
+		return
+	}
+	clinchedID, err := h.leagueService.GetClinchedChampion(ctx)
+	if err != nil {
+		respondWithServiceError(w, err, "Error checking whether the title is clinched")
 		return
 	}
 	type predictionDisplayItem struct {
 		TeamName    string  `json:"team_name"`
 		TeamID      int     `json:"team_id"`
 		Probability float64 `json:"probability_percentage"`
+		Clinched    bool    `json:"clinched"` // Takım şampiyonluğu matematiksel olarak garantiledi
 	}
 	var displayPredictions []predictionDisplayItem
 	var teamIDs []int
@@ -192,7 +184,7 @@ func (h *LeagueHandler) GetPredictions(w http.ResponseWriter, r *http.Request) {
 				break
 			}
 		}
-		displayPredictions = append(displayPredictions, predictionDisplayItem{TeamName: teamName, TeamID: teamID, Probability: prob * 100})
+		displayPredictions = append(displayPredictions, predictionDisplayItem{TeamName: teamName, TeamID: teamID, Probability: prob * 100, Clinched: teamID == clinchedID})
 	}
 	sort.Slice(displayPredictions, func(i, j int) bool {
 		return displayPredictions[i].Probability > displayPredictions[j].Probability
@@ -208,17 +200,19 @@ func (h *LeagueHandler) ResetLeague(w http.ResponseWriter, r *http.Request) {
 	}
 	ctx := r.Context()
 	if err := h.leagueService.ResetLeague(ctx); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Error resetting league: "+err.Error())
+		respondWithServiceError(w, err, "Error resetting league")
 		return
 	}
-
-	leagueTable, tableErr := h.leagueService.GetLeagueTable(ctx)
-	if tableErr != nil {
-		log.Printf("ResetLeague: Error retrieving league table for logging after reset: %v", tableErr)
-	} else if leagueTable != nil {
-		logLeagueTableToConsole("League Table after /reset-league", leagueTable)
+	if !recordAudit(w, r, h.auditService, models.AuditEntry{
+		EntityType: models.AuditEntityLeague,
+		Action:     models.AuditActionLeagueReset,
+		Reason:     r.URL.Query().Get("reason"),
+	}) {
+		return
 	}
 
+	logLeagueTableFrom(ctx, h.leagueService, "league table after resetting the league")
+
 	respondWithJSON(w, http.StatusOK, map[string]string{"message": "League reset successfully. Team statistics and fixture have been renewed."})
 }
 
@@ -231,12 +225,12 @@ func (h *LeagueHandler) PlayAllRemainingWeeks(w http.ResponseWriter, r *http.Req
 	ctx := r.Context()
 	allPlayedMatches, finalTable, err := h.leagueService.PlayAllRemainingWeeks(ctx)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Error playing all remaining weeks: "+err.Error())
+		respondWithServiceError(w, err, "Error playing all remaining weeks")
 		return
 	}
 
 	if finalTable != nil {
-		logLeagueTableToConsole("Final League Table after /play-all", finalTable)
+		logLeagueTable(ctx, "final league table after playing all weeks", finalTable)
 	}
 
 	response := struct {
@@ -263,3 +257,51 @@ func (h *LeagueHandler) PlayAllRemainingWeeks(w http.ResponseWriter, r *http.Req
 	}
 	respondWithJSON(w, http.StatusOK, response)
 }
+
+// RevertLastPlayedWeek, son oynanan haftayı geri alır: maçlar oynanmamış hâle gelir ve sonuçları istatistiklerden çıkarılır.
+func (h *LeagueHandler) RevertLastPlayedWeek(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only POST method is supported for this endpoint.")
+		return
+	}
+	ctx := r.Context()
+	revertedWeek, revertedMatches, err := h.leagueService.RevertLastPlayedWeek(ctx)
+	if err != nil {
+		respondWithServiceError(w, err, "Error reverting the last played week")
+		return
+	}
+	previousScores := make([]weekRevertAuditValue, 0, len(revertedMatches))
+	for _, match := range revertedMatches {
+		previousScores = append(previousScores, weekRevertAuditValue{
+			MatchID:         match.ID,
+			scoreAuditValue: scoreAuditValue{HomeGoals: match.HomeGoals, AwayGoals: match.AwayGoals, IsForfeit: match.IsForfeit},
+		})
+	}
+	if !recordAudit(w, r, h.auditService, models.AuditEntry{
+		EntityType: models.AuditEntityLeague,
+		Action:     models.AuditActionWeekRevert,
+		OldValue:   auditValue(map[string]interface{}{"week": revertedWeek, "matches": previousScores}),
+		Reason:     r.URL.Query().Get("reason"),
+	}) {
+		return
+	}
+
+	currentWeek, err := h.leagueService.GetCurrentWeek(ctx)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Week reverted but the current week could not be determined: "+err.Error())
+		return
+	}
+	leagueTable, err := h.leagueService.GetLeagueTable(ctx)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Week reverted but the league table could not be retrieved: "+err.Error())
+		return
+	}
+	logLeagueTable(ctx, "league table after reverting a week", leagueTable, logging.KeyWeek, revertedWeek)
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message":               fmt.Sprintf("Week %d reverted. Its %d match(es) are unplayed again.", revertedWeek, len(revertedMatches)),
+		"reverted_week":         revertedWeek,
+		"reverted_matches":      revertedMatches,
+		"current_playable_week": currentWeek,
+		"league_table":          leagueTable,
+	})
+}
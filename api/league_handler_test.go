@@ -0,0 +1,64 @@
+package api
+
+import (
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/concretes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestPlayNextWeek_LeagueFinished checks that a finished league keeps the legacy 200 message on the
+// unprefixed route and answers 409 league_finished only on /v1.
+func TestPlayNextWeek_LeagueFinished(t *testing.T) {
+	ctx := context.Background()
+	teamService, matchService := concretes.NewMemoryTeamService(), concretes.NewMemoryMatchService()
+	for _, name := range []string{"Chelsea", "Arsenal"} {
+		if _, err := teamService.CreateTeam(ctx, models.Team{Name: name, Strength: 80}); err != nil {
+			t.Fatalf("Could not create team %s: %v", name, err)
+		}
+	}
+	teams, _ := teamService.GetAllTeams(ctx)
+	if err := matchService.GenerateAndStoreFixture(ctx, teams); err != nil {
+		t.Fatalf("Could not generate the fixture: %v", err)
+	}
+	leagueService := concretes.NewLeagueService(teamService, matchService, nil)
+	if _, _, err := leagueService.PlayAllRemainingWeeks(ctx); err != nil {
+		t.Fatalf("Could not play the league: %v", err)
+	}
+	handler := NewLeagueHandler(leagueService, teamService, matchService, nil)
+
+	tests := []struct {
+		name       string
+		v1         bool
+		wantStatus int
+		wantField  string
+		wantValue  string
+	}{
+		{name: "legacy route", wantStatus: http.StatusOK, wantField: "message", wantValue: "League already completed or no matches to play."},
+		{name: "v1 route", v1: true, wantStatus: http.StatusConflict, wantField: "code", wantValue: "league_finished"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/next-week", nil)
+			if tt.v1 {
+				r = r.WithContext(context.WithValue(r.Context(), v1RequestKey{}, true))
+			}
+			w := httptest.NewRecorder()
+			handler.PlayNextWeek(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("PlayNextWeek = %d, want %d (body: %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			var body map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Could not decode %s: %v", w.Body.String(), err)
+			}
+			if body[tt.wantField] != tt.wantValue {
+				t.Errorf("%s = %v, want %q", tt.wantField, body[tt.wantField], tt.wantValue)
+			}
+		})
+	}
+}
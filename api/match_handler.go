@@ -1,25 +1,256 @@
 package api
 
 import (
+	"MatchSimulator_Insider/logging"
+	"MatchSimulator_Insider/models"
 	"MatchSimulator_Insider/services/abstracts"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 )
 
 type MatchHandler struct {
 	leagueService abstracts.ILeagueService
+	matchService  abstracts.IMatchService
+	teamService   abstracts.TeamService
+	auditService  abstracts.AuditService
 }
 
-func NewMatchHandler(ls abstracts.ILeagueService) *MatchHandler {
+func NewMatchHandler(ls abstracts.ILeagueService, ms abstracts.IMatchService, ts abstracts.TeamService, as abstracts.AuditService) *MatchHandler {
 	return &MatchHandler{
 		leagueService: ls,
+		matchService:  ms,
+		teamService:   ts,
+		auditService:  as,
 	}
 }
 
+// matchView, okuma endpoint'lerinin döndürdüğü maçtır; takım ID'lerinin yanında takım isimlerini de taşır.
+type matchView struct {
+	models.Match
+	HomeTeamName   string `json:"home_team_name"`
+	AwayTeamName   string `json:"away_team_name"`
+	WinnerTeamName string `json:"winner_team_name,omitempty"`
+}
+
+// matchFilter, GET /matches sorgu parametreleridir. Sıfır değerler süzme yapmaz.
+type matchFilter struct {
+	Week   int
+	TeamID int
+	Played *bool
+	Stage  string
+}
+
+// parseMatchFilter, week, team, played ve stage sorgu parametrelerini okur.
+func parseMatchFilter(query url.Values) (matchFilter, error) {
+	var filter matchFilter
+	for _, param := range []struct {
+		name   string
+		target *int
+	}{{"week", &filter.Week}, {"team", &filter.TeamID}} {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return matchFilter{}, fmt.Errorf("Invalid %s: Must be a positive number.", param.name)
+		}
+		*param.target = parsed
+	}
+	if value := query.Get("played"); value != "" {
+		played, err := strconv.ParseBool(value)
+		if err != nil {
+			return matchFilter{}, errors.New("Invalid played: Must be true or false.")
+		}
+		filter.Played = &played
+	}
+	switch stage := query.Get("stage"); stage {
+	case "", models.StageLeague, models.StageGroup, models.StageKnockout, models.StagePlayoff:
+		filter.Stage = stage
+	default:
+		return matchFilter{}, errors.New("Invalid stage: Must be league, group, knockout or playoff.")
+	}
+	return filter, nil
+}
+
+// matches, maçın süzgeçten geçip geçmediğini söyler.
+func (f matchFilter) matches(match models.Match) bool {
+	if f.Week != 0 && match.Week != f.Week {
+		return false
+	}
+	if f.TeamID != 0 && match.HomeTeamID != f.TeamID && match.AwayTeamID != f.TeamID {
+		return false
+	}
+	if f.Played != nil && match.IsPlayed != *f.Played {
+		return false
+	}
+	return f.Stage == "" || match.Stage == f.Stage
+}
+
+// matchViews, maçlara takım isimlerini ekler. Silinmiş ya da bilinmeyen takımların ismi boş kalır.
+func (h *MatchHandler) matchViews(ctx context.Context, matches []models.Match) ([]matchView, error) {
+	teams, err := h.teamService.GetAllTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildMatchViews(matches, teams), nil
+}
+
+// buildMatchViews, maçlara verilen takımların isimlerini ekler.
+func buildMatchViews(matches []models.Match, teams []models.Team) []matchView {
+	names := make(map[int]string, len(teams))
+	for _, team := range teams {
+		names[team.ID] = team.Name
+	}
+	views := make([]matchView, 0, len(matches))
+	for _, match := range matches {
+		view := matchView{Match: match, HomeTeamName: names[match.HomeTeamID], AwayTeamName: names[match.AwayTeamID]}
+		if match.WinnerTeamID != nil {
+			view.WinnerTeamName = names[*match.WinnerTeamID]
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+// matchVersions, bir maç cevabının ETag'ine giren sürümleri döndürür: maçın ve iki takımının sürümü.
+// Cevapta takım isimleri de yer aldığından bir takım yeniden adlandırılınca ETag de değişmelidir.
+// Silinmiş bir takımın sürümü 0 sayılır.
+func matchVersions(match models.Match, teams []models.Team) []int {
+	versions := []int{match.Version, 0, 0}
+	for _, team := range teams {
+		switch team.ID {
+		case match.HomeTeamID:
+			versions[1] = team.Version
+		case match.AwayTeamID:
+			versions[2] = team.Version
+		}
+	}
+	return versions
+}
+
+// GetMatchesHandler, maçları hafta ve ID sırasıyla listeler.
+// week, team (ev sahibi ya da deplasman), played (true/false) ve stage sorgu parametreleri ile süzülebilir.
+func (h *MatchHandler) GetMatchesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	filter, err := parseMatchFilter(r.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ctx := r.Context()
+	var matches []models.Match
+	if filter.Week != 0 {
+		matches, err = h.matchService.GetMatchesByWeek(ctx, filter.Week)
+	} else {
+		matches, err = h.matchService.GetAllMatches(ctx)
+	}
+	if err != nil {
+		respondWithServiceError(w, err, "Error retrieving matches")
+		return
+	}
+	filtered := make([]models.Match, 0, len(matches))
+	for _, match := range matches {
+		if filter.matches(match) {
+			filtered = append(filtered, match)
+		}
+	}
+	views, err := h.matchViews(ctx, filtered)
+	if err != nil {
+		respondWithServiceError(w, err, "Error retrieving team names for matches")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, views)
+}
+
+// GetWeekHandler, bir haftanın maçlarını ve haftanın tamamen oynanıp oynanmadığını döndürür.
+func (h *MatchHandler) GetWeekHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	week, err := strconv.Atoi(r.PathValue("week"))
+	if err != nil || week < 1 {
+		respondWithError(w, http.StatusBadRequest, "Invalid week: Must be a positive number.")
+		return
+	}
+	ctx := r.Context()
+	matches, err := h.matchService.GetMatchesByWeek(ctx, week)
+	if err != nil {
+		respondWithServiceError(w, err, "Error retrieving matches for week")
+		return
+	}
+	if len(matches) == 0 {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("Week %d has no matches in the fixture.", week))
+		return
+	}
+	views, err := h.matchViews(ctx, matches)
+	if err != nil {
+		respondWithServiceError(w, err, "Error retrieving team names for matches")
+		return
+	}
+	played := 0
+	for _, match := range matches {
+		if match.IsPlayed {
+			played++
+		}
+	}
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"week":           week,
+		"is_played":      played == len(matches),
+		"played_matches": played,
+		"matches":        views,
+	})
+}
+
+// scoreAuditEntry, bir maçın skor değişikliği için denetim kaydı hazırlar. previous nil ise eski skor boş kalır.
+func (h *MatchHandler) scoreAuditEntry(r *http.Request, matchID int, action string, previous *models.Match, reason string) models.AuditEntry {
+	entry := models.AuditEntry{EntityType: models.AuditEntityMatch, EntityID: matchID, Action: action, Reason: reason}
+	if previous != nil {
+		entry.OldValue = auditValue(scoreAuditValue{HomeGoals: previous.HomeGoals, AwayGoals: previous.AwayGoals, IsForfeit: previous.IsForfeit})
+	}
+	if updated, err := h.matchService.GetMatchByID(r.Context(), matchID); err == nil {
+		entry.NewValue = auditValue(scoreAuditValue{HomeGoals: updated.HomeGoals, AwayGoals: updated.AwayGoals, IsForfeit: updated.IsForfeit})
+	}
+	return entry
+}
+
+// GetMatchHandler, bir maçı döndürür. ETag maçın ve iki takımının sürümünden oluşur (örn. "3-12-9");
+// skor düzeltmesinde If-Match ile geri gönderilebilir.
+func (h *MatchHandler) GetMatchHandler(w http.ResponseWriter, r *http.Request) {
+	matchID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid match ID: Must be a number.")
+		return
+	}
+	match, err := h.matchService.GetMatchByID(r.Context(), matchID)
+	if err != nil {
+		respondWithServiceError(w, err, "Error retrieving match")
+		return
+	}
+	teams, err := h.teamService.GetAllTeams(r.Context())
+	if err != nil {
+		respondWithServiceError(w, err, "Error retrieving team names for match")
+		return
+	}
+	versions := matchVersions(*match, teams)
+	if notModified(w, r, versions...) {
+		return
+	}
+	setVersionETag(w, versions...)
+	respondWithJSON(w, http.StatusOK, buildMatchViews([]models.Match{*match}, teams)[0])
+}
+
 // EditMatchScoreHandler, belirli bir maçın skorunu düzenler.
 func (h *MatchHandler) EditMatchScoreHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
@@ -43,7 +274,7 @@ func (h *MatchHandler) EditMatchScoreHandler(w http.ResponseWriter, r *http.Requ
 	}
 	var reqBody EditMatchScoreRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		respondWithBodyError(w, "Invalid request body", err)
 		return
 	}
 	defer r.Body.Close()
@@ -52,23 +283,32 @@ func (h *MatchHandler) EditMatchScoreHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	err = h.leagueService.HandleMatchScoreEdit(ctx, matchID, reqBody.HomeGoals, reqBody.AwayGoals)
+	expectedVersion, err := parseMatchIfMatch(r)
 	if err != nil {
-		if strings.Contains(err.Error(), "bulunamadı") {
-			respondWithError(w, http.StatusNotFound, err.Error())
-		} else {
-			respondWithError(w, http.StatusInternalServerError, "Error editing match score: "+err.Error())
-		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	previous, _ := h.matchService.GetMatchByID(ctx, matchID) // Eski skor denetim kaydı içindir; maç yoksa düzenleme hatayı bildirir
+	err = h.leagueService.HandleMatchScoreEdit(ctx, matchID, reqBody.HomeGoals, reqBody.AwayGoals, expectedVersion)
+	if err != nil {
+		respondWithServiceError(w, err, "Error editing match score")
+		return
+	}
+	if !recordAudit(w, r, h.auditService, h.scoreAuditEntry(r, matchID, models.AuditActionScoreEdit, previous, reqBody.Reason)) {
 		return
 	}
 
 	updatedLeagueTable, tableErr := h.leagueService.GetLeagueTable(ctx)
 	if tableErr != nil {
-		log.Printf("EditMatchScoreHandler: Match score edited but error retrieving updated league table for logging: %v", tableErr)
+		slog.WarnContext(ctx, "match score edited but the league table could not be retrieved", logging.KeyMatchID, matchID, "error", tableErr)
 	} else if updatedLeagueTable != nil {
-		logLeagueTableToConsole(fmt.Sprintf("League Table after editing Match ID %d to %d-%d", matchID, reqBody.HomeGoals, reqBody.AwayGoals), updatedLeagueTable)
+		logLeagueTable(ctx, "league table after editing a match score", updatedLeagueTable, logging.KeyMatchID, matchID, "home_goals", reqBody.HomeGoals, "away_goals", reqBody.AwayGoals)
 	}
 
+	if editedMatch, matchErr := h.matchService.GetMatchByID(ctx, matchID); matchErr == nil && tableErr == nil {
+		setVersionETag(w, matchVersions(*editedMatch, updatedLeagueTable)...)
+	}
 	if tableErr != nil {
 		respondWithJSON(w, http.StatusOK, map[string]string{
 			"message": fmt.Sprintf("Match ID %d score successfully updated to %d-%d. Could not retrieve updated league table for response.", matchID, reqBody.HomeGoals, reqBody.AwayGoals),
@@ -80,3 +320,46 @@ func (h *MatchHandler) EditMatchScoreHandler(w http.ResponseWriter, r *http.Requ
 		"league_table": updatedLeagueTable,
 	})
 }
+
+// AwardForfeitHandler, bir maçı hükmen (3-0) belirtilen takıma verir. Oynanmış maçlarda istatistikler yeniden hesaplanır.
+func (h *MatchHandler) AwardForfeitHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only POST method is supported for this endpoint.")
+		return
+	}
+	ctx := r.Context()
+	matchID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid match ID: Must be a number.")
+		return
+	}
+	var reqBody AwardForfeitRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		respondWithBodyError(w, "Invalid request body", err)
+		return
+	}
+	defer r.Body.Close()
+
+	previous, _ := h.matchService.GetMatchByID(ctx, matchID)
+	err = h.leagueService.AwardForfeit(ctx, matchID, reqBody.WinnerTeamID)
+	if err != nil {
+		respondWithServiceError(w, err, "Error awarding forfeit")
+		return
+	}
+	if !recordAudit(w, r, h.auditService, h.scoreAuditEntry(r, matchID, models.AuditActionForfeit, previous, reqBody.Reason)) {
+		return
+	}
+
+	updatedLeagueTable, tableErr := h.leagueService.GetLeagueTable(ctx)
+	if tableErr != nil {
+		respondWithJSON(w, http.StatusOK, map[string]string{
+			"message": fmt.Sprintf("Match ID %d awarded to team ID %d by forfeit. Could not retrieve updated league table.", matchID, reqBody.WinnerTeamID),
+		})
+		return
+	}
+	logLeagueTable(ctx, "league table after a forfeit", updatedLeagueTable, logging.KeyMatchID, matchID, "winner_team_id", reqBody.WinnerTeamID)
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message":      fmt.Sprintf("Match ID %d awarded to team ID %d by forfeit (3-0).", matchID, reqBody.WinnerTeamID),
+		"league_table": updatedLeagueTable,
+	})
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/concretes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParseMatchFilter(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr bool
+	}{
+		{query: ""},
+		{query: "week=2&team=3&played=false&stage=league"},
+		{query: "week=0", wantErr: true},
+		{query: "team=abc", wantErr: true},
+		{query: "played=maybe", wantErr: true},
+		{query: "stage=final", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			_, err := parseMatchFilter(query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseMatchFilter(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchFilter_Matches(t *testing.T) {
+	goals := 1
+	played := models.Match{ID: 1, Week: 1, HomeTeamID: 1, AwayTeamID: 2, HomeGoals: &goals, AwayGoals: &goals, IsPlayed: true, Stage: models.StageLeague}
+	unplayed := models.Match{ID: 2, Week: 2, HomeTeamID: 3, AwayTeamID: 1, Stage: models.StageLeague}
+	knockout := models.Match{ID: 3, Week: 3, HomeTeamID: 2, AwayTeamID: 4, Stage: models.StageKnockout}
+	all := []models.Match{played, unplayed, knockout}
+
+	tests := []struct {
+		query   string
+		wantIDs []int
+	}{
+		{query: "", wantIDs: []int{1, 2, 3}},
+		{query: "week=2", wantIDs: []int{2}},
+		{query: "team=1", wantIDs: []int{1, 2}},
+		{query: "team=1&played=false", wantIDs: []int{2}},
+		{query: "played=true", wantIDs: []int{1}},
+		{query: "stage=knockout", wantIDs: []int{3}},
+		{query: "team=4&week=1", wantIDs: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			filter, err := parseMatchFilter(query)
+			if err != nil {
+				t.Fatalf("parseMatchFilter(%q) error = %v", tt.query, err)
+			}
+			var gotIDs []int
+			for _, match := range all {
+				if filter.matches(match) {
+					gotIDs = append(gotIDs, match.ID)
+				}
+			}
+			if len(gotIDs) != len(tt.wantIDs) {
+				t.Fatalf("filter %q matched %v, want %v", tt.query, gotIDs, tt.wantIDs)
+			}
+			for i := range gotIDs {
+				if gotIDs[i] != tt.wantIDs[i] {
+					t.Fatalf("filter %q matched %v, want %v", tt.query, gotIDs, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
+
+// TestGetMatchHandler_ETag checks that the match ETag changes when a team in the response is renamed,
+// so a cached match never shows a stale team name.
+func TestGetMatchHandler_ETag(t *testing.T) {
+	ctx := context.Background()
+	teamService, matchService := concretes.NewMemoryTeamService(), concretes.NewMemoryMatchService()
+	for _, name := range []string{"Chelsea", "Arsenal"} {
+		if _, err := teamService.CreateTeam(ctx, models.Team{Name: name, Strength: 80}); err != nil {
+			t.Fatalf("Could not create team %s: %v", name, err)
+		}
+	}
+	teams, _ := teamService.GetAllTeams(ctx)
+	if err := matchService.GenerateAndStoreFixture(ctx, teams); err != nil {
+		t.Fatalf("Could not generate the fixture: %v", err)
+	}
+	match, err := matchService.GetMatchByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("Could not read match 1: %v", err)
+	}
+	handler := NewMatchHandler(nil, matchService, teamService, nil)
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/matches/1", nil)
+		r.SetPathValue("id", "1")
+		if ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		handler.GetMatchHandler(w, r)
+		return w
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag != fmt.Sprintf(`"%d-1-1"`, match.Version) {
+		t.Fatalf("GET = %d with ETag %s, want 200 with the match and both team versions", first.Code, etag)
+	}
+	if w := get(etag); w.Code != http.StatusNotModified {
+		t.Errorf("GET with the current ETag = %d, want 304", w.Code)
+	}
+
+	if err := teamService.UpdateTeamName(ctx, match.HomeTeamID, "Chelsea FC", 0); err != nil {
+		t.Fatalf("Could not rename the home team: %v", err)
+	}
+	renamed := get(etag)
+	if renamed.Code != http.StatusOK || renamed.Header().Get("ETag") == etag {
+		t.Fatalf("GET after renaming a team = %d with ETag %s, want 200 with a new ETag", renamed.Code, renamed.Header().Get("ETag"))
+	}
+	if !strings.Contains(renamed.Body.String(), "Chelsea FC") {
+		t.Errorf("Expected the new team name in %s", renamed.Body.String())
+	}
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"MatchSimulator_Insider/logging"
+	"MatchSimulator_Insider/metrics"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// requestIDHeader, isteğin kimliğini taşıyan başlıktır. İstemci gönderirse o kullanılır, göndermezse sunucu üretir.
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength, istemciden kabul edilen en uzun istek kimliğidir; daha uzunları yok sayılıp yenisi üretilir.
+const maxRequestIDLength = 128
+
+// Middleware, bir handler'ı saran ara katmandır.
+type Middleware func(http.Handler) http.Handler
+
+// Chain, handler'ı verilen middleware'lerle sarar. İlk middleware en dışta çalışır.
+func Chain(handler http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// statusRecorder, cevabın durum kodunu ve boyutunu access log ve panic kurtarma için tutar.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if s.status == 0 {
+		s.status = status
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(p []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	n, err := s.ResponseWriter.Write(p)
+	s.bytes += n
+	return n, err
+}
+
+// recorderFor, w zaten bir statusRecorder ise onu döndürür; böylece iç içe middleware'ler aynı kaydı paylaşır.
+func recorderFor(w http.ResponseWriter) *statusRecorder {
+	if recorder, ok := w.(*statusRecorder); ok {
+		return recorder
+	}
+	return &statusRecorder{ResponseWriter: w}
+}
+
+// RequestID, her isteğe bir kimlik verir ve bunu context'e koyar; servislerin logları bu kimlikle başlar.
+// Kimlik cevapta X-Request-ID başlığıyla geri döner.
+func RequestID() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := strings.TrimSpace(r.Header.Get(requestIDHeader))
+			if requestID == "" || len(requestID) > maxRequestIDLength || strings.ContainsFunc(requestID, func(c rune) bool { return c < ' ' || c > '~' }) {
+				requestID = logging.NewRequestID()
+			}
+			w.Header().Set(requestIDHeader, requestID)
+			next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), requestID)))
+		})
+	}
+}
+
+// AccessLog, her isteği metot, yol, durum kodu, cevap boyutu ve süresiyle tek bir log kaydı olarak yazar.
+func AccessLog() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := recorderFor(w)
+			next.ServeHTTP(recorder, r)
+			status := recorder.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			slog.InfoContext(r.Context(), "access",
+				"method", r.Method, "path", r.URL.RequestURI(), "status", status, "bytes", recorder.bytes,
+				"duration_ms", float64(time.Since(start).Microseconds())/1000, "remote", r.RemoteAddr, "user_agent", r.UserAgent())
+		})
+	}
+}
+
+// unmatchedRoute, ServeMux'ta hiçbir rotayla eşleşmeyen isteklerin metriklerdeki rota etiketidir.
+const unmatchedRoute = "unmatched"
+
+// Metrics, her isteği metot, rota ve durum koduna göre sayar ve süresini ölçer. Rota, ServeMux'un eşleştirdiği
+// kalıptır (örn. /v1/teams/{id}); böylece her takım ID'si ayrı bir seri açmaz. ServeMux kalıbı isteğin kendisine
+// yazdığı için bu middleware ile mux arasındaki middleware'ler isteği kopyalamamalıdır.
+// Recover'ın dışında çalışır ki panic'ten dönen 500'ler de sayılsın.
+func Metrics() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := recorderFor(w)
+			next.ServeHTTP(recorder, r)
+			status := recorder.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			route := unmatchedRoute
+			if r.Pattern != "" {
+				_, path, found := strings.Cut(r.Pattern, " ")
+				if !found {
+					path = r.Pattern
+				}
+				route = path
+			}
+			metrics.HTTPRequests.Inc(r.Method, route, strconv.Itoa(status))
+			metrics.HTTPRequestDuration.ObserveSince(start, r.Method, route)
+		})
+	}
+}
+
+// Recover, handler'daki bir panic'i yakalayıp loglar ve istemciye 500 döner; sunucu ayakta kalır.
+func Recover() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			recorder := recorderFor(w)
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+				slog.ErrorContext(r.Context(), "panic serving request", "method", r.Method, "path", r.URL.Path, "panic", fmt.Sprint(recovered), "stack", string(debug.Stack()))
+				if recorder.status != 0 {
+					return // Cevap yazılmaya başlandı; bağlantıyı olduğu gibi bırakmaktan başka yapılacak bir şey yok
+				}
+				respondWithErrorForPath(recorder, r, http.StatusInternalServerError, "Internal server error.")
+			}()
+			next.ServeHTTP(recorder, r)
+		})
+	}
+}
+
+// LimitBody, istek gövdesini maxBytes ile sınırlar. Sınırı aşan gövdeler okunurken hata verir ve handler 413 döner.
+func LimitBody(maxBytes int64) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				respondWithErrorForPath(w, r, http.StatusRequestEntityTooLarge, "Request body is larger than "+strconv.FormatInt(maxBytes, 10)+" bytes.")
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CORS, izin verilen origin'lerden gelen tarayıcı isteklerine CORS başlıklarını ekler ve ön kontrol (OPTIONS) isteklerini cevaplar.
+// allowedOrigins boşsa hiçbir şey yapmaz; "*" bütün origin'lere izin verir.
+func CORS(allowedOrigins []string, maxAge int) Middleware {
+	allowAll := false
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		if origin == "*" {
+			allowAll = true
+		}
+		allowed[strings.TrimSuffix(origin, "/")] = true
+	}
+	allowHeaders := strings.Join([]string{"Content-Type", "If-Match", "If-None-Match", actorHeader, requestIDHeader}, ", ")
+	exposeHeaders := strings.Join([]string{"ETag", "Location", "Content-Disposition", requestIDHeader, "Deprecation", "Link"}, ", ")
+
+	return func(next http.Handler) http.Handler {
+		if len(allowed) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" || !(allowAll || allowed[origin]) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			header := w.Header()
+			header.Add("Vary", "Origin")
+			header.Set("Access-Control-Allow-Origin", origin)
+			header.Set("Access-Control-Expose-Headers", exposeHeaders)
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				header.Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+				header.Set("Access-Control-Allow-Headers", allowHeaders)
+				if maxAge > 0 {
+					header.Set("Access-Control-Max-Age", strconv.Itoa(maxAge))
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"MatchSimulator_Insider/logging"
+	"MatchSimulator_Insider/metrics"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		wantSame bool
+	}{
+		{name: "Generated"},
+		{name: "Client Supplied", incoming: "frontend-42", wantSame: true},
+		{name: "Too Long", incoming: strings.Repeat("a", maxRequestIDLength+1)},
+		{name: "Control Characters", incoming: "abc\x01def"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromContext string
+			handler := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fromContext = logging.RequestID(r.Context())
+			}), RequestID())
+			req := httptest.NewRequest(http.MethodGet, "/v1/teams", nil)
+			if tt.incoming != "" {
+				req.Header.Set(requestIDHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			got := w.Header().Get(requestIDHeader)
+			if got == "" || got != fromContext {
+				t.Fatalf("header ID %q and context ID %q must be set and equal", got, fromContext)
+			}
+			if (got == tt.incoming) != tt.wantSame {
+				t.Errorf("request ID = %q, incoming %q, want reused = %v", got, tt.incoming, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestRecover(t *testing.T) {
+	panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { panic("boom") })
+	tests := []struct {
+		path     string
+		wantCode string
+	}{
+		{path: "/teams", wantCode: `"code":"internal_error"`},
+		{path: "/v1/teams", wantCode: `"errors":[{"code":"internal_error"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			Chain(panicking, AccessLog(), Recover()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("status = %d, want 500", w.Code)
+			}
+			if !json.Valid(w.Body.Bytes()) || !strings.Contains(w.Body.String(), tt.wantCode) {
+				t.Errorf("body = %s, want JSON containing %s", w.Body.String(), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/teams/{id}", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	mux.HandleFunc("GET /v1/panic", func(w http.ResponseWriter, r *http.Request) { panic("boom") })
+	handler := Chain(mux, RequestID(), Metrics(), Recover(), LimitBody(1024))
+
+	tests := []struct {
+		path   string
+		route  string
+		status string
+	}{
+		{path: "/v1/teams/7", route: "/v1/teams/{id}", status: "200"},
+		{path: "/v1/teams/8", route: "/v1/teams/{id}", status: "200"},
+		{path: "/v1/panic", route: "/v1/panic", status: "500"},
+		{path: "/nowhere", route: unmatchedRoute, status: "404"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			before := metrics.HTTPRequests.Value(http.MethodGet, tt.route, tt.status)
+			observed := metrics.HTTPRequestDuration.Count(http.MethodGet, tt.route)
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if got := metrics.HTTPRequests.Value(http.MethodGet, tt.route, tt.status); got != before+1 {
+				t.Errorf("http_requests_total{route=%q,status=%q} = %v, want %v", tt.route, tt.status, got, before+1)
+			}
+			if got := metrics.HTTPRequestDuration.Count(http.MethodGet, tt.route); got != observed+1 {
+				t.Errorf("http_request_duration_seconds count for %q = %d, want %d", tt.route, got, observed+1)
+			}
+		})
+	}
+}
+
+func TestLimitBody(t *testing.T) {
+	const limit = 16
+	decoding := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			respondWithBodyError(w, "Invalid request body", err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	tests := []struct {
+		name       string
+		body       string
+		chunked    bool
+		wantStatus int
+	}{
+		{name: "Within Limit", body: `{"a":1}`, wantStatus: http.StatusOK},
+		{name: "Declared Too Large", body: `{"name":"` + strings.Repeat("x", limit) + `"}`, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "Streamed Too Large", body: `{"name":"` + strings.Repeat("x", limit) + `"}`, chunked: true, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "Malformed", body: `{`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader = strings.NewReader(tt.body)
+			if tt.chunked {
+				body = io.MultiReader(body) // hides the length, so the limit is only hit while reading
+			}
+			req := httptest.NewRequest(http.MethodPost, "/teams", body)
+			w := httptest.NewRecorder()
+			Chain(decoding, LimitBody(limit)).ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCORS(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	tests := []struct {
+		name        string
+		allowed     []string
+		origin      string
+		preflight   bool
+		wantStatus  int
+		wantAllowed string
+	}{
+		{name: "Disabled", origin: "http://app.example", wantStatus: http.StatusOK},
+		{name: "Allowed Origin", allowed: []string{"http://app.example"}, origin: "http://app.example", wantStatus: http.StatusOK, wantAllowed: "http://app.example"},
+		{name: "Other Origin", allowed: []string{"http://app.example"}, origin: "http://evil.example", wantStatus: http.StatusOK},
+		{name: "Wildcard", allowed: []string{"*"}, origin: "http://any.example", wantStatus: http.StatusOK, wantAllowed: "http://any.example"},
+		{name: "Preflight", allowed: []string{"http://app.example"}, origin: "http://app.example", preflight: true, wantStatus: http.StatusNoContent, wantAllowed: "http://app.example"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := http.MethodGet
+			if tt.preflight {
+				method = http.MethodOptions
+			}
+			req := httptest.NewRequest(method, "/v1/teams/1/name", nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+			}
+			w := httptest.NewRecorder()
+			Chain(next, CORS(tt.allowed, 600)).ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantAllowed {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantAllowed)
+			}
+			if tt.preflight && !strings.Contains(w.Header().Get("Access-Control-Allow-Headers"), "If-Match") {
+				t.Errorf("preflight must allow If-Match, got %q", w.Header().Get("Access-Control-Allow-Headers"))
+			}
+		})
+	}
+}
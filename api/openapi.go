@@ -0,0 +1,46 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec, API'nin OpenAPI 3 tanımıdır. Yeni bir rota eklendiğinde buraya da eklenmelidir; TestOpenAPISpecCoversRoutes eksikleri yakalar.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// docsPage, /openapi.json'u okuyup endpoint'leri listeleyen bağımsız HTML sayfasıdır; dış kaynak (CDN) kullanmaz.
+//
+//go:embed docs.html
+var docsPage []byte
+
+// OpenAPIHandler, OpenAPI tanımını ve ondan üretilen dokümantasyon sayfasını sunar.
+type OpenAPIHandler struct{}
+
+// NewOpenAPIHandler, yeni bir OpenAPIHandler örneği oluşturur.
+func NewOpenAPIHandler() *OpenAPIHandler {
+	return &OpenAPIHandler{}
+}
+
+// GetSpecHandler, OpenAPI tanımını JSON olarak döndürür.
+func (h *OpenAPIHandler) GetSpecHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(openAPISpec)
+}
+
+// GetDocsHandler, API dokümantasyon sayfasını döndürür.
+func (h *OpenAPIHandler) GetDocsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(docsPage)
+}
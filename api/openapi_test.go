@@ -0,0 +1,136 @@
+package api
+
+import (
+	"MatchSimulator_Insider/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// routeRecorder collects the patterns RegisterRoutes registers instead of serving them.
+type routeRecorder struct {
+	patterns []string
+}
+
+func (r *routeRecorder) HandleFunc(pattern string, _ func(http.ResponseWriter, *http.Request)) {
+	r.patterns = append(r.patterns, pattern)
+}
+
+// specOperations returns the spec's operations as "METHOD /path" patterns, the same form the router uses.
+func specOperations(t *testing.T) map[string]bool {
+	t.Helper()
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openAPISpec, &spec); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+	operations := make(map[string]bool)
+	for path, item := range spec.Paths {
+		for method := range item {
+			operations[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+	return operations
+}
+
+func TestOpenAPISpecCoversRoutes(t *testing.T) {
+	recorder := &routeRecorder{}
+	RegisterRoutes(recorder, nil, nil, nil, nil, nil, nil, nil, nil, models.SeedPresets{})
+	if len(recorder.patterns) == 0 {
+		t.Fatal("RegisterRoutes registered no routes")
+	}
+
+	// Spec paths are relative to the /v1 server; every legacy route must also exist under /v1.
+	registered := make(map[string]bool, len(recorder.patterns))
+	for _, pattern := range recorder.patterns {
+		registered[pattern] = true
+	}
+	operations := specOperations(t)
+	versioned := make(map[string]bool)
+	for _, pattern := range recorder.patterns {
+		method, path, _ := strings.Cut(pattern, " ")
+		if !strings.HasPrefix(path, apiV1Prefix+"/") {
+			if !registered[method+" "+apiV1Prefix+path] {
+				t.Errorf("Legacy route %q has no /v1 counterpart", pattern)
+			}
+			continue
+		}
+		operation := method + " " + strings.TrimPrefix(path, apiV1Prefix)
+		versioned[operation] = true
+		if !operations[operation] {
+			t.Errorf("Route %q is registered but %q is missing from api/openapi.json", pattern, operation)
+		}
+	}
+	var stale []string
+	for operation := range operations {
+		if !versioned[operation] {
+			stale = append(stale, operation)
+		}
+	}
+	sort.Strings(stale)
+	for _, operation := range stale {
+		t.Errorf("api/openapi.json documents %q, but no such /v1 route is registered", operation)
+	}
+}
+
+func TestOpenAPISpecReferencesResolve(t *testing.T) {
+	var spec map[string]interface{}
+	if err := json.Unmarshal(openAPISpec, &spec); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch value := node.(type) {
+		case map[string]interface{}:
+			if ref, ok := value["$ref"].(string); ok {
+				var target interface{} = spec
+				for _, key := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+					object, _ := target.(map[string]interface{})
+					target = object[key]
+				}
+				if target == nil {
+					t.Errorf("Reference %q does not resolve", ref)
+				}
+			}
+			for _, child := range value {
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+	walk(spec)
+}
+
+func TestOpenAPIHandlers(t *testing.T) {
+	handler := NewOpenAPIHandler()
+	tests := []struct {
+		name            string
+		serve           http.HandlerFunc
+		method          string
+		wantStatus      int
+		wantContentType string
+	}{
+		{"Spec", handler.GetSpecHandler, http.MethodGet, http.StatusOK, "application/json"},
+		{"Docs", handler.GetDocsHandler, http.MethodGet, http.StatusOK, "text/html; charset=utf-8"},
+		{"Spec Wrong Method", handler.GetSpecHandler, http.MethodPost, http.StatusMethodNotAllowed, "application/json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.serve(w, httptest.NewRequest(tt.method, "/", nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+type PyramidHandler struct {
+	pyramidService abstracts.IPyramidService
+}
+
+func NewPyramidHandler(ps abstracts.IPyramidService) *PyramidHandler {
+	return &PyramidHandler{
+		pyramidService: ps,
+	}
+}
+
+// GetDivisionTablesHandler, her lig kademesinin puan durumunu ayrı ayrı döndürür.
+func (h *PyramidHandler) GetDivisionTablesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	tables, err := h.pyramidService.GetDivisionTables(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error retrieving division tables: "+err.Error())
+		return
+	}
+	if tables == nil {
+		tables = []models.DivisionTable{}
+	}
+	respondWithJSON(w, http.StatusOK, tables)
+}
+
+// GetPlayoffsHandler, sezonun play-off maçlarını (yarı final ayakları ve final) döndürür.
+// season sorgu parametresi verilirse end-season ile kapatılıp arşivlenmiş o sezonun play-off maçları döner.
+func (h *PyramidHandler) GetPlayoffsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+		season, err := strconv.Atoi(seasonStr)
+		if err != nil || season < 1 {
+			respondWithError(w, http.StatusBadRequest, "Invalid season: Must be a positive number.")
+			return
+		}
+		archive, err := h.pyramidService.GetSeasonArchive(r.Context(), season)
+		if err != nil {
+			respondWithServiceError(w, err, "Error retrieving archived playoff matches")
+			return
+		}
+		respondWithJSON(w, http.StatusOK, archive.PlayoffMatches)
+		return
+	}
+	matches, err := h.pyramidService.GetPlayoffMatches(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error retrieving playoff matches: "+err.Error())
+		return
+	}
+	if matches == nil {
+		matches = []models.Match{}
+	}
+	respondWithJSON(w, http.StatusOK, matches)
+}
+
+// PlayPlayoffsHandler, sezon sonu play-off'larını oynatır ve maçlarını döndürür. Takımlar henüz yer değiştirmez.
+func (h *PyramidHandler) PlayPlayoffsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only POST method is supported for this endpoint.")
+		return
+	}
+	matches, err := h.pyramidService.PlayPlayoffs(r.Context())
+	if err != nil {
+		respondWithServiceError(w, err, "Error playing playoffs")
+		return
+	}
+	if matches == nil {
+		matches = []models.Match{}
+	}
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message":         fmt.Sprintf("Playoffs played (%d matches). Call /pyramid/end-season to apply promotion and relegation.", len(matches)),
+		"playoff_matches": matches,
+	})
+}
+
+// EndSeasonHandler, sezonu kapatır: play-off'ları oynatır, küme düşme/yükselmeyi uygular ve yeni sezonu başlatır.
+func (h *PyramidHandler) EndSeasonHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only POST method is supported for this endpoint.")
+		return
+	}
+	ctx := r.Context()
+	movements, err := h.pyramidService.EndSeason(ctx)
+	if err != nil {
+		respondWithServiceError(w, err, "Error ending season")
+		return
+	}
+	if movements == nil {
+		movements = []models.DivisionMovement{}
+	}
+
+	archive, archiveErr := h.pyramidService.GetSeasonArchive(ctx, 0)
+	tables, tableErr := h.pyramidService.GetDivisionTables(ctx)
+	if archiveErr != nil || tableErr != nil {
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{
+			"message":   fmt.Sprintf("Season closed with %d division changes. Could not retrieve the archived season or the new division tables.", len(movements)),
+			"movements": movements,
+		})
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message":         fmt.Sprintf("Season %d closed with %d division changes. Next season's fixtures have been generated; its playoffs stay available at /pyramid/playoffs?season=%d.", archive.Season, len(movements), archive.Season),
+		"season":          archive.Season,
+		"movements":       movements,
+		"division_tables": tables,
+	})
+}
@@ -2,16 +2,63 @@ package api
 
 // EditMatchScoreRequest, maç skoru düzenleme isteğinin gövdesini tanımlar.
 type EditMatchScoreRequest struct {
-	HomeGoals int `json:"home_goals"`
-	AwayGoals int `json:"away_goals"`
+	HomeGoals int    `json:"home_goals"`
+	AwayGoals int    `json:"away_goals"`
+	Reason    string `json:"reason,omitempty"` // İsteğe bağlı; denetim kaydına (audit log) yazılır
+}
+
+// CreateTeamRequest, yeni takım ekleme isteğinin gövdesini tanımlar. Division verilmezse takım en üst lige (1) eklenir.
+type CreateTeamRequest struct {
+	Name     string `json:"name"`
+	Strength int    `json:"strength"`
+	Division int    `json:"division,omitempty"`
+	Reason   string `json:"reason,omitempty"`
 }
 
 // UpdateTeamStrengthRequest, takım gücü güncelleme isteğinin gövdesini tanımlar.
 type UpdateTeamStrengthRequest struct {
-	Strength int `json:"strength"`
+	Strength int    `json:"strength"`
+	Reason   string `json:"reason,omitempty"`
 }
 
 // UpdateTeamNameRequest, takım ismi güncelleme isteğinin gövdesini tanımlar.
 type UpdateTeamNameRequest struct {
-	Name string `json:"name"`
+	Name   string `json:"name"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// UpdateTeamDivisionRequest, takımın ligini (kademesini) güncelleme isteğinin gövdesini tanımlar.
+type UpdateTeamDivisionRequest struct {
+	Division int    `json:"division"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// PointsAdjustmentRequest, takıma puan silme/ekleme isteğinin gövdesini tanımlar. Negatif puan silme anlamına gelir.
+type PointsAdjustmentRequest struct {
+	Points int    `json:"points"`
+	Reason string `json:"reason"`
+}
+
+// AwardForfeitRequest, maçı hükmen bir takıma verme isteğinin gövdesini tanımlar.
+type AwardForfeitRequest struct {
+	WinnerTeamID int    `json:"winner_team_id"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// RevertAuditEntryRequest, bir denetim kaydını geri alma isteğinin isteğe bağlı gövdesini tanımlar.
+type RevertAuditEntryRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// CreateTournamentRequest, grup kurası ve grup aşaması fikstürü oluşturma isteğinin gövdesini tanımlar.
+// Pots boş bırakılırsa takımlar güçlerine göre torbalara ayrılır.
+type CreateTournamentRequest struct {
+	GroupCount int     `json:"group_count"`
+	Pots       [][]int `json:"pots,omitempty"`
+}
+
+// AdvanceTournamentRequest, turnuvayı bir sonraki aşamaya taşıma isteğinin gövdesini tanımlar.
+// AdvancePerGroup yalnızca grup aşamasından eleme turuna geçerken kullanılır (varsayılan 2).
+type AdvanceTournamentRequest struct {
+	AdvancePerGroup int `json:"advance_per_group"`
 }
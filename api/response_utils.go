@@ -1,41 +1,75 @@
 package api
 
 import (
+	"MatchSimulator_Insider/logging"
 	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 )
 
-// respondWithError, istemciye bir hata mesajı ve HTTP durum kodu gönderir.
+// respondWithError, istemciye bir hata mesajı ve HTTP durum kodu gönderir. Hata kodu durumdan türetilir.
 func respondWithError(w http.ResponseWriter, code int, message string) {
-	log.Printf("API Response - Error %d: %s", code, message)
-	respondWithJSON(w, code, map[string]string{"error": message})
+	respondWithErrorCode(w, code, errorCodeForStatus(code), message)
+}
+
+// respondWithErrorCode, hata mesajını belirli bir hata koduyla gönderir.
+func respondWithErrorCode(w http.ResponseWriter, status int, code string, message string) {
+	slog.Debug("error response", "status", status, "code", code, "message", message)
+	respondWithJSON(w, status, errorResponse{Error: message, Code: code})
+}
+
+// respondWithBodyError, istek gövdesi okunamadığında döner. Gövde boyut sınırını aştıysa 413, aksi halde 400 olur.
+func respondWithBodyError(w http.ResponseWriter, message string, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		respondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("%s: the body is larger than %d bytes.", message, tooLarge.Limit))
+		return
+	}
+	respondWithError(w, http.StatusBadRequest, message+": "+err.Error())
 }
 
 // respondWithJSON, istemciye JSON formatında bir cevap gönderir.
 func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	response, err := json.Marshal(payload)
 	if err != nil {
-		log.Printf("JSON Marshal Error: %v, Payload: %+v", err, payload)
+		slog.Error("response could not be encoded", "error", err, "payload", fmt.Sprintf("%+v", payload))
 		http.Error(w, "Server Error: Could not format response data", http.StatusInternalServerError)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 	if _, err = w.Write(response); err != nil {
-		log.Printf("HTTP response write error: %v", err)
+		slog.Warn("response could not be written", "error", err)
+	}
+}
+
+// debugEnabled, debug seviyesindeki logların yazılıp yazılmayacağını söyler. Lig tablosu yalnızca log için okunacaksa önce buna bakılır.
+func debugEnabled(ctx context.Context) bool {
+	return slog.Default().Enabled(ctx, slog.LevelDebug)
+}
+
+// logLeagueTable, lig tablosunu debug seviyesinde tek bir log kaydı olarak yazar; args kayda eklenecek alanlardır.
+func logLeagueTable(ctx context.Context, message string, table []models.Team, args ...any) {
+	if !debugEnabled(ctx) {
+		return
 	}
+	slog.DebugContext(ctx, message, append(args, logging.LeagueTable(table))...)
 }
 
-// logLeagueTableToConsole, lig tablosunu sunucu konsoluna loglar.
-func logLeagueTableToConsole(header string, table []models.Team) {
-	log.Printf("\n--- %s (API Handler Log) ---\n", header)
-	log.Println(" Rank | Team              | Pld | W | D | L | GF | GA | GD | Pts")
-	log.Println("------|-------------------|-----|---|---|---|----|----|----|----")
-	for i, t := range table {
-		log.Printf(" %-4d | %-17s | %-3d | %-1d | %-1d | %-1d | %-2d | %-2d | %-2d | %-3d\n",
-			i+1, t.Name, t.Played, t.Wins, t.Draws, t.Losses, t.GoalsFor, t.GoalsAgainst, t.GoalDifference, t.Points)
+// logLeagueTableFrom, lig tablosunu yalnızca debug açıkken okuyup loglar. Tabloyu cevapta kullanmayan handler'lar içindir.
+func logLeagueTableFrom(ctx context.Context, leagueService abstracts.ILeagueService, message string, args ...any) {
+	if !debugEnabled(ctx) {
+		return
+	}
+	table, err := leagueService.GetLeagueTable(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "could not retrieve the league table for logging", "error", err)
+		return
 	}
-	log.Println("-----------------------------------------------------------------------")
+	logLeagueTable(ctx, message, table, args...)
 }
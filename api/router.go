@@ -1,33 +1,88 @@
 package api
 
 import (
+	"MatchSimulator_Insider/models"
 	"MatchSimulator_Insider/services/abstracts"
-	"log"
 	"net/http"
+	"strings"
 )
 
-func RegisterRoutes(mux *http.ServeMux, leagueService abstracts.ILeagueService, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
-	log.Println("API rotaları kaydediliyor...")
+// RouteRegistrar, rotaların kaydedildiği yerdir. *http.ServeMux bunu sağlar; testler kayıtlı rotaları listelemek için kendi uygulamalarını verir.
+type RouteRegistrar interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+func RegisterRoutes(mux RouteRegistrar, leagueService abstracts.ILeagueService, teamService abstracts.TeamService, matchService abstracts.IMatchService, tournamentService abstracts.ITournamentService, pyramidService abstracts.IPyramidService, snapshotService abstracts.ISnapshotService, auditService abstracts.AuditService, transactor abstracts.Transactor, presets models.SeedPresets) {
+	leagueHandler := NewLeagueHandler(leagueService, teamService, matchService, auditService)
+	teamHandler := NewTeamHandler(teamService, leagueService, auditService, presets)
+	matchHandler := NewMatchHandler(leagueService, matchService, teamService, auditService)
+	tournamentHandler := NewTournamentHandler(tournamentService)
+	pyramidHandler := NewPyramidHandler(pyramidService)
+	auditHandler := NewAuditHandler(auditService, leagueService, matchService)
+	snapshotHandler := NewSnapshotHandler(snapshotService, leagueService, teamService, matchService, auditService)
+	openAPIHandler := NewOpenAPIHandler()
 
-	leagueHandler := NewLeagueHandler(leagueService, teamService, matchService)
-	teamHandler := NewTeamHandler(teamService, leagueService)
-	matchHandler := NewMatchHandler(leagueService)
+	// Değişiklik yapan (POST/PUT/DELETE) endpoint'ler tek bir transaction içinde çalışır.
+	mutate := func(handler http.HandlerFunc) http.HandlerFunc {
+		return withTransaction(transactor, handler)
+	}
+	// Her rota /v1 altında zarflı cevapla kaydedilir; öneksiz eski yolu da kullanımdan kalkmış olarak çalışmaya devam eder.
+	handle := func(pattern string, handler http.HandlerFunc) {
+		method, path, _ := strings.Cut(pattern, " ")
+		mux.HandleFunc(method+" "+apiV1Prefix+path, withEnvelope(handler))
+		mux.HandleFunc(pattern, withDeprecation(handler))
+	}
 
 	// League endpoints
-	mux.HandleFunc("GET /league-table", leagueHandler.GetLeagueTable)
-	mux.HandleFunc("POST /next-week", leagueHandler.PlayNextWeek)
-	mux.HandleFunc("GET /current-week", leagueHandler.GetCurrentWeekInfo)
-	mux.HandleFunc("GET /predictions", leagueHandler.GetPredictions)
-	mux.HandleFunc("POST /reset-league", leagueHandler.ResetLeague)
-	mux.HandleFunc("POST /play-all", leagueHandler.PlayAllRemainingWeeks)
+	handle("GET /league-table", leagueHandler.GetLeagueTable)
+	handle("POST /next-week", mutate(leagueHandler.PlayNextWeek))
+	handle("GET /current-week", leagueHandler.GetCurrentWeekInfo)
+	handle("GET /predictions", leagueHandler.GetPredictions)
+	handle("POST /reset-league", mutate(leagueHandler.ResetLeague))
+	handle("POST /play-all", mutate(leagueHandler.PlayAllRemainingWeeks))
+	handle("POST /revert-week", mutate(leagueHandler.RevertLastPlayedWeek))
+	handle("GET /snapshot", snapshotHandler.ExportSnapshotHandler)
+	handle("POST /snapshot", mutate(snapshotHandler.ImportSnapshotHandler))
 
 	// Match endpoints
-	mux.HandleFunc("PUT /matches/{id}", matchHandler.EditMatchScoreHandler)
+	handle("GET /matches", matchHandler.GetMatchesHandler)
+	handle("GET /weeks/{week}", matchHandler.GetWeekHandler)
+	handle("GET /matches/{id}", matchHandler.GetMatchHandler)
+	handle("PUT /matches/{id}", mutate(matchHandler.EditMatchScoreHandler))
+	handle("POST /matches/{id}/forfeit", mutate(matchHandler.AwardForfeitHandler))
 
 	// Team endpoints
-	mux.HandleFunc("PUT /teams/{id}/strength", teamHandler.UpdateTeamStrengthHandler)
-	mux.HandleFunc("PUT /teams/{id}/name", teamHandler.UpdateTeamNameHandler)
-	mux.HandleFunc("POST /teams/reset-defaults", teamHandler.ResetTeamsToDefaultsHandler)
+	handle("GET /teams", teamHandler.GetTeamsHandler)
+	handle("POST /teams", mutate(teamHandler.CreateTeamHandler))
+	handle("DELETE /teams/{id}", mutate(teamHandler.DeleteTeamHandler))
+	handle("GET /teams/{id}", teamHandler.GetTeamHandler)
+	handle("PUT /teams/{id}/strength", mutate(teamHandler.UpdateTeamStrengthHandler))
+	handle("PUT /teams/{id}/name", mutate(teamHandler.UpdateTeamNameHandler))
+	handle("POST /teams/reset-defaults", mutate(teamHandler.ResetTeamsToDefaultsHandler))
+	handle("GET /teams/presets", teamHandler.GetPresetsHandler)
+	handle("PUT /teams/{id}/division", mutate(teamHandler.UpdateTeamDivisionHandler))
+	handle("POST /teams/{id}/adjustments", mutate(teamHandler.ApplyPointsAdjustmentHandler))
+	handle("GET /adjustments", teamHandler.GetPointsAdjustmentsHandler)
+
+	// Tournament endpoints
+	handle("POST /tournament", mutate(tournamentHandler.CreateTournamentHandler))
+	handle("GET /tournament/groups", tournamentHandler.GetGroupStandingsHandler)
+	handle("GET /tournament/bracket", tournamentHandler.GetBracketHandler)
+	handle("POST /tournament/advance", mutate(tournamentHandler.AdvanceTournamentHandler))
+
+	// Pyramid endpoints
+	handle("GET /pyramid/tables", pyramidHandler.GetDivisionTablesHandler)
+	handle("GET /pyramid/playoffs", pyramidHandler.GetPlayoffsHandler)
+	handle("POST /pyramid/playoffs", mutate(pyramidHandler.PlayPlayoffsHandler))
+	handle("POST /pyramid/end-season", mutate(pyramidHandler.EndSeasonHandler))
+
+	// Audit endpoints
+	handle("GET /audit", auditHandler.GetAuditLogHandler)
+	handle("POST /audit/{id}/revert", mutate(auditHandler.RevertAuditEntryHandler))
 
-	log.Println("API rotaları başarıyla kaydedildi.")
+	// API documentation endpoints; zarflanmaz ve her iki yolda da desteklenir.
+	for _, prefix := range []string{apiV1Prefix, ""} {
+		mux.HandleFunc("GET "+prefix+"/openapi.json", openAPIHandler.GetSpecHandler)
+		mux.HandleFunc("GET "+prefix+"/docs", openAPIHandler.GetDocsHandler)
+	}
 }
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type SnapshotHandler struct {
+	snapshotService abstracts.ISnapshotService
+	leagueService   abstracts.ILeagueService
+	teamService     abstracts.TeamService
+	matchService    abstracts.IMatchService
+	auditService    abstracts.AuditService
+}
+
+func NewSnapshotHandler(ss abstracts.ISnapshotService, ls abstracts.ILeagueService, ts abstracts.TeamService, ms abstracts.IMatchService, as abstracts.AuditService) *SnapshotHandler {
+	return &SnapshotHandler{
+		snapshotService: ss,
+		leagueService:   ls,
+		teamService:     ts,
+		matchService:    ms,
+		auditService:    as,
+	}
+}
+
+// snapshotAuditValue, içe aktarmadan önceki ve sonraki ligin özetidir; belgenin tamamı audit kaydına yazılmaz.
+type snapshotAuditValue struct {
+	Teams      int    `json:"teams"`
+	Matches    int    `json:"matches"`
+	Seed       int64  `json:"seed,string,omitempty"`
+	ExportedAt string `json:"exported_at,omitempty"`
+}
+
+// ExportSnapshotHandler, ligin bütün durumunu başka bir sunucuya aktarılabilecek tek bir JSON belgesi olarak döndürür.
+func (h *SnapshotHandler) ExportSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	snapshot, err := h.snapshotService.Export(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error exporting league snapshot: "+err.Error())
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="league-snapshot-%s.json"`, snapshot.ExportedAt.Format("20060102-150405")))
+	respondWithJSON(w, http.StatusOK, snapshot)
+}
+
+// ImportSnapshotHandler, mevcut ligi silip gövdede gönderilen snapshot belgesindeki ligi kurar.
+func (h *SnapshotHandler) ImportSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only POST method is supported for this endpoint.")
+		return
+	}
+	var snapshot models.LeagueSnapshot
+	if err := json.NewDecoder(r.Body).Decode(&snapshot); err != nil {
+		respondWithBodyError(w, "Invalid snapshot document", err)
+		return
+	}
+
+	ctx := r.Context()
+	previous := snapshotAuditValue{}
+	if teams, err := h.teamService.GetAllTeams(ctx); err == nil {
+		previous.Teams = len(teams)
+	}
+	if matches, err := h.matchService.GetAllMatches(ctx); err == nil {
+		previous.Matches = len(matches)
+	}
+
+	warnings, err := h.snapshotService.Import(ctx, snapshot)
+	if err != nil {
+		respondWithServiceError(w, err, "Error importing league snapshot")
+		return
+	}
+	imported := snapshotAuditValue{Teams: len(snapshot.Teams), Matches: len(snapshot.Matches), Seed: snapshot.Seed}
+	if !snapshot.ExportedAt.IsZero() {
+		imported.ExportedAt = snapshot.ExportedAt.Format(time.RFC3339)
+	}
+	if !recordAudit(w, r, h.auditService, models.AuditEntry{
+		EntityType: models.AuditEntityLeague,
+		Action:     models.AuditActionSnapshotImport,
+		OldValue:   auditValue(previous),
+		NewValue:   auditValue(imported),
+		Reason:     r.URL.Query().Get("reason"),
+	}) {
+		return
+	}
+	for _, warning := range warnings {
+		slog.WarnContext(ctx, "snapshot import warning", "warning", warning)
+	}
+	if warnings == nil {
+		warnings = []string{}
+	}
+
+	leagueTable, err := h.leagueService.GetLeagueTable(ctx)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Snapshot imported, but the league table could not be retrieved: "+err.Error())
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message":      fmt.Sprintf("League restored from snapshot (%d teams, %d matches).", len(snapshot.Teams), len(snapshot.Matches)),
+		"warnings":     warnings,
+		"league_table": leagueTable,
+	})
+}
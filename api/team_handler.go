@@ -1,11 +1,14 @@
 package api
 
 import (
+	"MatchSimulator_Insider/logging"
+	"MatchSimulator_Insider/models"
 	"MatchSimulator_Insider/services/abstracts"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -13,15 +16,160 @@ import (
 type TeamHandler struct {
 	teamService   abstracts.TeamService
 	leagueService abstracts.ILeagueService
+	auditService  abstracts.AuditService
+	presets       models.SeedPresets
 }
 
-func NewTeamHandler(ts abstracts.TeamService, ls abstracts.ILeagueService) *TeamHandler {
+func NewTeamHandler(ts abstracts.TeamService, ls abstracts.ILeagueService, as abstracts.AuditService, presets models.SeedPresets) *TeamHandler {
 	return &TeamHandler{
 		teamService:   ts,
 		leagueService: ls,
+		auditService:  as,
+		presets:       presets,
 	}
 }
 
+// teamAuditEntry, bir takım alanının değişikliği için denetim kaydı hazırlar. previous nil ise eski değer boş kalır.
+func teamAuditEntry(teamID int, action string, previous *models.Team, oldValue func(models.Team) interface{}, newValue interface{}, reason string) models.AuditEntry {
+	entry := models.AuditEntry{
+		EntityType: models.AuditEntityTeam,
+		EntityID:   teamID,
+		Action:     action,
+		NewValue:   auditValue(newValue),
+		Reason:     reason,
+	}
+	if previous != nil {
+		entry.OldValue = auditValue(oldValue(*previous))
+	}
+	return entry
+}
+
+// teamLifecycleAuditValue, eklenen veya silinen takımın denetim kaydındaki hâlidir.
+type teamLifecycleAuditValue struct {
+	Name     string `json:"name"`
+	Strength int    `json:"strength"`
+	Division int    `json:"division"`
+}
+
+// CreateTeamHandler, yeni bir takım ekler ve fikstürü yeniden oluşturur. Sezonda maç oynandıysa önce lig sıfırlanmalıdır.
+func (h *TeamHandler) CreateTeamHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only POST method is supported for this endpoint.")
+		return
+	}
+	ctx := r.Context()
+	var reqBody CreateTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		respondWithBodyError(w, "Invalid request body", err)
+		return
+	}
+	defer r.Body.Close()
+
+	team, err := h.leagueService.AddTeam(ctx, models.Team{Name: reqBody.Name, Strength: reqBody.Strength, Division: reqBody.Division})
+	if err != nil {
+		respondWithServiceError(w, err, "Error creating team")
+		return
+	}
+	if !recordAudit(w, r, h.auditService, models.AuditEntry{
+		EntityType: models.AuditEntityTeam,
+		EntityID:   team.ID,
+		Action:     models.AuditActionTeamCreate,
+		NewValue:   auditValue(teamLifecycleAuditValue{Name: team.Name, Strength: team.Strength, Division: team.Division}),
+		Reason:     reqBody.Reason,
+	}) {
+		return
+	}
+
+	setVersionETag(w, team.Version)
+	w.Header().Set("Location", fmt.Sprintf("/teams/%d", team.ID))
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"message": fmt.Sprintf("Team '%s' created with ID %d. The fixture has been regenerated.", team.Name, team.ID),
+		"team":    team,
+	})
+}
+
+// DeleteTeamHandler, bir takımı puan düzeltmeleriyle birlikte siler ve fikstürü kalan takımlar için yeniden oluşturur.
+// Sezonda maç oynandıysa önce lig sıfırlanmalıdır. İsteğe bağlı ?reason= denetim kaydına yazılır.
+func (h *TeamHandler) DeleteTeamHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only DELETE method is supported for this endpoint.")
+		return
+	}
+	ctx := r.Context()
+	teamID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid team ID: Must be a number.")
+		return
+	}
+	removed, err := h.leagueService.RemoveTeam(ctx, teamID)
+	if err != nil {
+		respondWithServiceError(w, err, "Error deleting team")
+		return
+	}
+	if !recordAudit(w, r, h.auditService, models.AuditEntry{
+		EntityType: models.AuditEntityTeam,
+		EntityID:   teamID,
+		Action:     models.AuditActionTeamDelete,
+		OldValue:   auditValue(teamLifecycleAuditValue{Name: removed.Name, Strength: removed.Strength, Division: removed.Division}),
+		Reason:     r.URL.Query().Get("reason"),
+	}) {
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message": fmt.Sprintf("Team '%s' (ID: %d) deleted. The fixture has been regenerated.", removed.Name, removed.ID),
+		"team":    removed,
+	})
+}
+
+// GetTeamsHandler, takımları ID sırasıyla listeler. division sorgu parametresi ile tek bir lig kademesi seçilebilir.
+func (h *TeamHandler) GetTeamsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	division := 0
+	if divisionStr := r.URL.Query().Get("division"); divisionStr != "" {
+		parsed, err := strconv.Atoi(divisionStr)
+		if err != nil || parsed < 1 {
+			respondWithError(w, http.StatusBadRequest, "Invalid division: Must be a positive number.")
+			return
+		}
+		division = parsed
+	}
+	teams, err := h.teamService.GetAllTeams(r.Context())
+	if err != nil {
+		respondWithServiceError(w, err, "Error retrieving teams")
+		return
+	}
+	listed := make([]models.Team, 0, len(teams))
+	for _, team := range teams {
+		if division == 0 || team.Division == division {
+			listed = append(listed, team)
+		}
+	}
+	sort.Slice(listed, func(i, j int) bool { return listed[i].ID < listed[j].ID })
+	respondWithJSON(w, http.StatusOK, listed)
+}
+
+// GetTeamHandler, bir takımı döndürür. Cevaptaki ETag, takımı düzenleyen PUT isteklerinde If-Match ile gönderilebilir.
+func (h *TeamHandler) GetTeamHandler(w http.ResponseWriter, r *http.Request) {
+	teamID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid team ID: Must be a number.")
+		return
+	}
+	team, err := h.teamService.GetTeamByID(r.Context(), teamID)
+	if err != nil {
+		respondWithServiceError(w, err, "Error retrieving team")
+		return
+	}
+	if notModified(w, r, team.Version) {
+		return
+	}
+	setVersionETag(w, team.Version)
+	respondWithJSON(w, http.StatusOK, team)
+}
+
 // UpdateTeamStrengthHandler, belirli bir takımın gücünü günceller.
 func (h *TeamHandler) UpdateTeamStrengthHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
@@ -45,43 +193,39 @@ func (h *TeamHandler) UpdateTeamStrengthHandler(w http.ResponseWriter, r *http.R
 	}
 	var reqBody UpdateTeamStrengthRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		respondWithBodyError(w, "Invalid request body", err)
 		return
 	}
 	defer r.Body.Close()
 
-	err = h.teamService.UpdateTeamStrength(ctx, teamID, reqBody.Strength)
+	expectedVersion, err := parseIfMatch(r)
 	if err != nil {
-		if strings.Contains(err.Error(), "bulunamadı") { // Varsayım
-			respondWithError(w, http.StatusNotFound, err.Error())
-		} else if strings.Contains(err.Error(), "geçersiz güç değeri") { // Varsayım
-			respondWithError(w, http.StatusBadRequest, err.Error())
-		} else {
-			respondWithError(w, http.StatusInternalServerError, "Error updating team strength: "+err.Error())
-		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	leagueTable, tableErr := h.leagueService.GetLeagueTable(ctx)
-	if tableErr != nil {
-		log.Printf("UpdateTeamStrengthHandler: Error retrieving league table for logging: %v", tableErr)
-	} else if leagueTable != nil {
-		updatedTeamForLog, _ := h.teamService.GetTeamByID(ctx, teamID)
-		teamNameToLog := "Unknown"
-		if updatedTeamForLog != nil {
-			teamNameToLog = updatedTeamForLog.Name
-		}
-		logLeagueTableToConsole(fmt.Sprintf("League Table after updating strength of Team %s (ID %d) to %d", teamNameToLog, teamID, reqBody.Strength), leagueTable)
+	previous, _ := h.teamService.GetTeamByID(ctx, teamID) // Eski değer denetim kaydı içindir; takım yoksa güncelleme hatayı bildirir
+	err = h.teamService.UpdateTeamStrength(ctx, teamID, reqBody.Strength, expectedVersion)
+	if err != nil {
+		respondWithServiceError(w, err, "Error updating team strength")
+		return
 	}
+	strengthOf := func(team models.Team) interface{} { return team.Strength }
+	if !recordAudit(w, r, h.auditService, teamAuditEntry(teamID, models.AuditActionStrengthChange, previous, strengthOf, reqBody.Strength, reqBody.Reason)) {
+		return
+	}
+
+	logLeagueTableFrom(ctx, h.leagueService, "league table after updating a team's strength", logging.KeyTeamID, teamID, "strength", reqBody.Strength)
 
 	updatedTeam, teamErr := h.teamService.GetTeamByID(ctx, teamID)
 	if teamErr != nil {
-		log.Printf("UpdateTeamStrengthHandler: Team strength updated but error retrieving updated team info: %v", teamErr)
+		slog.WarnContext(ctx, "team strength updated but the team could not be retrieved", logging.KeyTeamID, teamID, "error", teamErr)
 		respondWithJSON(w, http.StatusOK, map[string]string{
 			"message": fmt.Sprintf("Team ID %d strength successfully updated to %d. Could not retrieve team details.", teamID, reqBody.Strength),
 		})
 		return
 	}
+	setVersionETag(w, updatedTeam.Version)
 	respondWithJSON(w, http.StatusOK, map[string]interface{}{
 		"message": fmt.Sprintf("Team ID %d strength successfully updated to %d.", teamID, reqBody.Strength),
 		"team":    updatedTeam,
@@ -111,7 +255,7 @@ func (h *TeamHandler) UpdateTeamNameHandler(w http.ResponseWriter, r *http.Reque
 	}
 	var reqBody UpdateTeamNameRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		respondWithBodyError(w, "Invalid request body", err)
 		return
 	}
 	defer r.Body.Close()
@@ -120,74 +264,235 @@ func (h *TeamHandler) UpdateTeamNameHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	err = h.teamService.UpdateTeamName(ctx, teamID, reqBody.Name)
+	expectedVersion, err := parseIfMatch(r)
 	if err != nil {
-		if strings.Contains(err.Error(), "bulunamadı") {
-			respondWithError(w, http.StatusNotFound, err.Error())
-		} else if strings.Contains(err.Error(), "zaten kullanımda") || strings.Contains(err.Error(), "unique constraint") { // Varsayım
-			respondWithError(w, http.StatusConflict, err.Error())
-		} else if strings.Contains(err.Error(), "boş olamaz") {
-			respondWithError(w, http.StatusBadRequest, err.Error())
-		} else {
-			respondWithError(w, http.StatusInternalServerError, "Error updating team name: "+err.Error())
-		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	leagueTable, tableErr := h.leagueService.GetLeagueTable(ctx)
-	if tableErr != nil {
-		log.Printf("UpdateTeamNameHandler: Error retrieving league table for logging: %v", tableErr)
-	} else if leagueTable != nil {
-		logLeagueTableToConsole(fmt.Sprintf("League Table after updating name of Team ID %d to '%s'", teamID, reqBody.Name), leagueTable)
+	previous, _ := h.teamService.GetTeamByID(ctx, teamID)
+	err = h.teamService.UpdateTeamName(ctx, teamID, reqBody.Name, expectedVersion)
+	if err != nil {
+		respondWithServiceError(w, err, "Error updating team name")
+		return
 	}
+	nameOf := func(team models.Team) interface{} { return team.Name }
+	if !recordAudit(w, r, h.auditService, teamAuditEntry(teamID, models.AuditActionNameChange, previous, nameOf, reqBody.Name, reqBody.Reason)) {
+		return
+	}
+
+	logLeagueTableFrom(ctx, h.leagueService, "league table after renaming a team", logging.KeyTeamID, teamID, "name", reqBody.Name)
 
 	updatedTeam, teamErr := h.teamService.GetTeamByID(ctx, teamID)
 	if teamErr != nil {
-		log.Printf("UpdateTeamNameHandler: Team name updated but error retrieving updated team info: %v", teamErr)
+		slog.WarnContext(ctx, "team name updated but the team could not be retrieved", logging.KeyTeamID, teamID, "error", teamErr)
 		respondWithJSON(w, http.StatusOK, map[string]string{
 			"message": fmt.Sprintf("Team ID %d name successfully updated to '%s'. Could not retrieve team details.", teamID, reqBody.Name),
 		})
 		return
 	}
+	setVersionETag(w, updatedTeam.Version)
 	respondWithJSON(w, http.StatusOK, map[string]interface{}{
 		"message": fmt.Sprintf("Team ID %d name successfully updated to '%s'.", teamID, reqBody.Name),
 		"team":    updatedTeam,
 	})
 }
 
-// ResetTeamsToDefaultsHandler, tüm takımların isimlerini ve güçlerini varsayılana sıfırlar.
+// ResetTeamsToDefaultsHandler, bütün takımları silip bir seed preset'inin takımlarıyla yeni bir lig başlatır.
+// preset sorgu parametresi verilmezse seed dosyasındaki varsayılan preset kullanılır.
 func (h *TeamHandler) ResetTeamsToDefaultsHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		respondWithError(w, http.StatusMethodNotAllowed, "Only POST method is supported for this endpoint.")
 		return
 	}
 	ctx := r.Context()
-	err := h.teamService.ResetTeamsToDefaults(ctx)
-	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Error resetting teams to defaults: "+err.Error())
+	presetName := r.URL.Query().Get("preset")
+	if presetName == "" {
+		presetName = h.presets.Default
+	}
+	preset, ok := h.presets.Find(presetName)
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Unknown preset '%s'. See GET /teams/presets for the available presets.", presetName))
 		return
 	}
-	err = h.leagueService.ResetLeague(ctx)
-	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Error resetting league after setting teams to defaults: "+err.Error())
+	previousTeams, _ := h.teamService.GetAllTeams(ctx) // Yalnızca denetim kaydı içindir
+	if _, err := h.leagueService.ReplaceTeams(ctx, preset.TeamModels()); err != nil {
+		respondWithServiceError(w, err, "Error resetting teams to preset '"+preset.Name+"'")
+		return
+	}
+	if !recordAudit(w, r, h.auditService, models.AuditEntry{
+		EntityType: models.AuditEntityLeague,
+		Action:     models.AuditActionDefaultsReset,
+		OldValue:   auditValue(map[string]int{"teams": len(previousTeams)}),
+		NewValue:   auditValue(map[string]interface{}{"preset": preset.Name, "teams": len(preset.Teams)}),
+		Reason:     r.URL.Query().Get("reason"),
+	}) {
 		return
 	}
 
 	finalTable, tableErr := h.leagueService.GetLeagueTable(ctx)
 	if tableErr != nil {
-		log.Printf("ResetTeamsToDefaultsHandler: Error retrieving league table for logging after full reset: %v", tableErr)
-	} else if finalTable != nil {
-		logLeagueTableToConsole("League Table after /teams/reset-defaults", finalTable)
+		slog.WarnContext(ctx, "teams reset but the league table could not be retrieved", "preset", preset.Name, "error", tableErr)
+		respondWithJSON(w, http.StatusOK, map[string]string{
+			"message": fmt.Sprintf("Teams reset to preset '%s', league reset. Could not fetch current league table for response.", preset.Name),
+			"preset":  preset.Name,
+		})
+		return
 	}
+	logLeagueTable(ctx, "league table after resetting the teams", finalTable, "preset", preset.Name)
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message":      fmt.Sprintf("Teams have been replaced with the %s preset. League statistics and fixture have also been renewed.", presetTitle(preset)),
+		"preset":       preset.Name,
+		"league_table": finalTable,
+	})
+}
 
-	if tableErr != nil {
+// GetPresetsHandler, /teams/reset-defaults ile uygulanabilecek seed preset'lerini listeler.
+func (h *TeamHandler) GetPresetsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, h.presets)
+}
+
+// presetTitle, preset'in görünen adını, yoksa anahtarını döndürür.
+func presetTitle(preset models.SeedPreset) string {
+	if preset.Title != "" {
+		return preset.Title
+	}
+	return preset.Name
+}
+
+// UpdateTeamDivisionHandler, belirli bir takımı başka bir lige (kademeye) taşır.
+func (h *TeamHandler) UpdateTeamDivisionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only PUT method is supported for this endpoint.")
+		return
+	}
+	ctx := r.Context()
+	teamID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid team ID: Must be a number.")
+		return
+	}
+	var reqBody UpdateTeamDivisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		respondWithBodyError(w, "Invalid request body", err)
+		return
+	}
+	defer r.Body.Close()
+	if reqBody.Division < 1 {
+		respondWithError(w, http.StatusBadRequest, "Division must be 1 or greater.")
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	previous, _ := h.teamService.GetTeamByID(ctx, teamID)
+	err = h.teamService.UpdateTeamDivision(ctx, teamID, reqBody.Division, expectedVersion)
+	if err != nil {
+		respondWithServiceError(w, err, "Error updating team division")
+		return
+	}
+	divisionOf := func(team models.Team) interface{} { return team.Division }
+	if !recordAudit(w, r, h.auditService, teamAuditEntry(teamID, models.AuditActionDivisionChange, previous, divisionOf, reqBody.Division, reqBody.Reason)) {
+		return
+	}
+
+	updatedTeam, teamErr := h.teamService.GetTeamByID(ctx, teamID)
+	if teamErr != nil {
+		slog.WarnContext(ctx, "team division updated but the team could not be retrieved", logging.KeyTeamID, teamID, logging.KeyLeagueID, reqBody.Division, "error", teamErr)
 		respondWithJSON(w, http.StatusOK, map[string]string{
-			"message": "All teams reset to defaults, league reset. Could not fetch current league table for response.",
+			"message": fmt.Sprintf("Team ID %d moved to division %d. Could not retrieve team details.", teamID, reqBody.Division),
 		})
 		return
 	}
+	setVersionETag(w, updatedTeam.Version)
 	respondWithJSON(w, http.StatusOK, map[string]interface{}{
-		"message":      "All teams have been reset to default names and strengths. League statistics and fixture have also been renewed.",
-		"league_table": finalTable,
+		"message": fmt.Sprintf("Team ID %d moved to division %d. Use /reset-league to regenerate the fixture for the new divisions.", teamID, reqBody.Division),
+		"team":    updatedTeam,
+	})
+}
+
+// ApplyPointsAdjustmentHandler, bir takıma gerekçesiyle birlikte puan silme veya puan ekleme uygular.
+func (h *TeamHandler) ApplyPointsAdjustmentHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only POST method is supported for this endpoint.")
+		return
+	}
+	ctx := r.Context()
+	teamID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid team ID: Must be a number.")
+		return
+	}
+	var reqBody PointsAdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		respondWithBodyError(w, "Invalid request body", err)
+		return
+	}
+	defer r.Body.Close()
+
+	adjustment, err := h.teamService.ApplyPointsAdjustment(ctx, teamID, reqBody.Points, reqBody.Reason)
+	if err != nil {
+		respondWithServiceError(w, err, "Error applying points adjustment")
+		return
+	}
+	if !recordAudit(w, r, h.auditService, models.AuditEntry{
+		EntityType: models.AuditEntityTeam,
+		EntityID:   teamID,
+		Action:     models.AuditActionPointsAdjustment,
+		NewValue:   auditValue(map[string]int{"points": adjustment.Points, "adjustment_id": adjustment.ID}),
+		Reason:     adjustment.Reason,
+	}) {
+		return
+	}
+
+	updatedLeagueTable, tableErr := h.leagueService.GetLeagueTable(ctx)
+	if tableErr != nil {
+		slog.WarnContext(ctx, "points adjustment applied but the league table could not be retrieved", logging.KeyTeamID, teamID, "error", tableErr)
+		respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+			"message":    fmt.Sprintf("Points adjustment of %d applied to team ID %d. Could not retrieve updated league table.", adjustment.Points, teamID),
+			"adjustment": adjustment,
+		})
+		return
+	}
+	logLeagueTable(ctx, "league table after a points adjustment", updatedLeagueTable, logging.KeyTeamID, teamID, "points", adjustment.Points)
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"message":      fmt.Sprintf("Points adjustment of %d applied to team ID %d.", adjustment.Points, teamID),
+		"adjustment":   adjustment,
+		"league_table": updatedLeagueTable,
 	})
 }
+
+// GetPointsAdjustmentsHandler, puan düzeltmelerini listeler. team_id sorgu parametresi ile tek bir takıma göre süzülebilir.
+func (h *TeamHandler) GetPointsAdjustmentsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	teamID := 0
+	if teamIDStr := r.URL.Query().Get("team_id"); teamIDStr != "" {
+		parsed, err := strconv.Atoi(teamIDStr)
+		if err != nil || parsed < 1 {
+			respondWithError(w, http.StatusBadRequest, "Invalid team_id: Must be a positive number.")
+			return
+		}
+		teamID = parsed
+	}
+
+	adjustments, err := h.teamService.GetPointsAdjustments(r.Context(), teamID)
+	if err != nil {
+		respondWithServiceError(w, err, "Error retrieving points adjustments")
+		return
+	}
+	if adjustments == nil {
+		adjustments = []models.PointsAdjustment{}
+	}
+	respondWithJSON(w, http.StatusOK, adjustments)
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type TournamentHandler struct {
+	tournamentService abstracts.ITournamentService
+}
+
+func NewTournamentHandler(ts abstracts.ITournamentService) *TournamentHandler {
+	return &TournamentHandler{
+		tournamentService: ts,
+	}
+}
+
+// CreateTournamentHandler, takımları gruplara çeker ve grup aşaması fikstürünü oluşturur.
+func (h *TournamentHandler) CreateTournamentHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only POST method is supported for this endpoint.")
+		return
+	}
+	ctx := r.Context()
+	var reqBody CreateTournamentRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		respondWithBodyError(w, "Invalid request body", err)
+		return
+	}
+	defer r.Body.Close()
+	if reqBody.GroupCount < 1 {
+		respondWithError(w, http.StatusBadRequest, "group_count must be at least 1.")
+		return
+	}
+
+	groups, err := h.tournamentService.CreateTournament(ctx, reqBody.GroupCount, reqBody.Pots)
+	if err != nil {
+		respondWithServiceError(w, err, "Error creating tournament")
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"message": fmt.Sprintf("Tournament created with %d groups. Group stage fixture has been generated.", len(groups)),
+		"groups":  groups,
+	})
+}
+
+// GetGroupStandingsHandler, grup aşamasındaki her grubun puan durumunu döndürür.
+func (h *TournamentHandler) GetGroupStandingsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	groups, err := h.tournamentService.GetGroupStandings(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error retrieving group standings: "+err.Error())
+		return
+	}
+	if groups == nil {
+		groups = []models.TournamentGroup{}
+	}
+	respondWithJSON(w, http.StatusOK, groups)
+}
+
+// GetBracketHandler, oluşturulmuş eleme turu maçlarını tur sırasına göre döndürür.
+func (h *TournamentHandler) GetBracketHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only GET method is supported for this endpoint.")
+		return
+	}
+	matches, err := h.tournamentService.GetKnockoutMatches(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error retrieving knockout bracket: "+err.Error())
+		return
+	}
+	if matches == nil {
+		matches = []models.Match{}
+	}
+	respondWithJSON(w, http.StatusOK, matches)
+}
+
+// AdvanceTournamentHandler, tamamlanan aşamaya göre bir sonraki eleme turunu oluşturur veya şampiyonu döndürür.
+func (h *TournamentHandler) AdvanceTournamentHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Only POST method is supported for this endpoint.")
+		return
+	}
+	ctx := r.Context()
+	reqBody := AdvanceTournamentRequest{AdvancePerGroup: 2}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil && err != io.EOF {
+		respondWithBodyError(w, "Invalid request body", err)
+		return
+	}
+	defer r.Body.Close()
+
+	round, newMatches, championTeamID, err := h.tournamentService.AdvanceTournament(ctx, reqBody.AdvancePerGroup)
+	if err != nil {
+		respondWithServiceError(w, err, "Error advancing tournament")
+		return
+	}
+
+	if championTeamID != 0 {
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{
+			"message":          "Tournament completed.",
+			"champion_team_id": championTeamID,
+		})
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message": fmt.Sprintf("Knockout round %d generated. Play it with /next-week or /play-all.", round),
+		"round":   round,
+		"matches": newMatches,
+	})
+}
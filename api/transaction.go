@@ -0,0 +1,63 @@
+package api
+
+import (
+	"MatchSimulator_Insider/services/abstracts"
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// errRequestFailed, handler 4xx/5xx döndürdüğünde transaction'ın geri alınması için kullanılır.
+var errRequestFailed = errors.New("request failed")
+
+// bufferedResponse, handler'ın cevabını transaction sonucu belli olana kadar bellekte tutar.
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferedResponse() *bufferedResponse {
+	return &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+}
+
+func (b *bufferedResponse) Header() http.Header { return b.header }
+
+func (b *bufferedResponse) Write(p []byte) (int, error) { return b.body.Write(p) }
+
+func (b *bufferedResponse) WriteHeader(status int) { b.status = status }
+
+// flushTo, tutulan cevabı gerçek ResponseWriter'a yazar.
+func (b *bufferedResponse) flushTo(w http.ResponseWriter) {
+	for key, values := range b.header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(b.status)
+	if _, err := w.Write(b.body.Bytes()); err != nil {
+		slog.Warn("response could not be written", "error", err)
+	}
+}
+
+// withTransaction, bir değişiklik endpoint'ini tek bir transaction içinde çalıştırır.
+// Handler 400 altı bir kod dönerse değişiklikler kaydedilir, aksi halde hepsi geri alınır;
+// böylece yarıda kalan bir istek (örn. play-all) veritabanında yarım bir hafta bırakmaz.
+func withTransaction(transactor abstracts.Transactor, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		buffered := newBufferedResponse()
+		err := transactor.RunInTx(r.Context(), func(txCtx context.Context) error {
+			next(buffered, r.WithContext(txCtx))
+			if buffered.status >= http.StatusBadRequest {
+				return errRequestFailed
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errRequestFailed) {
+			slog.ErrorContext(r.Context(), "transaction could not be committed", "method", r.Method, "path", r.URL.Path, "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Changes could not be saved: "+err.Error())
+			return
+		}
+		buffered.flushTo(w)
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// recordingTransactor records the outcome RunInTx would give a real transaction.
+type recordingTransactor struct {
+	commitErr  error
+	committed  bool
+	rolledBack bool
+}
+
+func (t *recordingTransactor) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		t.rolledBack = true
+		return err
+	}
+	if t.commitErr != nil {
+		t.rolledBack = true
+		return t.commitErr
+	}
+	t.committed = true
+	return nil
+}
+
+func TestWithTransaction(t *testing.T) {
+	tests := []struct {
+		name           string
+		handlerStatus  int
+		commitErr      error
+		wantStatus     int
+		wantCommitted  bool
+		wantRolledBack bool
+	}{
+		{name: "Success Commits", handlerStatus: http.StatusOK, wantStatus: http.StatusOK, wantCommitted: true},
+		{name: "Client Error Rolls Back", handlerStatus: http.StatusBadRequest, wantStatus: http.StatusBadRequest, wantRolledBack: true},
+		{name: "Server Error Rolls Back", handlerStatus: http.StatusInternalServerError, wantStatus: http.StatusInternalServerError, wantRolledBack: true},
+		{name: "Commit Failure Returns 500", handlerStatus: http.StatusOK, commitErr: errors.New("connection lost"), wantStatus: http.StatusInternalServerError, wantRolledBack: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactor := &recordingTransactor{commitErr: tt.commitErr}
+			handler := withTransaction(transactor, func(w http.ResponseWriter, r *http.Request) {
+				respondWithJSON(w, tt.handlerStatus, map[string]string{"message": "done"})
+			})
+
+			recorder := httptest.NewRecorder()
+			handler(recorder, httptest.NewRequest(http.MethodPost, "/next-week", nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if transactor.committed != tt.wantCommitted || transactor.rolledBack != tt.wantRolledBack {
+				t.Errorf("committed = %v, rolledBack = %v; want %v, %v", transactor.committed, transactor.rolledBack, tt.wantCommitted, tt.wantRolledBack)
+			}
+			if tt.commitErr == nil && recorder.Body.String() != `{"message":"done"}` {
+				t.Errorf("body = %q, want the handler's response", recorder.Body.String())
+			}
+		})
+	}
+}
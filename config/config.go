@@ -1,26 +1,130 @@
 package config
 
 import (
+	"MatchSimulator_Insider/logging"
+	"MatchSimulator_Insider/models"
 	"encoding/json"
 	"fmt"
-	"log" 
+	"log/slog"
 	"os"
+	"time"
 )
 
 
 type Config struct {
+	Storage  string    `json:"storage"` // "postgres" (varsayılan), "sqlite" veya "memory"
 	Database DBConfig  `json:"database"` 
 	Server   APIConfig `json:"server"`   
+	Pyramid  models.PyramidRules `json:"pyramid"`
+	// Seed, maç simülasyonlarının rastgele sayı üretecini başlatır. 0 ise her açılışta zamana göre yeni bir seed seçilir.
+	Seed int64 `json:"seed"`
+	// SeedFile, takım preset'lerinin (seed kadrolarının) okunduğu JSON dosyasıdır. Boşsa uygulamayla gelen preset'ler kullanılır.
+	SeedFile string `json:"seedFile"`
+	// Logging, log seviyesini ve çıktı biçimini belirler.
+	Logging LoggingConfig `json:"logging"`
 }
 
+// LoggingConfig, yapılandırılmış log ayarlarıdır. Boş değerlerde seviye "info", biçim "json" olur.
+type LoggingConfig struct {
+	Level  string `json:"level"`  // "debug", "info", "warn" veya "error"; lig tablosu dökümleri yalnızca "debug"da yazılır
+	Format string `json:"format"` // "json" veya "text"
+}
+
+// Desteklenen depolama türleri. sqlite tek bir dosyada çalışır; memory seçildiğinde veritabanı bağlantısı kurulmaz ve veriler süreç kapanınca kaybolur.
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
+)
+
+// DefaultSQLitePath, sqlitePath verilmediğinde kullanılan veritabanı dosyasıdır.
+const DefaultSQLitePath = "league.db"
+
 
 type DBConfig struct {
 	ConnectionString string `json:"connectionString"`
+	// SQLitePath, storage "sqlite" iken kullanılan veritabanı dosyasının yoludur.
+	SQLitePath string `json:"sqlitePath"`
+	// DisableAutoMigrate true ise bekleyen migration'lar başlangıçta uygulanmaz; "migrate up" ile elle çalıştırılır.
+	DisableAutoMigrate bool `json:"disableAutoMigrate"`
+	// PostgreSQL bağlantı havuzu ayarları. Sıfır veya boş değerlerde pgxpool varsayılanları kullanılır.
+	MaxConns        int32  `json:"maxConns"`
+	MinConns        int32  `json:"minConns"`
+	MaxConnLifetime string `json:"maxConnLifetime"` // Örn. "1h"
+	MaxConnIdleTime string `json:"maxConnIdleTime"` // Örn. "30m"
 }
 
 
 type APIConfig struct {
 	Port string `json:"port"`
+	// MaxBodyBytes, istek gövdesinin azami boyutudur; aşan istekler 413 alır. 0 ise DefaultMaxBodyBytes kullanılır.
+	MaxBodyBytes int64 `json:"maxBodyBytes"`
+	// CORS, tarayıcıdaki ön yüzün API'yi hangi origin'lerden çağırabileceğini belirler.
+	CORS CORSConfig `json:"cors"`
+	// Sunucu zaman aşımları, Go süre biçiminde (örn. "15s", "2m"). Boş değerlerde Default* sabitleri kullanılır.
+	ReadTimeout     string `json:"readTimeout"`     // İsteğin başlık ve gövdesinin okunması için azami süre
+	WriteTimeout    string `json:"writeTimeout"`    // Cevabın yazılması için azami süre; uzun simülasyonlar bu süreye sığmalıdır
+	IdleTimeout     string `json:"idleTimeout"`     // Keep-alive bağlantısının bir sonraki isteği bekleme süresi
+	ShutdownTimeout string `json:"shutdownTimeout"` // SIGTERM sonrası süren isteklerin bitmesi için beklenen süre; aşılırsa simülasyonlar iptal edilir
+	DrainDelay      string `json:"drainDelay"`      // SIGTERM sonrası /readyz 503 dönerken yeni isteklerin kabul edilmeye devam ettiği süre; "0s" beklemeyi kapatır
+}
+
+// Sunucu zaman aşımlarının varsayılanları.
+const (
+	DefaultReadTimeout     = 15 * time.Second
+	DefaultWriteTimeout    = 60 * time.Second
+	DefaultIdleTimeout     = 120 * time.Second
+	DefaultShutdownTimeout = 20 * time.Second
+	DefaultDrainDelay      = 5 * time.Second
+)
+
+// ServerTimeouts, APIConfig'teki sürelerin çözümlenmiş hâlidir.
+type ServerTimeouts struct {
+	Read     time.Duration
+	Write    time.Duration
+	Idle     time.Duration
+	Shutdown time.Duration
+	Drain    time.Duration
+}
+
+// Timeouts, sunucu zaman aşımlarını çözümler; boş değerler için varsayılanları döndürür.
+func (c APIConfig) Timeouts() (ServerTimeouts, error) {
+	timeouts := ServerTimeouts{Read: DefaultReadTimeout, Write: DefaultWriteTimeout, Idle: DefaultIdleTimeout, Shutdown: DefaultShutdownTimeout, Drain: DefaultDrainDelay}
+	fields := []struct {
+		name      string
+		value     string
+		target    *time.Duration
+		allowZero bool
+	}{
+		{"readTimeout", c.ReadTimeout, &timeouts.Read, false},
+		{"writeTimeout", c.WriteTimeout, &timeouts.Write, false},
+		{"idleTimeout", c.IdleTimeout, &timeouts.Idle, false},
+		{"shutdownTimeout", c.ShutdownTimeout, &timeouts.Shutdown, false},
+		{"drainDelay", c.DrainDelay, &timeouts.Drain, true},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(field.value)
+		if err != nil {
+			return ServerTimeouts{}, fmt.Errorf("invalid %s %q: %w", field.name, field.value, err)
+		}
+		if duration < 0 || (duration == 0 && !field.allowZero) {
+			return ServerTimeouts{}, fmt.Errorf("invalid %s %q: must be positive", field.name, field.value)
+		}
+		*field.target = duration
+	}
+	return timeouts, nil
+}
+
+// DefaultMaxBodyBytes, maxBodyBytes verilmediğinde kullanılan gövde sınırıdır (1 MiB).
+const DefaultMaxBodyBytes = 1 << 20
+
+// CORSConfig, CORS ayarlarıdır. AllowedOrigins boşsa CORS başlıkları hiç eklenmez.
+type CORSConfig struct {
+	AllowedOrigins []string `json:"allowedOrigins"` // Örn. ["http://localhost:5173"]; "*" bütün origin'lere izin verir
+	MaxAge         int      `json:"maxAge"`         // Ön kontrol (preflight) cevabının tarayıcıda saklanma süresi, saniye
 }
 
 
@@ -28,12 +132,12 @@ var AppConfig Config
 
 
 func LoadConfig(filePath string) (*Config, error) {
-	log.Printf("INFO: Loading configuration from %s", filePath)
+	slog.Info("loading configuration", "path", filePath)
 
 	configFile, err := os.Open(filePath)
 	if err != nil {
 	
-		log.Printf("WARNING: Could not open config file '%s': %v. Using default values.", filePath, err)
+		slog.Warn("could not open config file, using default values", "path", filePath, "error", err)
 	
 		return nil, fmt.Errorf("could not open config file '%s': %w", filePath, err)
 	}
@@ -48,12 +152,56 @@ func LoadConfig(filePath string) (*Config, error) {
 	
 	if cfg.Server.Port == "" {
 		cfg.Server.Port = "8080" 
-		log.Println("INFO: API port not found in config, using default '8080'.")
+		slog.Info("API port not found in config, using default", "port", cfg.Server.Port)
+	}
+
+	if cfg.Server.MaxBodyBytes < 0 {
+		return nil, fmt.Errorf("invalid maxBodyBytes in config file '%s': cannot be negative", filePath)
+	}
+	if cfg.Server.MaxBodyBytes == 0 {
+		cfg.Server.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if _, err := cfg.Server.Timeouts(); err != nil {
+		return nil, fmt.Errorf("invalid server timeout in config file '%s': %w", filePath, err)
+	}
+
+	switch cfg.Storage {
+	case "":
+		cfg.Storage = StoragePostgres
+	case StoragePostgres, StorageSQLite, StorageMemory:
+	default:
+		return nil, fmt.Errorf("unsupported storage '%s' in config file '%s': use '%s', '%s' or '%s'", cfg.Storage, filePath, StoragePostgres, StorageSQLite, StorageMemory)
+	}
+	if cfg.Storage == StorageSQLite && cfg.Database.SQLitePath == "" {
+		cfg.Database.SQLitePath = DefaultSQLitePath
+		slog.Info("SQLite path not found in config, using default", "path", DefaultSQLitePath)
+	}
+
+	if cfg.Database.MaxConns < 0 || cfg.Database.MinConns < 0 {
+		return nil, fmt.Errorf("invalid pool size in config file '%s': maxConns and minConns cannot be negative", filePath)
+	}
+
+	if _, err := logging.ParseLevel(cfg.Logging.Level); err != nil {
+		return nil, fmt.Errorf("invalid logging level in config file '%s': %w", filePath, err)
+	}
+	switch cfg.Logging.Format {
+	case "":
+		cfg.Logging.Format = logging.FormatJSON
+	case logging.FormatJSON, logging.FormatText:
+	default:
+		return nil, fmt.Errorf("unsupported logging format '%s' in config file '%s': use '%s' or '%s'", cfg.Logging.Format, filePath, logging.FormatJSON, logging.FormatText)
+	}
+
+	if cfg.Pyramid.PromotionSpots == 0 {
+		cfg.Pyramid.PromotionSpots = 1
+	}
+	if cfg.Pyramid.PlayoffTeams == 0 {
+		cfg.Pyramid.PlayoffTeams = 2
 	}
 
-	if cfg.Database.ConnectionString == "" {
+	if cfg.Storage == StoragePostgres && cfg.Database.ConnectionString == "" {
 		
-		log.Println("WARNING: Database connectionString not found in config. Application might not connect to DB.")
+		slog.Warn("database connectionString not found in config, application might not connect to the database")
 		
 	}
 
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"MatchSimulator_Insider/config"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DB is the part of pgx the PostgreSQL services use. *pgxpool.Pool, *pgx.Conn and pgx.Tx all implement it,
+// so a service runs unchanged on the pool or inside a transaction started by RunInTx.
+type DB interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// txKey, context içinde RunInTx tarafından başlatılan transaction'ı taşır.
+type txKey struct{}
+
+// Conn returns the transaction RunInTx stored in ctx, or db when there is none.
+// Services call it for every statement so they join the request's transaction automatically.
+func Conn(ctx context.Context, db DB) DB {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// RunInTx runs fn in a transaction that is committed when fn returns nil and rolled back otherwise.
+// If ctx already carries a transaction, fn joins it and the outermost call decides the outcome.
+// A transaction started by a service inside fn becomes a savepoint of the outer transaction.
+func RunInTx(ctx context.Context, db DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("database.RunInTx: Could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(context.Background()); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.WarnContext(ctx, "transaction rollback failed", "error", err)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("database.RunInTx: Could not commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Transactor runs units of work in PostgreSQL transactions.
+type Transactor struct {
+	DB DB
+}
+
+// NewTransactor creates an abstracts.Transactor backed by RunInTx.
+func NewTransactor(db DB) abstracts.Transactor {
+	return &Transactor{DB: db}
+}
+
+// RunInTx runs fn in one transaction; see the package-level RunInTx.
+func (t *Transactor) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return RunInTx(ctx, t.DB, fn)
+}
+
+// NewPool opens a connection pool sized from the database configuration and checks that it can connect.
+func NewPool(ctx context.Context, cfg config.DBConfig) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.ConnectionString)
+	if err != nil {
+		return nil, fmt.Errorf("database.NewPool: Invalid connection string: %w", err)
+	}
+	if cfg.MaxConns > 0 {
+		poolConfig.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns > 0 {
+		poolConfig.MinConns = cfg.MinConns
+	}
+	if poolConfig.MinConns > poolConfig.MaxConns {
+		return nil, fmt.Errorf("database.NewPool: minConns (%d) cannot be greater than maxConns (%d)", poolConfig.MinConns, poolConfig.MaxConns)
+	}
+	if poolConfig.MaxConnLifetime, err = parseDuration(cfg.MaxConnLifetime, poolConfig.MaxConnLifetime); err != nil {
+		return nil, fmt.Errorf("database.NewPool: Invalid maxConnLifetime: %w", err)
+	}
+	if poolConfig.MaxConnIdleTime, err = parseDuration(cfg.MaxConnIdleTime, poolConfig.MaxConnIdleTime); err != nil {
+		return nil, fmt.Errorf("database.NewPool: Invalid maxConnIdleTime: %w", err)
+	}
+
+	poolConfig.ConnConfig.Tracer = queryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("database.NewPool: Could not create pool: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("database.NewPool: Could not ping the database: %w", err)
+	}
+	slog.InfoContext(ctx, "database pool ready", "max_conns", poolConfig.MaxConns, "min_conns", poolConfig.MinConns)
+	return pool, nil
+}
+
+// parseDuration parses a Go duration such as "30m", returning fallback for an empty value.
+func parseDuration(value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration must be positive, got %s", value)
+	}
+	return duration, nil
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"MatchSimulator_Insider/queries"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// leagueLockKey is the advisory lock key that serialises league state changes across server instances.
+const leagueLockKey = 7_240_036
+
+// AdvisoryLeagueLocker takes the league lock as a PostgreSQL advisory lock.
+type AdvisoryLeagueLocker struct {
+	Pool *pgxpool.Pool
+}
+
+// NewAdvisoryLeagueLocker creates a league locker that works across every instance sharing the database.
+func NewAdvisoryLeagueLocker(pool *pgxpool.Pool) abstracts.LeagueLocker {
+	return &AdvisoryLeagueLocker{Pool: pool}
+}
+
+// TryLockLeague takes the lock without waiting and returns abstracts.ErrLeagueBusy when another instance holds it.
+// Inside a request transaction the lock is transaction-scoped, so it is held until the changes are committed
+// or rolled back; otherwise a pool connection keeps a session lock until release is called.
+func (l *AdvisoryLeagueLocker) TryLockLeague(ctx context.Context) (func(), error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		if err := tryLock(ctx, tx, queries.TryLeagueXactLockSQL); err != nil {
+			return nil, err
+		}
+		return func() {}, nil
+	}
+
+	conn, err := l.Pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("AdvisoryLeagueLocker.TryLockLeague: Could not acquire a connection: %w", err)
+	}
+	if err := tryLock(ctx, conn, queries.TryLeagueLockSQL); err != nil {
+		conn.Release()
+		return nil, err
+	}
+	return func() {
+		if _, err := conn.Exec(context.Background(), queries.ReleaseLeagueLockSQL, leagueLockKey); err != nil {
+			slog.WarnContext(ctx, "could not release the league lock", "error", err)
+		}
+		conn.Release()
+	}, nil
+}
+
+// tryLock runs one of the pg_try_advisory_* queries for the league lock key.
+func tryLock(ctx context.Context, db DB, query string) error {
+	var locked bool
+	if err := db.QueryRow(ctx, query, leagueLockKey).Scan(&locked); err != nil {
+		return fmt.Errorf("AdvisoryLeagueLocker.TryLockLeague: Could not take the league lock: %w", err)
+	}
+	if !locked {
+		return abstracts.ErrLeagueBusy
+	}
+	return nil
+}
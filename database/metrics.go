@@ -0,0 +1,118 @@
+package database
+
+import (
+	"MatchSimulator_Insider/metrics"
+	"context"
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Backend labels of metrics.DBQueryDuration.
+const (
+	BackendPostgres = "postgres"
+	BackendSQLite   = "sqlite"
+)
+
+// queryOperation returns the kind of a statement (select, insert, update, delete, ...) from its first keyword.
+// Anything unexpected is reported as "other" so the label keeps a small, fixed set of values.
+func queryOperation(sql string) string {
+	keyword, _, _ := strings.Cut(strings.TrimSpace(sql), " ")
+	switch keyword = strings.ToLower(strings.TrimRight(keyword, "\n\t(")); keyword {
+	case "select", "insert", "update", "delete", "with", "create", "alter", "drop", "begin", "commit", "rollback", "savepoint", "release", "pragma":
+		return keyword
+	default:
+		return "other"
+	}
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	at        time.Time
+	operation string
+}
+
+// queryTracer records the latency of every statement run through a pgx connection, including those inside transactions.
+type queryTracer struct{}
+
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{at: time.Now(), operation: queryOperation(data.SQL)})
+}
+
+func (queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryEndData) {
+	if start, ok := ctx.Value(queryStartKey{}).(queryStart); ok {
+		metrics.DBQueryDuration.ObserveSince(start.at, BackendPostgres, start.operation)
+	}
+}
+
+// NewInstrumentedConnector opens connections with drv and records the latency of every statement they run
+// under the given backend label. Use it with sql.OpenDB in place of sql.Open.
+func NewInstrumentedConnector(drv driver.Driver, dsn, backend string) driver.Connector {
+	return &instrumentedConnector{driver: drv, dsn: dsn, backend: backend}
+}
+
+type instrumentedConnector struct {
+	driver  driver.Driver
+	dsn     string
+	backend string
+}
+
+func (c *instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{Conn: conn, backend: c.backend}, nil
+}
+
+func (c *instrumentedConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// instrumentedConn times ExecContext and QueryContext and passes everything else through to the driver's connection.
+type instrumentedConn struct {
+	driver.Conn
+	backend string
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	defer metrics.DBQueryDuration.ObserveSince(time.Now(), c.backend, queryOperation(query))
+	return execer.ExecContext(ctx, query, args)
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	defer metrics.DBQueryDuration.ObserveSince(time.Now(), c.backend, queryOperation(query))
+	return queryer.QueryContext(ctx, query, args)
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"MatchSimulator_Insider/metrics"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestQueryOperation(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{sql: "SELECT id FROM teams", want: "select"},
+		{sql: "\n\t\tINSERT INTO teams (name) VALUES ($1)", want: "insert"},
+		{sql: "update teams SET name = $1", want: "update"},
+		{sql: "WITH ranked AS (SELECT 1) SELECT * FROM ranked", want: "with"},
+		{sql: "SELECT(1)", want: "other"},
+		{sql: "VACUUM", want: "other"},
+		{sql: "", want: "other"},
+	}
+	for _, tt := range tests {
+		if got := queryOperation(tt.sql); got != tt.want {
+			t.Errorf("queryOperation(%q) = %q, want %q", tt.sql, got, tt.want)
+		}
+	}
+}
+
+func TestInstrumentedConnector(t *testing.T) {
+	const backend = "sqlite-test"
+	db := sql.OpenDB(NewInstrumentedConnector(&sqlite3.SQLiteDriver{}, ":memory:", backend))
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	ctx := context.Background()
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE teams (id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO teams (name) VALUES (?)", "Arsenal"); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	var name string
+	if err := db.QueryRowContext(ctx, "SELECT name FROM teams WHERE id = ?", 1).Scan(&name); err != nil || name != "Arsenal" {
+		t.Fatalf("select = %q, %v; want Arsenal", name, err)
+	}
+
+	for _, operation := range []string{"create", "insert", "select"} {
+		if got := metrics.DBQueryDuration.Count(backend, operation); got != 1 {
+			t.Errorf("db_query_duration_seconds{operation=%q} count = %d, want 1", operation, got)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// SQLiteDB is the part of database/sql the SQLite services use. *sql.DB and *sql.Tx both implement it,
+// so a service runs unchanged on the database or inside a transaction started by RunInSQLiteTx.
+type SQLiteDB interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// SQLiteTx is a transaction begun by BeginSQLite: a real transaction, or a savepoint of the transaction in ctx.
+type SQLiteTx interface {
+	SQLiteDB
+	Commit() error
+	Rollback() error
+}
+
+// sqliteTxKey, context içinde RunInSQLiteTx tarafından başlatılan transaction'ı taşır.
+type sqliteTxKey struct{}
+
+// sqliteSavepointName names the savepoints of nested transactions. SQLite keeps savepoints on a stack
+// and releases the innermost one with a matching name, so the same name is safe at every depth.
+const sqliteSavepointName = "nested_tx"
+
+// SQLiteConn returns the transaction RunInSQLiteTx stored in ctx, or db when there is none.
+// Services call it for every statement so they join the request's transaction automatically.
+// This matters beyond atomicity: the SQLite database has a single connection, which an open
+// transaction holds until it ends, so a statement sent to db directly would wait for it forever.
+func SQLiteConn(ctx context.Context, db *sql.DB) SQLiteDB {
+	if tx, ok := ctx.Value(sqliteTxKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// BeginSQLite starts a transaction for a service. Inside the transaction ctx carries it becomes a savepoint,
+// so the service's statements are still applied or undone together and the outer transaction decides the rest.
+func BeginSQLite(ctx context.Context, db *sql.DB) (SQLiteTx, error) {
+	tx, ok := ctx.Value(sqliteTxKey{}).(*sql.Tx)
+	if !ok {
+		return db.BeginTx(ctx, nil)
+	}
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+sqliteSavepointName); err != nil {
+		return nil, err
+	}
+	return &sqliteSavepoint{tx: tx}, nil
+}
+
+// sqliteSavepoint is a nested transaction inside an outer *sql.Tx.
+type sqliteSavepoint struct {
+	tx   *sql.Tx
+	done bool
+}
+
+func (s *sqliteSavepoint) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return s.tx.ExecContext(ctx, query, args...)
+}
+
+func (s *sqliteSavepoint) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return s.tx.QueryContext(ctx, query, args...)
+}
+
+func (s *sqliteSavepoint) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return s.tx.QueryRowContext(ctx, query, args...)
+}
+
+// Commit releases the savepoint; its changes become part of the outer transaction.
+func (s *sqliteSavepoint) Commit() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.tx.Exec("RELEASE SAVEPOINT " + sqliteSavepointName)
+	return err
+}
+
+// Rollback undoes the changes made since the savepoint and leaves the outer transaction open.
+func (s *sqliteSavepoint) Rollback() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	if _, err := s.tx.Exec("ROLLBACK TO SAVEPOINT " + sqliteSavepointName); err != nil {
+		return err
+	}
+	_, err := s.tx.Exec("RELEASE SAVEPOINT " + sqliteSavepointName)
+	return err
+}
+
+// RunInSQLiteTx runs fn in a transaction that is committed when fn returns nil and rolled back otherwise.
+// If ctx already carries a transaction, fn joins it and the outermost call decides the outcome.
+func RunInSQLiteTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(sqliteTxKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("database.RunInSQLiteTx: Could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			slog.WarnContext(ctx, "transaction rollback failed", "error", err)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, sqliteTxKey{}, tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("database.RunInSQLiteTx: Could not commit transaction: %w", err)
+	}
+	return nil
+}
+
+// SQLiteTransactor runs units of work in SQLite transactions.
+type SQLiteTransactor struct {
+	DB *sql.DB
+}
+
+// NewSQLiteTransactor creates an abstracts.Transactor backed by RunInSQLiteTx.
+func NewSQLiteTransactor(db *sql.DB) abstracts.Transactor {
+	return &SQLiteTransactor{DB: db}
+}
+
+// RunInTx runs fn in one transaction; see RunInSQLiteTx.
+func (t *SQLiteTransactor) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return RunInSQLiteTx(ctx, t.DB, fn)
+}
@@ -0,0 +1,156 @@
+// Package logging sets up the structured (log/slog) logger and carries request-scoped fields through contexts,
+// so log records written deep inside the services can be matched with the request that caused them.
+package logging
+
+import (
+	"MatchSimulator_Insider/models"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"strings"
+)
+
+// Field keys shared by every log record. LeagueID is the division a record is about: in the pyramid every division is its own league.
+const (
+	KeyRequestID = "request_id"
+	KeyLeagueID  = "league_id"
+	KeyWeek      = "week"
+	KeyMatchID   = "match_id"
+	KeyTeamID    = "team_id"
+)
+
+// Supported output formats.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// ParseLevel converts a configured level name (debug, info, warn, error) to a slog level. An empty name means info.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q: use debug, info, warn or error", name)
+	}
+}
+
+// Setup makes a JSON (or text) logger writing to w the default slog logger. Records below level are dropped.
+// Lines written with the standard log package go through the same logger at info level.
+func Setup(w io.Writer, level, format string) error {
+	parsedLevel, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	options := &slog.HandlerOptions{Level: parsedLevel}
+	var handler slog.Handler
+	switch format {
+	case "", FormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return fmt.Errorf("unknown log format %q: use %s or %s", format, FormatJSON, FormatText)
+	}
+	slog.SetDefault(slog.New(contextHandler{handler}))
+	log.SetFlags(0)
+	return nil
+}
+
+type requestIDKey struct{}
+
+type fieldsKey struct{}
+
+// WithRequestID returns a copy of ctx that carries the request ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID stored in ctx, or "" outside of a request.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// NewRequestID generates a random 16 character hex ID.
+func NewRequestID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "00000000"
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// With returns a copy of ctx whose log records also carry the given key-value pairs, e.g. With(ctx, KeyWeek, 3).
+func With(ctx context.Context, args ...any) context.Context {
+	fields, _ := ctx.Value(fieldsKey{}).([]slog.Attr)
+	record := slog.Record{}
+	record.Add(args...)
+	merged := append([]slog.Attr(nil), fields...)
+	record.Attrs(func(attr slog.Attr) bool {
+		merged = append(merged, attr)
+		return true
+	})
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// contextHandler adds the request ID and the fields stored with With to every record logged with a context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String(KeyRequestID, requestID))
+	}
+	if fields, ok := ctx.Value(fieldsKey{}).([]slog.Attr); ok {
+		record.AddAttrs(fields...)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// tableRow is one line of a league table in a log record.
+type tableRow struct {
+	Rank   int    `json:"rank"`
+	TeamID int    `json:"team_id"`
+	Team   string `json:"team"`
+	Played int    `json:"played"`
+	Won    int    `json:"won"`
+	Drawn  int    `json:"drawn"`
+	Lost   int    `json:"lost"`
+	GF     int    `json:"gf"`
+	GA     int    `json:"ga"`
+	GD     int    `json:"gd"`
+	Adj    int    `json:"adj,omitempty"`
+	Points int    `json:"points"`
+}
+
+// LeagueTable turns a league table into a "table" attribute with one compact row per team.
+func LeagueTable(table []models.Team) slog.Attr {
+	rows := make([]tableRow, 0, len(table))
+	for i, team := range table {
+		rows = append(rows, tableRow{
+			Rank: i + 1, TeamID: team.ID, Team: team.Name, Played: team.Played, Won: team.Wins, Drawn: team.Draws, Lost: team.Losses,
+			GF: team.GoalsFor, GA: team.GoalsAgainst, GD: team.GoalDifference, Adj: team.Adjustment, Points: team.Points,
+		})
+	}
+	return slog.Any("table", rows)
+}
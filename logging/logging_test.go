@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"MatchSimulator_Insider/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    slog.Level
+		wantErr bool
+	}{
+		{name: "", want: slog.LevelInfo},
+		{name: "debug", want: slog.LevelDebug},
+		{name: "INFO", want: slog.LevelInfo},
+		{name: "warn", want: slog.LevelWarn},
+		{name: "error", want: slog.LevelError},
+		{name: "verbose", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLevel(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLevel(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseLevel(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContextFields(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(contextHandler{slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug})})
+
+	ctx := WithRequestID(context.Background(), "rq-1")
+	ctx = With(ctx, KeyWeek, 3)
+	ctx = With(ctx, KeyMatchID, 12)
+	logger.InfoContext(ctx, "week played", "matches", 2)
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("could not decode log record %q: %v", out.String(), err)
+	}
+	want := map[string]interface{}{"msg": "week played", "level": "INFO", KeyRequestID: "rq-1", KeyWeek: 3.0, KeyMatchID: 12.0, "matches": 2.0}
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("%s = %v, want %v (record %s)", key, record[key], value, out.String())
+		}
+	}
+}
+
+func TestSetupLevelFiltersTableDumps(t *testing.T) {
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+
+	tests := []struct {
+		level     string
+		wantTable bool
+	}{
+		{level: "info"},
+		{level: "debug", wantTable: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := Setup(&out, tt.level, FormatJSON); err != nil {
+				t.Fatalf("Setup: %v", err)
+			}
+			slog.Debug("league table", LeagueTable([]models.Team{{ID: 1, Name: "Arsenal", Points: 3}}))
+			if got := out.Len() > 0; got != tt.wantTable {
+				t.Errorf("table written = %v, want %v (output %q)", got, tt.wantTable, out.String())
+			}
+		})
+	}
+	if err := Setup(&bytes.Buffer{}, "info", "xml"); err == nil {
+		t.Error("Setup accepted an unknown format")
+	}
+}
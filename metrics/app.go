@@ -0,0 +1,31 @@
+package metrics
+
+// Default holds every metric below; main serves it at /metrics.
+var Default = NewRegistry()
+
+// HTTP metrics, labelled with the route pattern (e.g. /v1/teams/{id}) rather than the raw path to keep the series count bounded.
+var (
+	HTTPRequests        = NewCounter("http_requests_total", "HTTP requests served, by method, route and status code.", "method", "route", "status")
+	HTTPRequestDuration = NewHistogram("http_request_duration_seconds", "Time spent serving HTTP requests, by method and route.", DefaultBuckets, "method", "route")
+)
+
+// League metrics.
+var (
+	WeeksPlayed      = NewCounter("league_weeks_played_total", "Weeks played by the league simulation.")
+	MatchesSimulated = NewCounter("league_matches_simulated_total", "Matches played by the simulation, by stage.", "stage")
+	GoalsPerMatch    = NewHistogram("league_goals_per_match", "Total goals scored in simulated matches.", []float64{0, 1, 2, 3, 4, 5, 6, 8, 10})
+)
+
+// Prediction metrics. Every prediction runs a fixed number of Monte Carlo iterations over the remaining fixture.
+var (
+	PredictionDuration   = NewHistogram("league_prediction_duration_seconds", "Time spent computing championship predictions.", DefaultBuckets)
+	PredictionIterations = NewCounter("league_prediction_iterations_total", "Monte Carlo iterations run for championship predictions.")
+)
+
+// DBQueryDuration is the latency of database statements, by backend (postgres, sqlite) and statement kind (select, insert, ...).
+var DBQueryDuration = NewHistogram("db_query_duration_seconds", "Time spent running database statements, by backend and operation.",
+	[]float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}, "backend", "operation")
+
+func init() {
+	Default.Register(HTTPRequests, HTTPRequestDuration, WeeksPlayed, MatchesSimulated, GoalsPerMatch, PredictionDuration, PredictionIterations, DBQueryDuration)
+}
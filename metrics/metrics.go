@@ -0,0 +1,256 @@
+// Package metrics keeps counters and histograms in memory and serves them in the Prometheus text format,
+// so the service can be scraped without pulling in a client library or running a collector in tests.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are latency buckets in seconds, from 5ms to 10s.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// collector is a metric the registry can write.
+type collector interface {
+	write(w io.Writer) error
+}
+
+// desc is what every metric has: a name, a help text and the names of its labels.
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+// key joins label values into the series key. Values must be given in the order the labels were declared.
+func (d desc) key(labelValues []string) string {
+	if len(labelValues) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", d.name, len(d.labels), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+// labelString renders a series key as {a="x",b="y"}, with extra appended last (used for le).
+func (d desc) labelString(key string, extra ...string) string {
+	var pairs []string
+	if len(d.labels) > 0 {
+		for i, value := range strings.Split(key, "\xff") {
+			pairs = append(pairs, d.labels[i]+`="`+escapeLabel(value)+`"`)
+		}
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+escapeLabel(extra[i+1])+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (d desc) writeHeader(w io.Writer, kind string) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, strings.ReplaceAll(d.help, "\n", " "), d.name, kind)
+	return err
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// sortedKeys returns the series keys in a stable order so scrapes are easy to diff.
+func sortedKeys[V any](series map[string]V) []string {
+	keys := make([]string, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Counter is a value that only goes up, one series per combination of label values.
+type Counter struct {
+	desc
+	mu     sync.Mutex
+	series map[string]float64
+}
+
+// NewCounter creates a counter with the given label names.
+func NewCounter(name, help string, labels ...string) *Counter {
+	return &Counter{desc: desc{name: name, help: help, labels: labels}, series: make(map[string]float64)}
+}
+
+// Inc adds one to the series for labelValues.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds delta, which must not be negative, to the series for labelValues.
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("metrics: counter %s cannot decrease", c.name))
+	}
+	key := c.key(labelValues)
+	c.mu.Lock()
+	c.series[key] += delta
+	c.mu.Unlock()
+}
+
+// Value returns the current value of the series for labelValues.
+func (c *Counter) Value(labelValues ...string) float64 {
+	key := c.key(labelValues)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.series[key]
+}
+
+func (c *Counter) write(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.writeHeader(w, "counter"); err != nil {
+		return err
+	}
+	if len(c.labels) == 0 && len(c.series) == 0 {
+		_, err := fmt.Fprintf(w, "%s 0\n", c.name)
+		return err
+	}
+	for _, key := range sortedKeys(c.series) {
+		if _, err := fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelString(key), formatFloat(c.series[key])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Histogram counts observations into cumulative buckets, one series per combination of label values.
+type Histogram struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	counts []uint64 // per bucket, not cumulative; the last one is +Inf
+	sum    float64
+	count  uint64
+}
+
+// NewHistogram creates a histogram with the given upper bucket bounds and label names. A +Inf bucket is always added.
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &Histogram{desc: desc{name: name, help: help, labels: labels}, buckets: sorted, series: make(map[string]*histogramSeries)}
+}
+
+// Observe records one value in the series for labelValues.
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	key := h.key(labelValues)
+	bucket := sort.SearchFloat64s(h.buckets, value) // first bound >= value, or len(buckets) for +Inf
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	series, ok := h.series[key]
+	if !ok {
+		series = &histogramSeries{counts: make([]uint64, len(h.buckets)+1)}
+		h.series[key] = series
+	}
+	series.counts[bucket]++
+	series.sum += value
+	series.count++
+}
+
+// ObserveSince records the seconds elapsed since start.
+func (h *Histogram) ObserveSince(start time.Time, labelValues ...string) {
+	h.Observe(time.Since(start).Seconds(), labelValues...)
+}
+
+// Count returns how many values the series for labelValues has recorded.
+func (h *Histogram) Count(labelValues ...string) uint64 {
+	key := h.key(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if series, ok := h.series[key]; ok {
+		return series.count
+	}
+	return 0
+}
+
+func (h *Histogram) write(w io.Writer) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.writeHeader(w, "histogram"); err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(h.series) {
+		series := h.series[key]
+		var cumulative uint64
+		for i, count := range series.counts {
+			cumulative += count
+			bound := math.Inf(1)
+			if i < len(h.buckets) {
+				bound = h.buckets[i]
+			}
+			if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelString(key, "le", formatFloat(bound)), cumulative); err != nil {
+				return err
+			}
+		}
+		labels := h.labelString(key)
+		if _, err := fmt.Fprintf(w, "%s_sum%s %s\n%s_count%s %d\n", h.name, labels, formatFloat(series.sum), h.name, labels, series.count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Registry is a set of metrics written together, in registration order.
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds counters and histograms to the registry.
+func (r *Registry) Register(collectors ...collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, collectors...)
+}
+
+// WriteText writes every registered metric in the Prometheus text exposition format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	collectors := append([]collector(nil), r.collectors...)
+	r.mu.Unlock()
+	for _, c := range collectors {
+		if err := c.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler serves the registry for Prometheus to scrape.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.WriteText(w)
+	})
+}
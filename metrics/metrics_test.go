@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistryWriteText(t *testing.T) {
+	requests := NewCounter("test_requests_total", "Requests.", "route", "status")
+	weeks := NewCounter("test_weeks_total", "Weeks.")
+	goals := NewHistogram("test_goals", "Goals.", []float64{1, 3})
+	registry := NewRegistry()
+	registry.Register(requests, weeks, goals)
+
+	requests.Inc("/v1/teams/{id}", "200")
+	requests.Add(2, "/v1/teams/{id}", "200")
+	requests.Inc(`/a"b\c`, "404")
+	for _, value := range []float64{0, 1, 2, 5} {
+		goals.Observe(value)
+	}
+
+	var out bytes.Buffer
+	if err := registry.WriteText(&out); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	want := `# HELP test_requests_total Requests.
+# TYPE test_requests_total counter
+test_requests_total{route="/a\"b\\c",status="404"} 1
+test_requests_total{route="/v1/teams/{id}",status="200"} 3
+# HELP test_weeks_total Weeks.
+# TYPE test_weeks_total counter
+test_weeks_total 0
+# HELP test_goals Goals.
+# TYPE test_goals histogram
+test_goals_bucket{le="1"} 2
+test_goals_bucket{le="3"} 3
+test_goals_bucket{le="+Inf"} 4
+test_goals_sum 8
+test_goals_count 4
+`
+	if out.String() != want {
+		t.Errorf("exposition mismatch\n got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestLabelCountMismatchPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Inc with the wrong number of label values did not panic")
+		}
+	}()
+	NewCounter("test_total", "Test.", "route").Inc()
+}
+
+func TestHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	Default.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q, want the Prometheus text format", got)
+	}
+	for _, name := range []string{"http_requests_total", "league_weeks_played_total", "league_goals_per_match", "league_prediction_duration_seconds", "db_query_duration_seconds"} {
+		if !strings.Contains(w.Body.String(), "# TYPE "+name+" ") {
+			t.Errorf("/metrics does not expose %s", name)
+		}
+	}
+}
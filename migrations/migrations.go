@@ -0,0 +1,322 @@
+package migrations
+
+import (
+	"MatchSimulator_Insider/queries"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Migration dosyaları sql/ (PostgreSQL) ve sqlite/ (SQLite) altında NNNN_açıklama.up.sql ve NNNN_açıklama.down.sql çiftleri olarak tutulur.
+//
+//go:embed sql/*.sql
+var migrationFiles embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteMigrationFiles embed.FS
+
+// migrationLockKey is the advisory lock key that serialises migration runs across processes.
+const migrationLockKey = 7_240_031
+
+// Migration is one versioned schema change with its up and down SQL.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status reports whether a migration has been applied to the database.
+type Status struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+// Runner is implemented by the PostgreSQL and SQLite migrators.
+type Runner interface {
+	Up(ctx context.Context) ([]int, error)
+	Down(ctx context.Context, steps int) ([]int, error)
+	Status(ctx context.Context) ([]Status, error)
+}
+
+// Migrator applies and rolls back the embedded migrations, recording them in schema_migrations.
+type Migrator struct {
+	DB         *pgx.Conn
+	migrations []Migration
+}
+
+// NewMigrator creates a Migrator for the embedded migrations.
+func NewMigrator(db *pgx.Conn) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("migrations.NewMigrator: %w", err)
+	}
+	return &Migrator{DB: db, migrations: migrations}, nil
+}
+
+// loadMigrations reads the up/down pairs in dir of fsys and returns them ordered by version.
+// Every version needs both files and versions must be unique.
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read migration directory: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(fileName, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(fileName, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("unexpected migration file %q: expected NNNN_name.up.sql or NNNN_name.down.sql", fileName)
+		}
+		base := strings.TrimSuffix(fileName, "."+direction+".sql")
+		versionStr, name, found := strings.Cut(base, "_")
+		version, errVersion := strconv.Atoi(versionStr)
+		if !found || errVersion != nil || version < 1 {
+			return nil, fmt.Errorf("migration file %q does not start with a positive version number", fileName)
+		}
+
+		content, err := fs.ReadFile(fsys, dir+"/"+fileName)
+		if err != nil {
+			return nil, fmt.Errorf("could not read migration file %q: %w", fileName, err)
+		}
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf("migration version %d is used by both %q and %q", version, migration.Name, name)
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Up applies every pending migration in version order and returns the versions it applied.
+// Each migration runs in its own transaction together with its schema_migrations record.
+func (m *Migrator) Up(ctx context.Context) ([]int, error) {
+	var applied []int
+	err := m.withLock(ctx, func(appliedVersions map[int]time.Time) error {
+		for _, migration := range m.migrations {
+			if _, done := appliedVersions[migration.Version]; done {
+				continue
+			}
+			if err := m.run(ctx, migration.Up, queries.InsertSchemaMigrationSQL, migration.Version, migration.Name); err != nil {
+				return fmt.Errorf("applying migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			slog.InfoContext(ctx, "applied migration", "version", migration.Version, "name", migration.Name)
+			applied = append(applied, migration.Version)
+		}
+		return nil
+	})
+	if err != nil {
+		return applied, fmt.Errorf("Migrator.Up: %w", err)
+	}
+	return applied, nil
+}
+
+// Down rolls back the latest steps applied migrations, newest first, and returns the versions it rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]int, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("Migrator.Down: steps must be at least 1, got %d", steps)
+	}
+	var rolledBack []int
+	err := m.withLock(ctx, func(appliedVersions map[int]time.Time) error {
+		for i := len(m.migrations) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+			migration := m.migrations[i]
+			if _, done := appliedVersions[migration.Version]; !done {
+				continue
+			}
+			if err := m.run(ctx, migration.Down, queries.DeleteSchemaMigrationSQL, migration.Version); err != nil {
+				return fmt.Errorf("rolling back migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			slog.InfoContext(ctx, "rolled back migration", "version", migration.Version, "name", migration.Name)
+			rolledBack = append(rolledBack, migration.Version)
+		}
+		return nil
+	})
+	if err != nil {
+		return rolledBack, fmt.Errorf("Migrator.Down: %w", err)
+	}
+	return rolledBack, nil
+}
+
+// Status lists every embedded migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if _, err := m.DB.Exec(ctx, queries.CreateSchemaMigrationsTableSQL); err != nil {
+		return nil, fmt.Errorf("Migrator.Status: Could not create schema_migrations table: %w", err)
+	}
+	appliedVersions, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Migrator.Status: %w", err)
+	}
+	return buildStatuses(m.migrations, appliedVersions), nil
+}
+
+// ErrPendingMigrations is returned by CheckApplied when the schema is behind the embedded migrations.
+var ErrPendingMigrations = errors.New("schema has pending migrations")
+
+// Querier is the read-only part of pgx that Pending needs. *pgxpool.Pool, *pgx.Conn and pgx.Tx implement it.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Pending returns the versions of the embedded PostgreSQL migrations that db has not applied yet.
+// Unlike Status it never writes: a missing schema_migrations table means every migration is pending,
+// so it also works for a read-only database role, e.g. in a readiness check.
+func Pending(ctx context.Context, db Querier) ([]int, error) {
+	migrations, err := loadMigrations(migrationFiles, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("migrations.Pending: %w", err)
+	}
+	var exists bool
+	if err := db.QueryRow(ctx, queries.SchemaMigrationsTableExistsSQL).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("migrations.Pending: Could not look for schema_migrations: %w", err)
+	}
+	applied := make(map[int]bool)
+	if exists {
+		rows, err := db.Query(ctx, queries.GetAppliedMigrationVersionsSQL)
+		if err != nil {
+			return nil, fmt.Errorf("migrations.Pending: Could not read schema_migrations: %w", err)
+		}
+		versions, err := pgx.CollectRows(rows, pgx.RowTo[int])
+		if err != nil {
+			return nil, fmt.Errorf("migrations.Pending: Could not read schema_migrations: %w", err)
+		}
+		for _, version := range versions {
+			applied[version] = true
+		}
+	}
+	return pendingVersions(migrations, applied), nil
+}
+
+// CheckApplied turns the result of Pending or PendingSQLite into a readiness error:
+// ErrPendingMigrations naming the pending versions, err if they could not be read, or nil.
+func CheckApplied(pending []int, err error) error {
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %v", ErrPendingMigrations, pending)
+	}
+	return nil
+}
+
+// pendingVersions returns the versions of migrations missing from applied, in version order.
+func pendingVersions(migrations []Migration, applied map[int]bool) []int {
+	var pending []int
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration.Version)
+		}
+	}
+	return pending
+}
+
+// buildStatuses pairs every migration with the time it was applied, if it was.
+func buildStatuses(migrations []Migration, appliedVersions map[int]time.Time) []Status {
+	statuses := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if appliedAt, done := appliedVersions[migration.Version]; done {
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// withLock holds the migration advisory lock while fn runs with the currently applied versions.
+func (m *Migrator) withLock(ctx context.Context, fn func(appliedVersions map[int]time.Time) error) error {
+	if _, err := m.DB.Exec(ctx, queries.AcquireMigrationLockSQL, migrationLockKey); err != nil {
+		return fmt.Errorf("could not acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := m.DB.Exec(context.Background(), queries.ReleaseMigrationLockSQL, migrationLockKey); err != nil {
+			slog.WarnContext(ctx, "could not release the migration lock", "error", err)
+		}
+	}()
+
+	if _, err := m.DB.Exec(ctx, queries.CreateSchemaMigrationsTableSQL); err != nil {
+		return fmt.Errorf("could not create schema_migrations table: %w", err)
+	}
+	appliedVersions, err := m.appliedVersions(ctx)
+	if err != nil {
+		return err
+	}
+	return fn(appliedVersions)
+}
+
+// run executes a migration script and its bookkeeping statement in one transaction.
+func (m *Migrator) run(ctx context.Context, script string, bookkeepingSQL string, args ...any) error {
+	tx, err := m.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Parametresiz Exec basit protokolü kullandığı için birden fazla SQL ifadesi tek seferde çalıştırılabilir
+	if _, err := tx.Exec(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, bookkeepingSQL, args...); err != nil {
+		return fmt.Errorf("could not update schema_migrations: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// appliedVersions returns the applied migration versions with the time they were applied.
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int]time.Time, error) {
+	rows, err := m.DB.Query(ctx, queries.GetAppliedMigrationsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("could not read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var name string
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &name, &appliedAt); err != nil {
+			return nil, fmt.Errorf("could not scan schema_migrations row: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read schema_migrations: %w", err)
+	}
+	return applied, nil
+}
@@ -0,0 +1,142 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// TestLoadMigrations_Embedded checks that the embedded migrations load in order with both directions present.
+func TestLoadMigrations_Embedded(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles, "sql")
+	if err != nil {
+		t.Fatalf("Did not expect an error but got: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("Expected embedded migrations, got none")
+	}
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("Expected migration %d to have version %d, got %d (%s)", i, i+1, migration.Version, migration.Name)
+		}
+		if migration.Up == "" || migration.Down == "" {
+			t.Errorf("Migration %04d_%s is missing its up or down SQL", migration.Version, migration.Name)
+		}
+	}
+}
+
+// TestLoadMigrations_SQLiteEmbedded checks that the embedded SQLite migrations load.
+func TestLoadMigrations_SQLiteEmbedded(t *testing.T) {
+	migrations, err := loadMigrations(sqliteMigrationFiles, "sqlite")
+	if err != nil {
+		t.Fatalf("Did not expect an error but got: %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != 1 {
+		t.Fatalf("Expected embedded SQLite migrations starting at version 1, got %v", migrations)
+	}
+}
+
+// TestLoadMigrations_Invalid covers malformed migration directories.
+func TestLoadMigrations_Invalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{
+			name:  "Missing Down File",
+			files: fstest.MapFS{"sql/0001_init.up.sql": {Data: []byte("SELECT 1;")}},
+		},
+		{
+			name: "Duplicate Version",
+			files: fstest.MapFS{
+				"sql/0001_init.up.sql":    {Data: []byte("SELECT 1;")},
+				"sql/0001_init.down.sql":  {Data: []byte("SELECT 1;")},
+				"sql/0001_other.up.sql":   {Data: []byte("SELECT 1;")},
+				"sql/0001_other.down.sql": {Data: []byte("SELECT 1;")},
+			},
+		},
+		{
+			name:  "No Version Prefix",
+			files: fstest.MapFS{"sql/init.up.sql": {Data: []byte("SELECT 1;")}},
+		},
+		{
+			name:  "Unexpected Extension",
+			files: fstest.MapFS{"sql/0001_init.sql": {Data: []byte("SELECT 1;")}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := loadMigrations(tc.files, "sql"); err == nil {
+				t.Error("Expected an error but got nil")
+			}
+		})
+	}
+}
+
+// TestSQLiteMigrator_UpDownStatus applies and rolls back the embedded SQLite migrations on an in-memory database.
+func TestSQLiteMigrator_UpDownStatus(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Could not open SQLite: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1) // :memory: veritabanı bağlantıya özeldir
+
+	migrator, err := NewSQLiteMigrator(db)
+	if err != nil {
+		t.Fatalf("Did not expect an error but got: %v", err)
+	}
+	if err := CheckApplied(PendingSQLite(ctx, db)); !errors.Is(err, ErrPendingMigrations) {
+		t.Errorf("Expected ErrPendingMigrations before Up, got %v", err)
+	}
+	var created bool
+	if err := db.QueryRow(`SELECT COUNT(*) > 0 FROM sqlite_master WHERE name = 'schema_migrations'`).Scan(&created); err != nil || created {
+		t.Errorf("Expected PendingSQLite to leave the database untouched, schema_migrations created: %v (err: %v)", created, err)
+	}
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Did not expect an error applying migrations but got: %v", err)
+	}
+	if err := CheckApplied(PendingSQLite(ctx, db)); err != nil {
+		t.Errorf("Expected no pending migrations after Up, got %v", err)
+	}
+	if len(applied) != len(migrator.migrations) {
+		t.Errorf("Expected %d migrations to be applied, got %v", len(migrator.migrations), applied)
+	}
+	if again, err := migrator.Up(ctx); err != nil || len(again) != 0 {
+		t.Errorf("Expected a second Up to apply nothing, got %v (err: %v)", again, err)
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Did not expect an error reading status but got: %v", err)
+	}
+	for _, status := range statuses {
+		if !status.Applied || status.AppliedAt == nil {
+			t.Errorf("Expected migration %04d_%s to be applied", status.Version, status.Name)
+		}
+	}
+
+	rolledBack, err := migrator.Down(ctx, len(migrator.migrations))
+	if err != nil {
+		t.Fatalf("Did not expect an error rolling back but got: %v", err)
+	}
+	if len(rolledBack) != len(migrator.migrations) {
+		t.Errorf("Expected %d migrations to be rolled back, got %v", len(migrator.migrations), rolledBack)
+	}
+	if pending, err := PendingSQLite(ctx, db); err != nil || len(pending) != len(migrator.migrations) {
+		t.Errorf("Expected every migration to be pending after the rollback, got %v (err: %v)", pending, err)
+	}
+	var tables int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('teams', 'matches', 'points_adjustments')`).Scan(&tables); err != nil {
+		t.Fatalf("Could not inspect the schema: %v", err)
+	}
+	if tables != 0 {
+		t.Errorf("Expected the rollback to drop every table, %d remain", tables)
+	}
+}
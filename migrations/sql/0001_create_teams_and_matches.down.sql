@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS matches;
+DROP TABLE IF EXISTS teams;
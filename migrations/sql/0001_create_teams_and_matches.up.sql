@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS teams (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    strength INTEGER DEFAULT 50,
+    played INTEGER DEFAULT 0,
+    wins INTEGER DEFAULT 0,
+    draws INTEGER DEFAULT 0,
+    losses INTEGER DEFAULT 0,
+    goals_for INTEGER DEFAULT 0,
+    goals_against INTEGER DEFAULT 0,
+    goal_difference INTEGER DEFAULT 0,
+    points INTEGER DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS matches (
+    id SERIAL PRIMARY KEY,
+    week INTEGER NOT NULL,
+    home_team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    away_team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    home_goals INTEGER,
+    away_goals INTEGER,
+    is_played BOOLEAN DEFAULT FALSE,
+    CONSTRAINT check_different_teams CHECK (home_team_id <> away_team_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_matches_week ON matches(week);
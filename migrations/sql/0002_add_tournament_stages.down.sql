@@ -0,0 +1,4 @@
+ALTER TABLE matches
+    DROP COLUMN IF EXISTS round,
+    DROP COLUMN IF EXISTS group_name,
+    DROP COLUMN IF EXISTS stage;
@@ -0,0 +1,4 @@
+ALTER TABLE matches
+    ADD COLUMN IF NOT EXISTS stage VARCHAR(20) NOT NULL DEFAULT 'league',
+    ADD COLUMN IF NOT EXISTS group_name VARCHAR(10) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS round INTEGER NOT NULL DEFAULT 0;
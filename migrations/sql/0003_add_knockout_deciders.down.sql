@@ -0,0 +1,6 @@
+ALTER TABLE matches
+    DROP COLUMN IF EXISTS winner_team_id,
+    DROP COLUMN IF EXISTS away_penalties,
+    DROP COLUMN IF EXISTS home_penalties,
+    DROP COLUMN IF EXISTS away_goals_et,
+    DROP COLUMN IF EXISTS home_goals_et;
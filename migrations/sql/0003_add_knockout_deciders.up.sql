@@ -0,0 +1,6 @@
+ALTER TABLE matches
+    ADD COLUMN IF NOT EXISTS home_goals_et INTEGER,
+    ADD COLUMN IF NOT EXISTS away_goals_et INTEGER,
+    ADD COLUMN IF NOT EXISTS home_penalties INTEGER,
+    ADD COLUMN IF NOT EXISTS away_penalties INTEGER,
+    ADD COLUMN IF NOT EXISTS winner_team_id INTEGER REFERENCES teams(id) ON DELETE SET NULL;
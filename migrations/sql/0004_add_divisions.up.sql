@@ -0,0 +1 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS division INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1 @@
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS leg INTEGER NOT NULL DEFAULT 0;
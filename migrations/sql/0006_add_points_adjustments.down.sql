@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS points_adjustments;
+ALTER TABLE matches DROP COLUMN IF EXISTS is_forfeit;
+ALTER TABLE teams DROP COLUMN IF EXISTS points_adjustment;
@@ -0,0 +1,2 @@
+ALTER TABLE matches DROP COLUMN IF EXISTS version;
+ALTER TABLE teams DROP COLUMN IF EXISTS version;
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS season_archive (
+    season SERIAL PRIMARY KEY,
+    playoff_matches TEXT NOT NULL,
+    closed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
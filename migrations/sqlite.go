@@ -0,0 +1,155 @@
+package migrations
+
+import (
+	"MatchSimulator_Insider/queries"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// SQLiteMigrator applies and rolls back the embedded SQLite migrations, recording them in schema_migrations.
+// SQLite allows a single writer, so no advisory lock is needed.
+type SQLiteMigrator struct {
+	DB         *sql.DB
+	migrations []Migration
+}
+
+// NewSQLiteMigrator creates a SQLiteMigrator for the embedded SQLite migrations.
+func NewSQLiteMigrator(db *sql.DB) (*SQLiteMigrator, error) {
+	migrations, err := loadMigrations(sqliteMigrationFiles, "sqlite")
+	if err != nil {
+		return nil, fmt.Errorf("migrations.NewSQLiteMigrator: %w", err)
+	}
+	return &SQLiteMigrator{DB: db, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order and returns the versions it applied.
+func (m *SQLiteMigrator) Up(ctx context.Context) ([]int, error) {
+	appliedVersions, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("SQLiteMigrator.Up: %w", err)
+	}
+	var applied []int
+	for _, migration := range m.migrations {
+		if _, done := appliedVersions[migration.Version]; done {
+			continue
+		}
+		if err := m.run(ctx, migration.Up, queries.InsertSchemaMigrationSQL, migration.Version, migration.Name); err != nil {
+			return applied, fmt.Errorf("SQLiteMigrator.Up: applying migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		slog.InfoContext(ctx, "applied migration", "version", migration.Version, "name", migration.Name)
+		applied = append(applied, migration.Version)
+	}
+	return applied, nil
+}
+
+// Down rolls back the latest steps applied migrations, newest first, and returns the versions it rolled back.
+func (m *SQLiteMigrator) Down(ctx context.Context, steps int) ([]int, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("SQLiteMigrator.Down: steps must be at least 1, got %d", steps)
+	}
+	appliedVersions, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("SQLiteMigrator.Down: %w", err)
+	}
+	var rolledBack []int
+	for i := len(m.migrations) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+		migration := m.migrations[i]
+		if _, done := appliedVersions[migration.Version]; !done {
+			continue
+		}
+		if err := m.run(ctx, migration.Down, queries.DeleteSchemaMigrationSQL, migration.Version); err != nil {
+			return rolledBack, fmt.Errorf("SQLiteMigrator.Down: rolling back migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		slog.InfoContext(ctx, "rolled back migration", "version", migration.Version, "name", migration.Name)
+		rolledBack = append(rolledBack, migration.Version)
+	}
+	return rolledBack, nil
+}
+
+// Status lists every embedded migration and whether it has been applied.
+func (m *SQLiteMigrator) Status(ctx context.Context) ([]Status, error) {
+	appliedVersions, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("SQLiteMigrator.Status: %w", err)
+	}
+	return buildStatuses(m.migrations, appliedVersions), nil
+}
+
+// run executes a migration script and its bookkeeping statement in one transaction.
+func (m *SQLiteMigrator) run(ctx context.Context, script string, bookkeepingSQL string, args ...any) error {
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Parametresiz Exec, betikteki tüm ifadeleri sırayla çalıştırır
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bookkeepingSQL, args...); err != nil {
+		return fmt.Errorf("could not update schema_migrations: %w", err)
+	}
+	return tx.Commit()
+}
+
+// appliedVersions creates schema_migrations if needed and returns the applied versions with the time they were applied.
+func (m *SQLiteMigrator) appliedVersions(ctx context.Context) (map[int]time.Time, error) {
+	if _, err := m.DB.ExecContext(ctx, queries.CreateSQLiteSchemaMigrationsTableSQL); err != nil {
+		return nil, fmt.Errorf("could not create schema_migrations table: %w", err)
+	}
+	rows, err := m.DB.QueryContext(ctx, queries.GetAppliedMigrationsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("could not read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var name string
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &name, &appliedAt); err != nil {
+			return nil, fmt.Errorf("could not scan schema_migrations row: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read schema_migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// PendingSQLite returns the versions of the embedded SQLite migrations that db has not applied yet.
+// Like Pending it only reads and treats a missing schema_migrations table as every migration pending.
+func PendingSQLite(ctx context.Context, db *sql.DB) ([]int, error) {
+	migrations, err := loadMigrations(sqliteMigrationFiles, "sqlite")
+	if err != nil {
+		return nil, fmt.Errorf("migrations.PendingSQLite: %w", err)
+	}
+	var exists bool
+	if err := db.QueryRowContext(ctx, queries.SQLiteSchemaMigrationsTableExistsSQL).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("migrations.PendingSQLite: Could not look for schema_migrations: %w", err)
+	}
+	applied := make(map[int]bool)
+	if exists {
+		rows, err := db.QueryContext(ctx, queries.GetAppliedMigrationVersionsSQL)
+		if err != nil {
+			return nil, fmt.Errorf("migrations.PendingSQLite: Could not read schema_migrations: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var version int
+			if err := rows.Scan(&version); err != nil {
+				return nil, fmt.Errorf("migrations.PendingSQLite: Could not scan schema_migrations row: %w", err)
+			}
+			applied[version] = true
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("migrations.PendingSQLite: Could not read schema_migrations: %w", err)
+		}
+	}
+	return pendingVersions(migrations, applied), nil
+}
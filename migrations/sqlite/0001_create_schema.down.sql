@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS points_adjustments;
+DROP INDEX IF EXISTS idx_matches_week;
+DROP TABLE IF EXISTS matches;
+DROP TABLE IF EXISTS teams;
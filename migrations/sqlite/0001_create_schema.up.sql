@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS teams (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    strength INTEGER DEFAULT 50,
+    played INTEGER DEFAULT 0,
+    wins INTEGER DEFAULT 0,
+    draws INTEGER DEFAULT 0,
+    losses INTEGER DEFAULT 0,
+    goals_for INTEGER DEFAULT 0,
+    goals_against INTEGER DEFAULT 0,
+    goal_difference INTEGER DEFAULT 0,
+    points INTEGER DEFAULT 0,
+    division INTEGER NOT NULL DEFAULT 1,
+    points_adjustment INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS matches (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    week INTEGER NOT NULL,
+    home_team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    away_team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    home_goals INTEGER,
+    away_goals INTEGER,
+    is_played BOOLEAN NOT NULL DEFAULT FALSE,
+    stage TEXT NOT NULL DEFAULT 'league',
+    group_name TEXT NOT NULL DEFAULT '',
+    round INTEGER NOT NULL DEFAULT 0,
+    leg INTEGER NOT NULL DEFAULT 0,
+    home_goals_et INTEGER,
+    away_goals_et INTEGER,
+    home_penalties INTEGER,
+    away_penalties INTEGER,
+    winner_team_id INTEGER REFERENCES teams(id) ON DELETE SET NULL,
+    is_forfeit BOOLEAN NOT NULL DEFAULT FALSE,
+    CONSTRAINT check_different_teams CHECK (home_team_id <> away_team_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_matches_week ON matches(week);
+
+CREATE TABLE IF NOT EXISTS points_adjustments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    points INTEGER NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
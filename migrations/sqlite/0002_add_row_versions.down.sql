@@ -0,0 +1,2 @@
+ALTER TABLE matches DROP COLUMN version;
+ALTER TABLE teams DROP COLUMN version;
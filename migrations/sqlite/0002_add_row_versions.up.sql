@@ -0,0 +1,2 @@
+ALTER TABLE teams ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE matches ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
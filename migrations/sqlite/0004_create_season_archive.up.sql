@@ -0,0 +1,5 @@
+CREATE TABLE season_archive (
+    season INTEGER PRIMARY KEY AUTOINCREMENT,
+    playoff_matches TEXT NOT NULL,
+    closed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
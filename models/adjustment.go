@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// PointsAdjustment is an administrative points deduction (negative) or bonus (positive) applied to a team.
+type PointsAdjustment struct {
+	ID        int       `json:"id"`
+	TeamID    int       `json:"team_id"`
+	Points    int       `json:"points"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Entity types an audit entry can refer to. League-wide actions use AuditEntityLeague with EntityID 0.
+const (
+	AuditEntityTeam   = "team"
+	AuditEntityMatch  = "match"
+	AuditEntityLeague = "league"
+)
+
+// Actions recorded in the audit log.
+const (
+	AuditActionScoreEdit        = "score_edit"
+	AuditActionForfeit          = "forfeit"
+	AuditActionStrengthChange   = "strength_change"
+	AuditActionNameChange       = "name_change"
+	AuditActionDivisionChange   = "division_change"
+	AuditActionPointsAdjustment = "points_adjustment"
+	AuditActionLeagueReset      = "league_reset"
+	AuditActionDefaultsReset    = "defaults_reset"
+	AuditActionWeekRevert       = "week_revert"
+	AuditActionScoreRevert      = "score_revert"
+	AuditActionSnapshotImport   = "snapshot_import"
+	AuditActionTeamCreate       = "team_create"
+	AuditActionTeamDelete       = "team_delete"
+)
+
+// AuditEntry records who changed what and when. OldValue and NewValue hold the changed fields as JSON
+// and are empty when the action has no meaningful before or after state (e.g. a league reset).
+type AuditEntry struct {
+	ID         int             `json:"id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   int             `json:"entity_id"`
+	Action     string          `json:"action"`
+	Actor      string          `json:"actor"`
+	OldValue   json.RawMessage `json:"old_value,omitempty"`
+	NewValue   json.RawMessage `json:"new_value,omitempty"`
+	Reason     string          `json:"reason,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditFilter narrows an audit log query. Zero values match everything; Until is exclusive.
+type AuditFilter struct {
+	EntityType string
+	EntityID   int
+	Since      time.Time
+	Until      time.Time
+}
@@ -1,11 +1,35 @@
 package models
 
+// Stage values stored on a match. League and group stage matches count towards
+// the league table; knockout and playoff matches only decide who advances.
+const (
+	StageLeague   = "league"
+	StageGroup    = "group"
+	StageKnockout = "knockout"
+	StagePlayoff  = "playoff"
+)
+
 type Match struct {
-	ID         int  `json:"id"`
-	Week       int  `json:"week"`
-	HomeTeamID int  `json:"home_team_id"`
-	AwayTeamID int  `json:"away_team_id"`
-	HomeGoals  *int `json:"home_goals,omitempty"` 
-	AwayGoals  *int `json:"away_goals,omitempty"` 
-	IsPlayed   bool `json:"is_played"`
+	ID         int    `json:"id"`
+	Week       int    `json:"week"`
+	HomeTeamID int    `json:"home_team_id"`
+	AwayTeamID int    `json:"away_team_id"`
+	HomeGoals  *int   `json:"home_goals,omitempty"` 
+	AwayGoals  *int   `json:"away_goals,omitempty"` 
+	IsPlayed   bool   `json:"is_played"`
+	Stage      string `json:"stage"`
+	GroupName  string `json:"group_name,omitempty"`
+	Round      int    `json:"round,omitempty"`
+	Leg        int    `json:"leg,omitempty"` // 0 tek maç, 1/2 çift maçlı eşleşmenin ayakları
+
+	// Knockout deciders. Extra-time goals are only those scored in extra time,
+	// penalties hold the shootout score and WinnerTeamID the team that advanced.
+	HomeGoalsET   *int `json:"home_goals_et,omitempty"`
+	AwayGoalsET   *int `json:"away_goals_et,omitempty"`
+	HomePenalties *int `json:"home_penalties,omitempty"`
+	AwayPenalties *int `json:"away_penalties,omitempty"`
+	WinnerTeamID  *int `json:"winner_team_id,omitempty"`
+
+	IsForfeit bool `json:"is_forfeit,omitempty"` // Skor hükmen (3-0) verildiyse true
+	Version   int  `json:"version"`              // Her güncellemede artar; ETag/If-Match ile eşzamanlı düzenleme kontrolü için
 }
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// PyramidRules configures promotion and relegation between adjacent divisions.
+// Division 1 is the top tier. PromotionSpots teams swap between each pair of divisions;
+// with Playoffs enabled the last promotion spot is decided by a playoff in the lower division.
+// PlayoffTeams teams (2, 4 or 8), starting at the last promotion place, contest the playoff;
+// with TwoLeggedPlayoffs every round except the final is played home and away.
+type PyramidRules struct {
+	PromotionSpots    int  `json:"promotionSpots"`
+	Playoffs          bool `json:"playoffs"`
+	PlayoffTeams      int  `json:"playoffTeams"`
+	TwoLeggedPlayoffs bool `json:"twoLeggedPlayoffs"`
+}
+
+// DivisionTable is the league table of a single division.
+type DivisionTable struct {
+	Division  int    `json:"division"`
+	Standings []Team `json:"standings"`
+}
+
+// SeasonArchive keeps what a season closed by end-season recorded beyond the table, since the next season's
+// fixture replaces its matches. Seasons are numbered from 1 in the order they were closed.
+type SeasonArchive struct {
+	Season         int       `json:"season"`
+	PlayoffMatches []Match   `json:"playoff_matches"`
+	ClosedAt       time.Time `json:"closed_at"`
+}
+
+// DivisionMovement records a team changing division at the end of a season.
+type DivisionMovement struct {
+	TeamID       int    `json:"team_id"`
+	TeamName     string `json:"team_name"`
+	FromDivision int    `json:"from_division"`
+	ToDivision   int    `json:"to_division"`
+	ViaPlayoff   bool   `json:"via_playoff,omitempty"`
+}
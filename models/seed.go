@@ -0,0 +1,41 @@
+package models
+
+// SeedTeam is one team of a seed roster. A division below 1 puts the team in the top division.
+type SeedTeam struct {
+	Name     string `json:"name"`
+	Strength int    `json:"strength"`
+	Division int    `json:"division,omitempty"`
+}
+
+// SeedPreset is a named roster the league can be started with, e.g. "premier-league".
+type SeedPreset struct {
+	Name  string     `json:"name"`
+	Title string     `json:"title"`
+	Teams []SeedTeam `json:"teams"`
+}
+
+// SeedPresets is the content of a seed file. Default names the preset used at first startup
+// and by POST /teams/reset-defaults when no preset is given.
+type SeedPresets struct {
+	Default string       `json:"default"`
+	Presets []SeedPreset `json:"presets"`
+}
+
+// Find returns the preset with the given name.
+func (p SeedPresets) Find(name string) (SeedPreset, bool) {
+	for _, preset := range p.Presets {
+		if preset.Name == name {
+			return preset, true
+		}
+	}
+	return SeedPreset{}, false
+}
+
+// TeamModels converts the roster into teams with zeroed statistics.
+func (p SeedPreset) TeamModels() []Team {
+	teams := make([]Team, 0, len(p.Teams))
+	for _, team := range p.Teams {
+		teams = append(teams, Team{Name: team.Name, Strength: team.Strength, Division: team.Division})
+	}
+	return teams
+}
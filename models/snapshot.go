@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// SnapshotFormatVersion is the version of the LeagueSnapshot document written by this build.
+// It is raised whenever the document changes in a way older builds cannot read.
+const SnapshotFormatVersion = 1
+
+// LeagueSnapshot is the complete state of a league as a single JSON document: teams with their
+// strengths and divisions, the fixture with every result, points adjustments, the simulation seed with
+// its current position, and the pyramid rules it was played under. IDs are only meaningful within the document; an import
+// assigns new ones. Team statistics are informational and are recomputed from the results on import.
+type LeagueSnapshot struct {
+	FormatVersion int                `json:"format_version"`
+	ExportedAt    time.Time          `json:"exported_at"`
+	Seed          int64              `json:"seed,string"` // Metin olarak yazılır; JavaScript istemcileri 64 bitlik sayıları bozar
+	SeedDraws     uint64             `json:"seed_draws"`  // Tohumdan bu yana çekilen rastgele sayı adedi; içe aktarma simülasyonu tam bu noktadan sürdürür
+	Rules         PyramidRules       `json:"rules"`
+	Teams         []Team             `json:"teams"`
+	Matches       []Match            `json:"matches"`
+	Adjustments   []PointsAdjustment `json:"adjustments"`
+}
@@ -12,5 +12,8 @@ type Team struct {
 	GoalsFor       int    `json:"goals_for"`
 	GoalsAgainst   int    `json:"goals_against"`
 	GoalDifference int    `json:"goal_difference"`
-	Points         int    `json:"points"`
+	Points         int    `json:"points"` // Puan düzeltmeleri dahil toplam puan
+	Division       int    `json:"division"`
+	Adjustment     int    `json:"adjustment"` // İdari puan silme/ekleme toplamı
+	Version        int    `json:"version"`    // Her güncellemede artar; ETag/If-Match ile eşzamanlı düzenleme kontrolü için
 }
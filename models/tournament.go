@@ -0,0 +1,8 @@
+package models
+
+// TournamentGroup is one group of a group stage together with its standings,
+// ranked with the same rules as the league table.
+type TournamentGroup struct {
+	Name      string `json:"name"`
+	Standings []Team `json:"standings"`
+}
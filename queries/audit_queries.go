@@ -0,0 +1,29 @@
+package queries
+
+const (
+	// InsertAuditEntrySQL, bir denetim kaydı ekler.
+	// Parametreler: $1=entityType, $2=entityID, $3=action, $4=actor, $5=oldValue, $6=newValue, $7=reason, $8=createdAt
+	InsertAuditEntrySQL = `
+		INSERT INTO audit_log (entity_type, entity_id, action, actor, old_value, new_value, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	// GetAuditEntryByIDSQL, ID'ye göre bir denetim kaydını getirir.
+	// Parametreler: $1=auditEntryID
+	GetAuditEntryByIDSQL = `
+		SELECT id, entity_type, entity_id, action, actor, old_value, new_value, reason, created_at
+		FROM audit_log
+		WHERE id = $1`
+
+	// GetAuditEntriesSQL, denetim kayıtlarını eski tarihten yeniye doğru getirir.
+	// Boş entityType ve 0 entityID filtre uygulanmadığı anlamına gelir; zaman aralığı [since, until) şeklindedir.
+	// Parametreler: $1=entityType, $2=entityID, $3=since, $4=until
+	GetAuditEntriesSQL = `
+		SELECT id, entity_type, entity_id, action, actor, old_value, new_value, reason, created_at
+		FROM audit_log
+		WHERE ($1 = '' OR entity_type = $1)
+		  AND ($2 = 0 OR entity_id = $2)
+		  AND created_at >= $3
+		  AND created_at < $4
+		ORDER BY created_at ASC, id ASC`
+)
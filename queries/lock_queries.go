@@ -0,0 +1,15 @@
+package queries
+
+const (
+	// TryLeagueXactLockSQL, lig kilidini içinde bulunulan transaction bitene kadar almaya çalışır; beklemez.
+	// Parametreler: $1=lockKey
+	TryLeagueXactLockSQL = `SELECT pg_try_advisory_xact_lock($1)`
+
+	// TryLeagueLockSQL, lig kilidini oturum seviyesinde almaya çalışır; beklemez.
+	// Parametreler: $1=lockKey
+	TryLeagueLockSQL = `SELECT pg_try_advisory_lock($1)`
+
+	// ReleaseLeagueLockSQL, oturum seviyesindeki lig kilidini bırakır.
+	// Parametreler: $1=lockKey
+	ReleaseLeagueLockSQL = `SELECT pg_advisory_unlock($1)`
+)
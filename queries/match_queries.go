@@ -5,15 +5,17 @@ const (
 	DeleteAllMatchesSQL = `DELETE FROM matches`
 
 	// InsertMatchSQL, yeni bir maçı matches tablosuna ekler.
-	// Parametreler: $1=week, $2=home_team_id, $3=away_team_id, $4=is_played, $5=home_goals, $6=away_goals
+	// Parametreler: $1=week, $2=home_team_id, $3=away_team_id, $4=is_played, $5=home_goals, $6=away_goals,
+	// $7=stage, $8=group_name, $9=round, $10=leg
 	InsertMatchSQL = `
-		INSERT INTO matches (week, home_team_id, away_team_id, is_played, home_goals, away_goals)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+		INSERT INTO matches (week, home_team_id, away_team_id, is_played, home_goals, away_goals, stage, group_name, round, leg)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
 	// GetMatchesByWeekSQL, belirtilen haftadaki maçları ID'ye göre sıralı getirir.
 	// Parametreler: $1 = week
 	GetMatchesByWeekSQL = `
-		SELECT id, week, home_team_id, away_team_id, home_goals, away_goals, is_played
+		SELECT id, week, home_team_id, away_team_id, home_goals, away_goals, is_played, stage, group_name, round, leg,
+			home_goals_et, away_goals_et, home_penalties, away_penalties, winner_team_id, is_forfeit, version
 		FROM matches
 		WHERE week = $1
 		ORDER BY id ASC`
@@ -21,20 +23,63 @@ const (
 	// GetMatchByIDSQL, ID'ye göre bir maçı getirir.
 	// Parametreler: $1 = matchID
 	GetMatchByIDSQL = `
-		SELECT id, week, home_team_id, away_team_id, home_goals, away_goals, is_played
+		SELECT id, week, home_team_id, away_team_id, home_goals, away_goals, is_played, stage, group_name, round, leg,
+			home_goals_et, away_goals_et, home_penalties, away_penalties, winner_team_id, is_forfeit, version
 		FROM matches
 		WHERE id = $1`
 
-	// UpdateMatchResultSQL, bir maçın skorunu ve oynanma durumunu günceller.
+	// UpdateMatchResultSQL, bir maçın skorunu ve oynanma durumunu günceller. Yeni skor hükmen galibiyet işaretini kaldırır.
 	// Parametreler: $1=home_goals, $2=away_goals, $3=is_played, $4=matchID
 	UpdateMatchResultSQL = `
 		UPDATE matches
-		SET home_goals = $1, away_goals = $2, is_played = $3
+		SET home_goals = $1, away_goals = $2, is_played = $3, is_forfeit = FALSE, version = version + 1
 		WHERE id = $4`
 
+	// EditMatchScoreSQL, düzeltilen skoru yalnızca maç okunduğu sürümdeyse yazar; arada başka bir değişiklik olduysa satır etkilenmez.
+	// Parametreler: $1=home_goals, $2=away_goals, $3=matchID, $4=version
+	EditMatchScoreSQL = `
+		UPDATE matches
+		SET home_goals = $1, away_goals = $2, is_played = TRUE, is_forfeit = FALSE, version = version + 1
+		WHERE id = $3 AND version = $4`
+
+	// ClearMatchResultSQL, maçı oynanmamış hâle getirir; skor, uzatma, penaltı, galip ve hükmen bilgileri silinir.
+	// Parametreler: $1=matchID
+	ClearMatchResultSQL = `
+		UPDATE matches
+		SET home_goals = NULL, away_goals = NULL, is_played = FALSE, is_forfeit = FALSE,
+			home_goals_et = NULL, away_goals_et = NULL, home_penalties = NULL, away_penalties = NULL, winner_team_id = NULL,
+			version = version + 1
+		WHERE id = $1`
+
+	// UpdateMatchForfeitSQL, maçın hükmen sonuçlandığını işaretler.
+	// Parametreler: $1=is_forfeit, $2=matchID
+	UpdateMatchForfeitSQL = `UPDATE matches SET is_forfeit = $1, version = version + 1 WHERE id = $2`
+
+	// UpdateKnockoutResultSQL, eleme maçının uzatma, penaltı ve tur atlayan takım bilgilerini günceller.
+	// Parametreler: $1=home_goals_et, $2=away_goals_et, $3=home_penalties, $4=away_penalties, $5=winner_team_id, $6=matchID
+	UpdateKnockoutResultSQL = `
+		UPDATE matches
+		SET home_goals_et = $1, away_goals_et = $2, home_penalties = $3, away_penalties = $4, winner_team_id = $5,
+			version = version + 1
+		WHERE id = $6`
+
 	// GetAllMatchesSQL, tüm maçları hafta ve ID'ye göre sıralı getirir.
 	GetAllMatchesSQL = `
-		SELECT id, week, home_team_id, away_team_id, home_goals, away_goals, is_played
+		SELECT id, week, home_team_id, away_team_id, home_goals, away_goals, is_played, stage, group_name, round, leg,
+			home_goals_et, away_goals_et, home_penalties, away_penalties, winner_team_id, is_forfeit, version
 		FROM matches
 		ORDER BY week ASC, id ASC`
+
+	// InsertSeasonArchiveSQL, kapanan sezonun play-off maçlarını (JSON) arşive yazar ve sezon numarasını döndürür.
+	// Parametreler: $1=playoff_matches, $2=closed_at
+	InsertSeasonArchiveSQL = `INSERT INTO season_archive (playoff_matches, closed_at) VALUES ($1, $2) RETURNING season`
+
+	// GetSeasonArchiveSQL, arşivlenmiş bir sezonu getirir; 0 en son kapanan sezon anlamına gelir.
+	// Parametreler: $1=season
+	GetSeasonArchiveSQL = `
+		SELECT season, playoff_matches, closed_at
+		FROM season_archive
+		WHERE ($1 = 0 OR season = $1)
+		ORDER BY season DESC
+		LIMIT 1`
 )
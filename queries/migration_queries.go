@@ -0,0 +1,48 @@
+package queries
+
+const (
+	// CreateSchemaMigrationsTableSQL, uygulanan şema migration'larını takip eden tabloyu oluşturur.
+	CreateSchemaMigrationsTableSQL = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`
+
+	// GetAppliedMigrationsSQL, uygulanmış migration'ları sürüm sırasına göre getirir.
+	GetAppliedMigrationsSQL = `SELECT version, name, applied_at FROM schema_migrations ORDER BY version ASC`
+
+	// GetAppliedMigrationVersionsSQL, yalnızca uygulanmış migration sürümlerini getirir; salt okunur kontroller içindir.
+	GetAppliedMigrationVersionsSQL = `SELECT version FROM schema_migrations`
+
+	// SchemaMigrationsTableExistsSQL, schema_migrations tablosunun var olup olmadığını tabloyu oluşturmadan söyler.
+	SchemaMigrationsTableExistsSQL = `SELECT to_regclass('schema_migrations') IS NOT NULL`
+
+	// InsertSchemaMigrationSQL, bir migration'ı uygulanmış olarak kaydeder.
+	// Parametreler: $1=version, $2=name
+	InsertSchemaMigrationSQL = `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`
+
+	// DeleteSchemaMigrationSQL, geri alınan bir migration'ın kaydını siler.
+	// Parametreler: $1=version
+	DeleteSchemaMigrationSQL = `DELETE FROM schema_migrations WHERE version = $1`
+
+	// AcquireMigrationLockSQL, aynı anda birden fazla sürecin migration çalıştırmasını engelleyen advisory lock'u alır.
+	// Parametreler: $1=lockKey
+	AcquireMigrationLockSQL = `SELECT pg_advisory_lock($1)`
+
+	// ReleaseMigrationLockSQL, migration advisory lock'unu bırakır.
+	// Parametreler: $1=lockKey
+	ReleaseMigrationLockSQL = `SELECT pg_advisory_unlock($1)`
+
+	// CreateSQLiteSchemaMigrationsTableSQL, schema_migrations tablosunun SQLite karşılığıdır.
+	// Diğer migration sorguları her iki veritabanında da aynen kullanılır.
+	CreateSQLiteSchemaMigrationsTableSQL = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`
+
+	// SQLiteSchemaMigrationsTableExistsSQL, SchemaMigrationsTableExistsSQL'in SQLite karşılığıdır.
+	SQLiteSchemaMigrationsTableExistsSQL = `SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'`
+)
@@ -5,24 +5,24 @@ const (
 	CreateTeamCheckExistsSQL = `SELECT id FROM teams WHERE name = $1`
 
 	// CreateTeamInsertSQL, yeni bir takımı sıfır istatistikle ekler.
-	// Parametreler: $1 name, $2 strength
+	// Parametreler: $1 name, $2 strength, $3 division
 	CreateTeamInsertSQL = `
-		INSERT INTO teams (name, strength, played, wins, draws, losses, goals_for, goals_against, goal_difference, points)
-		VALUES ($1, $2, 0, 0, 0, 0, 0, 0, 0, 0)
+		INSERT INTO teams (name, strength, played, wins, draws, losses, goals_for, goals_against, goal_difference, points, division)
+		VALUES ($1, $2, 0, 0, 0, 0, 0, 0, 0, 0, $3)
 		RETURNING id`
 
 	// GetTeamByIDSQL, ID'ye göre bir takımı getirir.
 	// Parametreler: $1 = teamID
 	GetTeamByIDSQL = `
-		SELECT id, name, strength, played, wins, draws, losses, goals_for, goals_against, goal_difference, points 
+		SELECT id, name, strength, played, wins, draws, losses, goals_for, goals_against, goal_difference, points + points_adjustment, division, points_adjustment, version
 		FROM teams 
 		WHERE id = $1`
 
 	// GetAllTeamsSQL, tüm takımları puan durumu sıralamasına göre getirir.
 	GetAllTeamsSQL = `
-		SELECT id, name, strength, played, wins, draws, losses, goals_for, goals_against, goal_difference, points 
+		SELECT id, name, strength, played, wins, draws, losses, goals_for, goals_against, goal_difference, points + points_adjustment, division, points_adjustment, version
 		FROM teams 
-		ORDER BY points DESC, goal_difference DESC, goals_for DESC, name ASC`
+		ORDER BY points + points_adjustment DESC, goal_difference DESC, goals_for DESC, name ASC`
 
 	// UpdateTeamMainStatsSQL, bir maç sonrası takımın ana istatistiklerini günceller.
 	// Parametreler: $1=winIncrement, $2=drawIncrement, $3=lossIncrement, $4=goalsScored, $5=goalsConceded, $6=pointsEarned, $7=teamID
@@ -35,12 +35,28 @@ const (
 			losses = losses + $3,      
 			goals_for = goals_for + $4,  
 			goals_against = goals_against + $5, 
-			points = points + $6       
+			points = points + $6,
+			version = version + 1
+		WHERE id = $7`
+
+	// RevertTeamMainStatsSQL, oynanmış bir maçın takım istatistiklerine katkısını geri alır (UpdateTeamMainStatsSQL'in tersi).
+	// Parametreler: $1=winDecrement, $2=drawDecrement, $3=lossDecrement, $4=goalsScored, $5=goalsConceded, $6=pointsEarned, $7=teamID
+	RevertTeamMainStatsSQL = `
+		UPDATE teams
+		SET
+			played = played - 1,
+			wins = wins - $1,
+			draws = draws - $2,
+			losses = losses - $3,
+			goals_for = goals_for - $4,
+			goals_against = goals_against - $5,
+			points = points - $6,
+			version = version + 1
 		WHERE id = $7`
 
 	// UpdateTeamGDSQL, bir takımın gol averajını günceller.
 	// Parametreler: $1 = teamID
-	UpdateTeamGDSQL = `UPDATE teams SET goal_difference = goals_for - goals_against WHERE id = $1`
+	UpdateTeamGDSQL = `UPDATE teams SET goal_difference = goals_for - goals_against, version = version + 1 WHERE id = $1`
 
 	// ResetAllTeamStatsSQL, tüm takımların istatistiklerini sıfırlar.
 	ResetAllTeamStatsSQL = `
@@ -53,7 +69,9 @@ const (
 			goals_for = 0,
 			goals_against = 0,
 			goal_difference = 0,
-			points = 0`
+			points = 0,
+			points_adjustment = 0,
+			version = version + 1`
 
 	// AdjustTeamStatsSQL, skor değişikliği sonrası takımın ana istatistiklerini ayarlar.
 	// Parametreler: $1=deltaWins, $2=deltaDraws, $3=deltaLosses, $4=deltaGoalsFor, $5=deltaGoalsAgainst, $6=deltaPoints, $7=teamID
@@ -65,24 +83,53 @@ const (
 			losses = losses + $3,          
 			goals_for = goals_for + $4,    
 			goals_against = goals_against + $5, 
-			points = points + $6           
+			points = points + $6,
+			version = version + 1
 		WHERE id = $7`
 
-	// UpdateTeamStrengthSQL, bir takımın gücünü günceller.
-	// Parametreler: $1=newStrength, $2=teamID
-	UpdateTeamStrengthSQL = `UPDATE teams SET strength = $1 WHERE id = $2`
+	// UpdateTeamStrengthSQL, bir takımın gücünü günceller. expectedVersion 0 değilse yalnızca o sürümdeki satır güncellenir.
+	// Parametreler: $1=newStrength, $2=teamID, $3=expectedVersion
+	UpdateTeamStrengthSQL = `UPDATE teams SET strength = $1, version = version + 1 WHERE id = $2 AND ($3 = 0 OR version = $3)`
 
-	// UpdateTeamNameSQL, bir takımın ismini günceller.
-	// Parametreler: $1=newName, $2=teamID
-	UpdateTeamNameSQL = `UPDATE teams SET name = $1 WHERE id = $2`
+	// UpdateTeamNameSQL, bir takımın ismini günceller. expectedVersion 0 değilse yalnızca o sürümdeki satır güncellenir.
+	// Parametreler: $1=newName, $2=teamID, $3=expectedVersion
+	UpdateTeamNameSQL = `UPDATE teams SET name = $1, version = version + 1 WHERE id = $2 AND ($3 = 0 OR version = $3)`
 
-	// UpdateTeamNameAndStrengthSQL, bir takımın ismini ve gücünü günceller.
-	// Parametreler: $1=newName, $2=newStrength, $3=teamID
-	UpdateTeamNameAndStrengthSQL = `UPDATE teams SET name = $1, strength = $2 WHERE id = $3`
+	// UpdateTeamDivisionSQL, bir takımın ligini (kademesini) günceller. expectedVersion 0 değilse yalnızca o sürümdeki satır güncellenir.
+	// Parametreler: $1=division, $2=teamID, $3=expectedVersion
+	UpdateTeamDivisionSQL = `UPDATE teams SET division = $1, version = version + 1 WHERE id = $2 AND ($3 = 0 OR version = $3)`
 
-	// GetAllTeamsOrderedByIDSQL, tüm takımları ID'ye göre sıralı getirir (varsayılana reset için).
-	GetAllTeamsOrderedByIDSQL = `
-		SELECT id, name, strength, played, wins, draws, losses, goals_for, goals_against, goal_difference, points 
-		FROM teams 
-		ORDER BY id ASC`
+	// ApplyTeamPointsAdjustmentSQL, takımın toplam puan düzeltmesine verilen değeri ekler.
+	// Parametreler: $1=points, $2=teamID
+	ApplyTeamPointsAdjustmentSQL = `UPDATE teams SET points_adjustment = points_adjustment + $1, version = version + 1 WHERE id = $2`
+
+	// InsertPointsAdjustmentSQL, bir puan düzeltmesini gerekçesiyle birlikte kaydeder.
+	// Parametreler: $1=teamID, $2=points, $3=reason
+	InsertPointsAdjustmentSQL = `
+		INSERT INTO points_adjustments (team_id, points, reason)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+
+	// GetPointsAdjustmentsSQL, tüm puan düzeltmelerini uygulanma sırasına göre getirir.
+	GetPointsAdjustmentsSQL = `
+		SELECT id, team_id, points, reason, created_at
+		FROM points_adjustments
+		ORDER BY created_at ASC, id ASC`
+
+	// GetPointsAdjustmentsByTeamSQL, bir takımın puan düzeltmelerini getirir.
+	// Parametreler: $1=teamID
+	GetPointsAdjustmentsByTeamSQL = `
+		SELECT id, team_id, points, reason, created_at
+		FROM points_adjustments
+		WHERE team_id = $1
+		ORDER BY created_at ASC, id ASC`
+
+	// DeleteAllPointsAdjustmentsSQL, sezon sıfırlanırken tüm puan düzeltmelerini siler.
+	DeleteAllPointsAdjustmentsSQL = `DELETE FROM points_adjustments`
+
+	// DeleteAllTeamsSQL, bütün takımları siler; maçlar ve puan düzeltmeleri ON DELETE CASCADE ile birlikte silinir.
+	DeleteAllTeamsSQL = `DELETE FROM teams`
+
+	// DeleteTeamSQL, tek bir takımı siler; maçları ve puan düzeltmeleri ON DELETE CASCADE ile birlikte silinir.
+	DeleteTeamSQL = `DELETE FROM teams WHERE id = $1`
 )
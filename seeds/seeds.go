@@ -0,0 +1,100 @@
+// Package seeds loads the team rosters (presets) the league can be started with.
+package seeds
+
+import (
+	"MatchSimulator_Insider/models"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// builtinPresets, config.json'da seedFile verilmediğinde kullanılan preset dosyasıdır.
+//
+//go:embed presets.json
+var builtinPresets []byte
+
+// Builtin returns the presets shipped with the application.
+func Builtin() (models.SeedPresets, error) {
+	presets, err := parse(builtinPresets)
+	if err != nil {
+		return models.SeedPresets{}, fmt.Errorf("seeds.Builtin: %w", err)
+	}
+	return presets, nil
+}
+
+// Load reads and validates a seed file.
+func Load(path string) (models.SeedPresets, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.SeedPresets{}, fmt.Errorf("seeds.Load: could not read seed file '%s': %w", path, err)
+	}
+	presets, err := parse(data)
+	if err != nil {
+		return models.SeedPresets{}, fmt.Errorf("seeds.Load: seed file '%s': %w", path, err)
+	}
+	return presets, nil
+}
+
+func parse(data []byte) (models.SeedPresets, error) {
+	var presets models.SeedPresets
+	if err := json.Unmarshal(data, &presets); err != nil {
+		return models.SeedPresets{}, fmt.Errorf("could not decode presets: %w", err)
+	}
+	if err := validate(presets); err != nil {
+		return models.SeedPresets{}, err
+	}
+	return presets, nil
+}
+
+// validate checks that every preset can start a league: unique names, strengths between 1 and 100
+// and at least 2 teams in every division, which the fixture needs.
+func validate(presets models.SeedPresets) error {
+	if len(presets.Presets) == 0 {
+		return fmt.Errorf("no presets defined")
+	}
+	seenPresets := make(map[string]bool)
+	for _, preset := range presets.Presets {
+		if strings.TrimSpace(preset.Name) == "" {
+			return fmt.Errorf("a preset has no name")
+		}
+		if seenPresets[preset.Name] {
+			return fmt.Errorf("preset '%s' is defined more than once", preset.Name)
+		}
+		seenPresets[preset.Name] = true
+
+		seenTeams := make(map[string]bool)
+		teamsPerDivision := make(map[int]int)
+		for _, team := range preset.Teams {
+			name := strings.TrimSpace(team.Name)
+			if name == "" {
+				return fmt.Errorf("preset '%s' has a team without a name", preset.Name)
+			}
+			if seenTeams[name] {
+				return fmt.Errorf("preset '%s' lists team '%s' more than once", preset.Name, name)
+			}
+			seenTeams[name] = true
+			if team.Strength < 1 || team.Strength > 100 {
+				return fmt.Errorf("preset '%s': team '%s' has strength %d; strength must be between 1 and 100", preset.Name, name, team.Strength)
+			}
+			division := team.Division
+			if division < 1 {
+				division = 1
+			}
+			teamsPerDivision[division]++
+		}
+		if len(preset.Teams) < 2 {
+			return fmt.Errorf("preset '%s' needs at least 2 teams, has %d", preset.Name, len(preset.Teams))
+		}
+		for division, count := range teamsPerDivision {
+			if count < 2 {
+				return fmt.Errorf("preset '%s': division %d has %d team; at least 2 are required", preset.Name, division, count)
+			}
+		}
+	}
+	if _, ok := presets.Find(presets.Default); !ok {
+		return fmt.Errorf("default preset '%s' is not defined", presets.Default)
+	}
+	return nil
+}
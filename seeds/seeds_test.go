@@ -0,0 +1,79 @@
+package seeds
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuiltin(t *testing.T) {
+	presets, err := Builtin()
+	if err != nil {
+		t.Fatalf("Builtin() error = %v", err)
+	}
+	for _, name := range []string{"premier-league", "la-liga", "super-lig"} {
+		if preset, ok := presets.Find(name); !ok || len(preset.Teams) < 2 {
+			t.Errorf("expected built-in preset %q with teams, got %+v (found %v)", name, preset, ok)
+		}
+	}
+	if preset, _ := presets.Find(presets.Default); preset.Teams[0].Name != "Chelsea" {
+		t.Errorf("expected the default preset to start with Chelsea, got %+v", preset)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantError string
+	}{
+		{
+			name:    "Valid",
+			content: `{"default":"a","presets":[{"name":"a","teams":[{"name":"X","strength":50},{"name":"Y","strength":60}]}]}`,
+		},
+		{name: "Malformed", content: `{"default":`, wantError: "could not decode"},
+		{name: "No Presets", content: `{"default":"a","presets":[]}`, wantError: "no presets"},
+		{
+			name:      "Unknown Default",
+			content:   `{"default":"b","presets":[{"name":"a","teams":[{"name":"X","strength":50},{"name":"Y","strength":60}]}]}`,
+			wantError: "default preset 'b'",
+		},
+		{
+			name:      "Duplicate Team",
+			content:   `{"default":"a","presets":[{"name":"a","teams":[{"name":"X","strength":50},{"name":"X","strength":60}]}]}`,
+			wantError: "more than once",
+		},
+		{
+			name:      "Invalid Strength",
+			content:   `{"default":"a","presets":[{"name":"a","teams":[{"name":"X","strength":0},{"name":"Y","strength":60}]}]}`,
+			wantError: "strength 0",
+		},
+		{
+			name:      "Lonely Division",
+			content:   `{"default":"a","presets":[{"name":"a","teams":[{"name":"X","strength":50},{"name":"Y","strength":60},{"name":"Z","strength":40,"division":2}]}]}`,
+			wantError: "division 2 has 1 team",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "presets.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(path)
+			if tt.wantError == "" {
+				if err != nil {
+					t.Fatalf("Load() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Fatalf("Load() error = %v, want it to contain %q", err, tt.wantError)
+			}
+		})
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load() of a missing file succeeded, want an error")
+	}
+}
@@ -0,0 +1,13 @@
+package abstracts
+
+import (
+	"MatchSimulator_Insider/models"
+	"context"
+)
+
+// AuditService, skor düzenlemeleri, takım değişiklikleri, sıfırlamalar ve puan düzeltmeleri için kalıcı denetim kaydı tutar.
+type AuditService interface {
+	Record(ctx context.Context, entry models.AuditEntry) (models.AuditEntry, error) // ID ve CreatedAt doldurulmuş kaydı döndürür
+	GetByID(ctx context.Context, id int) (*models.AuditEntry, error)
+	List(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error)
+}
@@ -0,0 +1,53 @@
+package abstracts
+
+import "errors"
+
+// ErrVersionConflict, If-Match ile gönderilen sürüm kaydın güncel sürümüyle eşleşmediğinde döner;
+// kayıt istemci okuduktan sonra başka biri tarafından değiştirilmiştir.
+var ErrVersionConflict = errors.New("the record was modified by someone else")
+
+// ErrCannotRevert, geri alınmak istenen değişiklik geri alınamadığında döner; örneğin hiç hafta oynanmamıştır
+// veya sonucuna bağlı bir sonraki tur zaten çekilmiştir.
+var ErrCannotRevert = errors.New("cannot be reverted")
+
+// ErrInvalidSnapshot, içe aktarılan lig belgesi okunamadığında veya kendi içinde tutarsız olduğunda döner.
+var ErrInvalidSnapshot = errors.New("invalid league snapshot")
+
+// ErrTeamNotFound, istenen ID'ye sahip takım olmadığında döner.
+var ErrTeamNotFound = errors.New("team not found")
+
+// ErrMatchNotFound, istenen ID'ye sahip maç olmadığında döner.
+var ErrMatchNotFound = errors.New("match not found")
+
+// ErrAuditEntryNotFound, istenen ID'ye sahip audit kaydı olmadığında döner.
+var ErrAuditEntryNotFound = errors.New("audit entry not found")
+
+// ErrSeasonNotFound, istenen numarayla arşivlenmiş bir sezon olmadığında döner.
+var ErrSeasonNotFound = errors.New("season not found")
+
+// ErrInvalidStrength, takım gücü 1-100 aralığının dışında olduğunda döner.
+var ErrInvalidStrength = errors.New("invalid strength value")
+
+// ErrDuplicateName, takım adı başka bir takım tarafından kullanılıyorsa döner.
+var ErrDuplicateName = errors.New("duplicate team name")
+
+// ErrInvalidInput, istek geçersiz bir değer içerdiğinde (boş isim, geçersiz lig kademesi, kurallara uymayan turnuva ayarı vb.) döner.
+var ErrInvalidInput = errors.New("invalid input")
+
+// ErrLeagueFinished, bütün maçlar oynandıktan sonra yeni bir hafta oynatılmak istendiğinde döner.
+var ErrLeagueFinished = errors.New("the league is finished")
+
+// ErrFixtureMissing, oynatılacak haftanın maçları bulunamadığında döner; fikstür oluşturulmamış veya eksiktir.
+var ErrFixtureMissing = errors.New("fixture is missing")
+
+// ErrPredictionsUnavailable, şampiyonluk tahminleri ilk 4 hafta tamamlanmadan istendiğinde döner.
+var ErrPredictionsUnavailable = errors.New("championship predictions are not available yet")
+
+// ErrStageNotFinished, sezon, grup aşaması veya eleme turu bitmeden bir sonraki adıma geçilmek istendiğinde döner.
+var ErrStageNotFinished = errors.New("the current stage is not finished")
+
+// ErrSeasonInProgress, sezonda oynanmış maç varken takım eklenmek veya silinmek istendiğinde döner; önce lig sıfırlanmalıdır.
+var ErrSeasonInProgress = errors.New("the season is in progress")
+
+// ErrNoTournament, devam eden bir turnuva yokken turnuva ilerletilmek istendiğinde döner.
+var ErrNoTournament = errors.New("no tournament in progress")
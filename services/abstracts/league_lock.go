@@ -0,0 +1,15 @@
+package abstracts
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrLeagueBusy, lig üzerinde başka bir değişiklik (hafta oynatma, sıfırlama, skor düzeltme) sürerken döner.
+var ErrLeagueBusy = errors.New("another league operation is already in progress")
+
+// LeagueLocker, ligin durumunu değiştiren işlemleri birden fazla sunucu örneği arasında sıralar.
+// TryLockLeague kilit alınamazsa beklemez, ErrLeagueBusy döndürür.
+type LeagueLocker interface {
+	TryLockLeague(ctx context.Context) (release func(), err error)
+}
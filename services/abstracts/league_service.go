@@ -10,7 +10,14 @@ type ILeagueService interface {
 	GetLeagueTable(ctx context.Context) ([]models.Team, error)
 	GetCurrentWeek(ctx context.Context) (int, error)
 	GetChampionshipPredictions(ctx context.Context) (map[int]float64, error)
+	GetClinchedChampion(ctx context.Context) (int, error) // Şampiyonluğu matematiksel olarak garantileyen takımın ID'si; şampiyonluk hâlâ açıksa 0
 	ResetLeague(ctx context.Context) error
 	PlayAllRemainingWeeks(ctx context.Context) (map[int][]models.Match, []models.Team, error)
-	HandleMatchScoreEdit(ctx context.Context, matchID int, newHomeGoals int, newAwayGoals int) error // YENİ METOT
+	HandleMatchScoreEdit(ctx context.Context, matchID int, newHomeGoals int, newAwayGoals int, expectedVersion int) error // YENİ METOT; expectedVersion 0 ise sürüm kontrolü yapılmaz
+	AwardForfeit(ctx context.Context, matchID int, winnerTeamID int) error                                                // Maçı hükmen 3-0 verir
+	RevertLastPlayedWeek(ctx context.Context) (int, []models.Match, error)                                                // Son oynanan haftayı geri alır; haftayı ve maçların geri alınmadan önceki hâlini döndürür
+	RevertMatchResult(ctx context.Context, matchID int, expectedVersion int) error                                        // Maçı oynanmamış hâle getirir ve istatistiklerden çıkarır
+	AddTeam(ctx context.Context, team models.Team) (models.Team, error)                                                   // Sezonda maç oynanmadıysa takımı ekler ve fikstürü yeniden oluşturur
+	RemoveTeam(ctx context.Context, teamID int) (models.Team, error)                                                      // Sezonda maç oynanmadıysa takımı siler, fikstürü yeniden oluşturur ve silinen takımı döndürür
+	ReplaceTeams(ctx context.Context, roster []models.Team) ([]models.Team, error)                                        // Bütün takımları silip verilen kadroyla yeni bir lig başlatır (örn. seed preset'i)
 }
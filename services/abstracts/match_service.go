@@ -14,6 +14,29 @@ type IMatchService interface {
 	SimulateMatchOutcome(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (homeGoals int, awayGoals int, err error)
 
 	// EditMatchScore, belirli bir maçın skorunu günceller ve eski maç verisini döndürür.
-	// Maçın 'is_played' durumu true olarak güncellenir.
-	EditMatchScore(ctx context.Context, matchID int, newHomeGoals int, newAwayGoals int) (originalMatch models.Match, err error) // YENİ METOT
+	// Maçın 'is_played' durumu true olarak güncellenir. expectedVersion 0 değilse ve maçın sürümü farklıysa ErrVersionConflict döner.
+	EditMatchScore(ctx context.Context, matchID int, newHomeGoals int, newAwayGoals int, expectedVersion int) (originalMatch models.Match, err error) // YENİ METOT
+
+	// StoreMatches, verilen maçları tek bir transaction içinde kaydeder.
+	// replaceExisting true ise mevcut bütün maçlar önce silinir (yeni fikstür), false ise maçlar mevcut fikstüre eklenir.
+	StoreMatches(ctx context.Context, matches []models.Match, replaceExisting bool) error
+
+	// SimulateExtraTime, 30 dakikalık uzatmada atılan golleri simüle eder.
+	SimulateExtraTime(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (homeGoals int, awayGoals int, err error)
+	// SimulatePenaltyShootout, beşer atışlık ve gerekirse ani ölümle devam eden penaltı atışlarını tek tek simüle eder.
+	SimulatePenaltyShootout(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (homeScore int, awayScore int, err error)
+	// UpdateKnockoutResult, eleme maçının uzatma/penaltı sonucunu ve tur atlayan takımı kaydeder. Kullanılmayan aşamalar nil geçilir.
+	UpdateKnockoutResult(ctx context.Context, matchID int, homeGoalsET, awayGoalsET, homePenalties, awayPenalties *int, winnerTeamID int) error
+
+	// ClearMatchResult, maçı oynanmamış hâle getirir. Skor, uzatma, penaltı, galip ve hükmen bilgileri silinir; takım istatistiklerine dokunulmaz.
+	ClearMatchResult(ctx context.Context, matchID int) error
+
+	// SetMatchForfeit, maçın hükmen sonuçlandığını işaretler. Skor güncellemeleri bu işareti kaldırır.
+	SetMatchForfeit(ctx context.Context, matchID int, isForfeit bool) error
+
+	// ArchiveSeason, kapanan sezonun play-off maçlarını arşive yazar ve sezona verilen numarayı (1'den başlar) döndürür.
+	// Arşiv yeni fikstürle silinmez.
+	ArchiveSeason(ctx context.Context, playoffMatches []models.Match) (season int, err error)
+	// GetSeasonArchive, arşivlenmiş sezonu döndürür. season 0 ise en son kapanan sezon kullanılır; yoksa ErrSeasonNotFound döner.
+	GetSeasonArchive(ctx context.Context, season int) (*models.SeasonArchive, error)
 }
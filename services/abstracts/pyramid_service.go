@@ -0,0 +1,26 @@
+package abstracts
+
+import (
+	"MatchSimulator_Insider/models"
+	"context"
+)
+
+type IPyramidService interface {
+	// GetDivisionTables, lig tablosunu kademelere (division) ayırarak döndürür. 1 en üst ligdir.
+	GetDivisionTables(ctx context.Context) ([]models.DivisionTable, error)
+
+	// EndSeason, sezon bittiğinde varsa play-off maçlarını oluşturup oynatır, küme düşen ve çıkan takımları
+	// yer değiştirir ve her lig için yeni sezonun fikstürünü oluşturur.
+	EndSeason(ctx context.Context) ([]models.DivisionMovement, error)
+
+	// PlayPlayoffs, lig maçları bittiğinde alt liglerin play-off'larını puan tablosundan oluşturup oynatır.
+	// Küme düşme/yükselme uygulanmaz; bu adım EndSeason ile tamamlanır.
+	PlayPlayoffs(ctx context.Context) ([]models.Match, error)
+
+	// GetPlayoffMatches, mevcut sezonun play-off maçlarını tur ve ayak sırasıyla döndürür.
+	GetPlayoffMatches(ctx context.Context) ([]models.Match, error)
+
+	// GetSeasonArchive, EndSeason ile kapatılmış bir sezonu play-off maçlarıyla döndürür. season 0 en son kapanan sezondur;
+	// arşivde yoksa ErrSeasonNotFound döner.
+	GetSeasonArchive(ctx context.Context, season int) (*models.SeasonArchive, error)
+}
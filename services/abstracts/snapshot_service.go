@@ -0,0 +1,15 @@
+package abstracts
+
+import (
+	"MatchSimulator_Insider/models"
+	"context"
+)
+
+type ISnapshotService interface {
+	// Export, ligin bütün durumunu (takımlar, fikstür, sonuçlar, puan düzeltmeleri, seed ve kurallar) tek bir belge olarak döndürür.
+	Export(ctx context.Context) (models.LeagueSnapshot, error)
+
+	// Import, mevcut ligi silip yerine belgedeki ligi kurar. Belge geçersizse hiçbir şey silinmeden ErrInvalidSnapshot döner.
+	// Belgedeki kurallar sunucunun ayarlarından farklıysa sunucunun kuralları geçerli kalır ve uyarı olarak bildirilir.
+	Import(ctx context.Context, snapshot models.LeagueSnapshot) (warnings []string, err error)
+}
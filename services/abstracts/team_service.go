@@ -10,9 +10,14 @@ type TeamService interface {
 	GetTeamByID(ctx context.Context, id int) (*models.Team, error)
 	GetAllTeams(ctx context.Context) ([]models.Team, error)
 	UpdateTeamStatsAfterMatch(ctx context.Context, teamID int, goalsScored int, goalsConceded int) error // Bu, normal maç oynandığında kullanılır.
+	RevertTeamStatsAfterMatch(ctx context.Context, teamID int, goalsScored int, goalsConceded int) error // UpdateTeamStatsAfterMatch'in tersi; oynanmış bir maç geri alınırken kullanılır
 	ResetAllTeamStats(ctx context.Context) error
 	AdjustTeamStatsForScoreChange(ctx context.Context, teamID int, oldGoalsForTeam, oldGoalsAgainstTeam, newGoalsForTeam, newGoalsAgainstTeam int) error // YENİ METOT
-	UpdateTeamStrength(ctx context.Context, teamID int, newStrength int) error
-	UpdateTeamName(ctx context.Context, teamID int, newName string) error
-	ResetTeamsToDefaults(ctx context.Context) error
+	UpdateTeamStrength(ctx context.Context, teamID int, newStrength int, expectedVersion int) error                                                      // expectedVersion 0 ise sürüm kontrolü yapılmaz
+	UpdateTeamName(ctx context.Context, teamID int, newName string, expectedVersion int) error
+	UpdateTeamDivision(ctx context.Context, teamID int, division int, expectedVersion int) error
+	ApplyPointsAdjustment(ctx context.Context, teamID int, points int, reason string) (models.PointsAdjustment, error) // Puan silme (negatif) veya ekleme (pozitif)
+	GetPointsAdjustments(ctx context.Context, teamID int) ([]models.PointsAdjustment, error)                           // teamID 0 ise tüm takımlar
+	DeleteAllTeams(ctx context.Context) error                                                                          // Bütün takımları ve puan düzeltmelerini siler; maçlar veritabanında cascade ile silinir
+	DeleteTeam(ctx context.Context, teamID int) error                                                                  // Takımı ve puan düzeltmelerini siler; takımın maçları veritabanında cascade ile silinir
 }
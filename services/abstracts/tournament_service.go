@@ -0,0 +1,18 @@
+package abstracts
+
+import (
+	"MatchSimulator_Insider/models"
+	"context"
+)
+
+type ITournamentService interface {
+	// CreateTournament, takımları torbalara göre gruplara dağıtır ve grup maçlarının fikstürünü oluşturur.
+	// pots boşsa takımlar güçlerine göre torbalara ayrılır.
+	CreateTournament(ctx context.Context, groupCount int, pots [][]int) ([]models.TournamentGroup, error)
+	GetGroupStandings(ctx context.Context) ([]models.TournamentGroup, error)
+	GetKnockoutMatches(ctx context.Context) ([]models.Match, error)
+
+	// AdvanceTournament, grup aşaması bittiyse eleme turunun ilk turunu, bir eleme turu bittiyse bir sonraki turu oluşturur.
+	// advancePerGroup yalnızca grup aşamasından elemeye geçilirken kullanılır. Final oynandıysa şampiyonun ID'si döner.
+	AdvanceTournament(ctx context.Context, advancePerGroup int) (round int, newMatches []models.Match, championTeamID int, err error)
+}
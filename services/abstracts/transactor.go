@@ -0,0 +1,9 @@
+package abstracts
+
+import "context"
+
+// Transactor, birden fazla servis çağrısını tek bir transaction içinde çalıştırır.
+// fn hata döndürürse yapılan tüm değişiklikler geri alınır.
+type Transactor interface {
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
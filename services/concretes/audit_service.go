@@ -0,0 +1,137 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/database"
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/queries"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// auditListEnd stands in for an open-ended Until so every backend can run the same range query.
+var auditListEnd = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// PostgresAuditService stores audit entries in the audit_log table. Inside a request transaction the entry
+// is written in the same transaction as the change it describes, so both are committed or rolled back together.
+type PostgresAuditService struct {
+	DB database.DB
+}
+
+// NewPostgresAuditService creates an audit service on a migrated PostgreSQL database.
+func NewPostgresAuditService(db database.DB) abstracts.AuditService {
+	return &PostgresAuditService{DB: db}
+}
+
+// conn returns the transaction stored in ctx, or the pool.
+func (s *PostgresAuditService) conn(ctx context.Context) database.DB {
+	return database.Conn(ctx, s.DB)
+}
+
+// Record stores the entry and returns it with its ID and timestamp.
+func (s *PostgresAuditService) Record(ctx context.Context, entry models.AuditEntry) (models.AuditEntry, error) {
+	entry, err := prepareAuditEntry(entry)
+	if err != nil {
+		return models.AuditEntry{}, fmt.Errorf("PostgresAuditService.Record: %w", err)
+	}
+	err = s.conn(ctx).QueryRow(ctx, queries.InsertAuditEntrySQL, auditEntryArgs(entry)...).Scan(&entry.ID)
+	if err != nil {
+		return models.AuditEntry{}, fmt.Errorf("PostgresAuditService.Record: Error recording %s of %s %d: %w", entry.Action, entry.EntityType, entry.EntityID, err)
+	}
+	return entry, nil
+}
+
+// GetByID returns a single audit entry.
+func (s *PostgresAuditService) GetByID(ctx context.Context, id int) (*models.AuditEntry, error) {
+	entry, err := scanAuditEntry(s.conn(ctx).QueryRow(ctx, queries.GetAuditEntryByIDSQL, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("PostgresAuditService.GetByID: %w (ID: %d)", abstracts.ErrAuditEntryNotFound, id)
+		}
+		return nil, fmt.Errorf("PostgresAuditService.GetByID: Error retrieving audit entry (ID: %d): %w", id, err)
+	}
+	return &entry, nil
+}
+
+// List returns the entries matching filter, oldest first.
+func (s *PostgresAuditService) List(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error) {
+	rows, err := s.conn(ctx).Query(ctx, queries.GetAuditEntriesSQL, auditFilterArgs(filter)...)
+	if err != nil {
+		return nil, fmt.Errorf("PostgresAuditService.List: Error retrieving audit entries: %w", err)
+	}
+	defer rows.Close()
+	var entries []models.AuditEntry
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("PostgresAuditService.List: Error scanning audit entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("PostgresAuditService.List: Error processing rows: %w", err)
+	}
+	return entries, nil
+}
+
+// prepareAuditEntry validates an entry before it is stored and stamps it with the current time.
+// Timestamps are UTC with microsecond precision so every backend returns the value it was given.
+func prepareAuditEntry(entry models.AuditEntry) (models.AuditEntry, error) {
+	if entry.EntityType == "" || entry.Action == "" || entry.Actor == "" {
+		return models.AuditEntry{}, fmt.Errorf("audit entry needs an entity type, action and actor")
+	}
+	for _, value := range []json.RawMessage{entry.OldValue, entry.NewValue} {
+		if len(value) > 0 && !json.Valid(value) {
+			return models.AuditEntry{}, fmt.Errorf("audit entry value %q is not valid JSON", value)
+		}
+	}
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	return entry, nil
+}
+
+// auditEntryArgs returns the parameters of InsertAuditEntrySQL. Missing values are stored as NULL.
+func auditEntryArgs(entry models.AuditEntry) []any {
+	return []any{entry.EntityType, entry.EntityID, entry.Action, entry.Actor,
+		nullableJSON(entry.OldValue), nullableJSON(entry.NewValue), entry.Reason, entry.CreatedAt}
+}
+
+// auditFilterArgs returns the parameters of GetAuditEntriesSQL.
+func auditFilterArgs(filter models.AuditFilter) []any {
+	until := filter.Until
+	if until.IsZero() {
+		until = auditListEnd
+	}
+	return []any{filter.EntityType, filter.EntityID, filter.Since.UTC(), until.UTC()}
+}
+
+func nullableJSON(value json.RawMessage) *string {
+	if len(value) == 0 {
+		return nil
+	}
+	text := string(value)
+	return &text
+}
+
+// scanAuditEntry reads a row of GetAuditEntriesSQL.
+func scanAuditEntry(row pgx.Row) (models.AuditEntry, error) {
+	var entry models.AuditEntry
+	var oldValue, newValue *string
+	err := row.Scan(&entry.ID, &entry.EntityType, &entry.EntityID, &entry.Action, &entry.Actor,
+		&oldValue, &newValue, &entry.Reason, &entry.CreatedAt)
+	if err != nil {
+		return models.AuditEntry{}, err
+	}
+	if oldValue != nil {
+		entry.OldValue = json.RawMessage(*oldValue)
+	}
+	if newValue != nil {
+		entry.NewValue = json.RawMessage(*newValue)
+	}
+	entry.CreatedAt = entry.CreatedAt.UTC()
+	return entry, nil
+}
@@ -0,0 +1,120 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// auditBackends lists every audit log implementation, opened on the same databases as the storage conformance suite.
+func auditBackends() map[string]func(t *testing.T) abstracts.AuditService {
+	return map[string]func(t *testing.T) abstracts.AuditService{
+		"Memory":   func(t *testing.T) abstracts.AuditService { return NewMemoryAuditService() },
+		"SQLite":   func(t *testing.T) abstracts.AuditService { return NewSQLiteAuditService(newSQLiteTestDB(t)) },
+		"Postgres": func(t *testing.T) abstracts.AuditService { return NewPostgresAuditService(newPostgresTestPool(t)) },
+	}
+}
+
+func TestAuditServiceConformance(t *testing.T) {
+	for name, open := range auditBackends() {
+		t.Run(name, func(t *testing.T) {
+			auditService := open(t)
+			ctx := context.Background()
+
+			record := func(entry models.AuditEntry) models.AuditEntry {
+				t.Helper()
+				recorded, err := auditService.Record(ctx, entry)
+				if err != nil {
+					t.Fatalf("Record(%s %s) failed: %v", entry.EntityType, entry.Action, err)
+				}
+				return recorded
+			}
+			scoreEdit := record(models.AuditEntry{
+				EntityType: models.AuditEntityMatch, EntityID: 7, Action: models.AuditActionScoreEdit, Actor: "alice",
+				OldValue: json.RawMessage(`{"home_goals":1,"away_goals":0}`), NewValue: json.RawMessage(`{"home_goals":2,"away_goals":2}`),
+				Reason: "VAR review",
+			})
+			time.Sleep(2 * time.Millisecond)
+			rename := record(models.AuditEntry{
+				EntityType: models.AuditEntityTeam, EntityID: 3, Action: models.AuditActionNameChange, Actor: "bob",
+				OldValue: json.RawMessage(`"Arsenal"`), NewValue: json.RawMessage(`"Gunners"`),
+			})
+			time.Sleep(2 * time.Millisecond)
+			reset := record(models.AuditEntry{EntityType: models.AuditEntityLeague, Action: models.AuditActionLeagueReset, Actor: "alice"})
+
+			if scoreEdit.ID == 0 || rename.ID <= scoreEdit.ID || reset.ID <= rename.ID {
+				t.Fatalf("IDs = %d, %d, %d, want increasing", scoreEdit.ID, rename.ID, reset.ID)
+			}
+			if scoreEdit.CreatedAt.IsZero() {
+				t.Fatal("Record did not set CreatedAt")
+			}
+
+			list := func(filter models.AuditFilter) []models.AuditEntry {
+				t.Helper()
+				entries, err := auditService.List(ctx, filter)
+				if err != nil {
+					t.Fatalf("List(%+v) failed: %v", filter, err)
+				}
+				return entries
+			}
+			ids := func(entries []models.AuditEntry) []int {
+				result := []int{}
+				for _, entry := range entries {
+					result = append(result, entry.ID)
+				}
+				return result
+			}
+			tests := []struct {
+				name   string
+				filter models.AuditFilter
+				want   []int
+			}{
+				{name: "All", filter: models.AuditFilter{}, want: []int{scoreEdit.ID, rename.ID, reset.ID}},
+				{name: "Entity Type", filter: models.AuditFilter{EntityType: models.AuditEntityTeam}, want: []int{rename.ID}},
+				{name: "Entity", filter: models.AuditFilter{EntityType: models.AuditEntityMatch, EntityID: 7}, want: []int{scoreEdit.ID}},
+				{name: "Other Entity", filter: models.AuditFilter{EntityType: models.AuditEntityMatch, EntityID: 8}, want: []int{}},
+				{name: "Since", filter: models.AuditFilter{Since: rename.CreatedAt}, want: []int{rename.ID, reset.ID}},
+				{name: "Until Is Exclusive", filter: models.AuditFilter{Until: rename.CreatedAt}, want: []int{scoreEdit.ID}},
+				{name: "Range", filter: models.AuditFilter{Since: scoreEdit.CreatedAt.Add(time.Microsecond), Until: reset.CreatedAt}, want: []int{rename.ID}},
+			}
+			for _, tt := range tests {
+				got := ids(list(tt.filter))
+				if len(got) != len(tt.want) {
+					t.Errorf("%s: got IDs %v, want %v", tt.name, got, tt.want)
+					continue
+				}
+				for i := range got {
+					if got[i] != tt.want[i] {
+						t.Errorf("%s: got IDs %v, want %v", tt.name, got, tt.want)
+						break
+					}
+				}
+			}
+
+			stored := list(models.AuditFilter{EntityType: models.AuditEntityMatch})[0]
+			if stored.Actor != "alice" || stored.Reason != "VAR review" || string(stored.OldValue) != `{"home_goals":1,"away_goals":0}` ||
+				string(stored.NewValue) != `{"home_goals":2,"away_goals":2}` || !stored.CreatedAt.Equal(scoreEdit.CreatedAt) {
+				t.Errorf("stored entry = %+v, want the recorded values %+v", stored, scoreEdit)
+			}
+			if stored := list(models.AuditFilter{EntityType: models.AuditEntityLeague})[0]; stored.OldValue != nil || stored.NewValue != nil {
+				t.Errorf("league reset values = %s, %s; want none", stored.OldValue, stored.NewValue)
+			}
+
+			if got, err := auditService.GetByID(ctx, rename.ID); err != nil || got.Action != models.AuditActionNameChange || string(got.NewValue) != `"Gunners"` {
+				t.Errorf("GetByID(%d) = %+v, %v; want the rename", rename.ID, got, err)
+			}
+			_, err := auditService.GetByID(ctx, 9999)
+			assertNotFound(t, "GetByID", err)
+
+			if _, err := auditService.Record(ctx, models.AuditEntry{EntityType: models.AuditEntityTeam, Action: models.AuditActionNameChange}); err == nil {
+				t.Error("Record without an actor succeeded, want an error")
+			}
+			if _, err := auditService.Record(ctx, models.AuditEntry{EntityType: models.AuditEntityTeam, Action: models.AuditActionNameChange, Actor: "bob", NewValue: json.RawMessage(`{`)}); err == nil {
+				t.Error("Record with invalid JSON succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"fmt"
+)
+
+// seededPairings pairs the best remaining seed with the worst, so group winners face runners-up
+// from another group. The higher seed plays at home.
+func seededPairings(seeds []int) [][2]int {
+	var pairings [][2]int
+	for i := 0; i < len(seeds)/2; i++ {
+		pairings = append(pairings, [2]int{seeds[i], seeds[len(seeds)-1-i]})
+	}
+	return pairings
+}
+
+// buildKnockoutRound creates the matches of one knockout round starting at week.
+// Each pairing lists the higher seed first. A single match is hosted by the higher seed;
+// over two legs the lower seed hosts the first leg and the higher seed the second, one week later.
+func buildKnockoutRound(pairings [][2]int, stage string, round, week int, twoLegged bool) []models.Match {
+	var matches []models.Match
+	for _, pair := range pairings {
+		if !twoLegged {
+			matches = append(matches, models.Match{Week: week, HomeTeamID: pair[0], AwayTeamID: pair[1], Stage: stage, Round: round})
+			continue
+		}
+		matches = append(matches,
+			models.Match{Week: week, HomeTeamID: pair[1], AwayTeamID: pair[0], Stage: stage, Round: round, Leg: 1},
+			models.Match{Week: week + 1, HomeTeamID: pair[0], AwayTeamID: pair[1], Stage: stage, Round: round, Leg: 2},
+		)
+	}
+	return matches
+}
+
+// knockoutWinner returns the team that advances from a played knockout match.
+// Matches are normally settled by extra time or penalties; if no winner was recorded,
+// a level match goes to the home side, which is always the higher seed.
+func knockoutWinner(match models.Match) int {
+	if match.WinnerTeamID != nil {
+		return *match.WinnerTeamID
+	}
+	if match.HomeGoals != nil && match.AwayGoals != nil && *match.AwayGoals > *match.HomeGoals {
+		return match.AwayTeamID
+	}
+	return match.HomeTeamID
+}
+
+// tieWinners returns the teams that advance from one knockout round, in bracket order.
+// First legs are skipped because a two-legged tie is decided in its second leg.
+func tieWinners(roundMatches []models.Match) ([]int, error) {
+	var winners []int
+	for _, match := range roundMatches {
+		if !match.IsPlayed {
+			return nil, fmt.Errorf("%w: round %d has unplayed matches (match ID %d)", abstracts.ErrStageNotFinished, match.Round, match.ID)
+		}
+		if match.Leg == 1 {
+			continue
+		}
+		winners = append(winners, knockoutWinner(match))
+	}
+	return winners, nil
+}
+
+// findFirstLeg returns the first leg of the tie that secondLeg belongs to.
+func findFirstLeg(matches []models.Match, secondLeg models.Match) (models.Match, bool) {
+	for _, match := range matches {
+		if match.Leg == 1 && match.Stage == secondLeg.Stage && match.Round == secondLeg.Round &&
+			match.HomeTeamID == secondLeg.AwayTeamID && match.AwayTeamID == secondLeg.HomeTeamID {
+			return match, true
+		}
+	}
+	return models.Match{}, false
+}
+
+// findSecondLeg returns the second leg of the tie that firstLeg belongs to.
+func findSecondLeg(matches []models.Match, firstLeg models.Match) (models.Match, bool) {
+	for _, match := range matches {
+		if match.Leg == 2 && match.Stage == firstLeg.Stage && match.Round == firstLeg.Round &&
+			match.HomeTeamID == firstLeg.AwayTeamID && match.AwayTeamID == firstLeg.HomeTeamID {
+			return match, true
+		}
+	}
+	return models.Match{}, false
+}
@@ -0,0 +1,65 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/models"
+	"reflect"
+	"testing"
+)
+
+// TestBuildKnockoutRound checks home advantage and weeks for single matches and two-legged ties.
+func TestBuildKnockoutRound(t *testing.T) {
+	pairings := [][2]int{{1, 4}, {2, 3}}
+
+	single := buildKnockoutRound(pairings, models.StagePlayoff, 2, 10, false)
+	expectedSingle := []models.Match{
+		{Week: 10, HomeTeamID: 1, AwayTeamID: 4, Stage: models.StagePlayoff, Round: 2},
+		{Week: 10, HomeTeamID: 2, AwayTeamID: 3, Stage: models.StagePlayoff, Round: 2},
+	}
+	if !reflect.DeepEqual(single, expectedSingle) {
+		t.Errorf("Incorrect single-leg round:\nExpected: %+v\nGot:      %+v", expectedSingle, single)
+	}
+
+	twoLegged := buildKnockoutRound(pairings, models.StagePlayoff, 1, 10, true)
+	expectedTwoLegged := []models.Match{
+		{Week: 10, HomeTeamID: 4, AwayTeamID: 1, Stage: models.StagePlayoff, Round: 1, Leg: 1},
+		{Week: 11, HomeTeamID: 1, AwayTeamID: 4, Stage: models.StagePlayoff, Round: 1, Leg: 2},
+		{Week: 10, HomeTeamID: 3, AwayTeamID: 2, Stage: models.StagePlayoff, Round: 1, Leg: 1},
+		{Week: 11, HomeTeamID: 2, AwayTeamID: 3, Stage: models.StagePlayoff, Round: 1, Leg: 2},
+	}
+	if !reflect.DeepEqual(twoLegged, expectedTwoLegged) {
+		t.Errorf("Incorrect two-legged round:\nExpected: %+v\nGot:      %+v", expectedTwoLegged, twoLegged)
+	}
+
+	firstLeg, found := findFirstLeg(twoLegged, twoLegged[3])
+	if !found || firstLeg.HomeTeamID != 3 || firstLeg.Leg != 1 {
+		t.Errorf("Expected the first leg hosted by team 3, got %+v (found: %v)", firstLeg, found)
+	}
+	secondLeg, found := findSecondLeg(twoLegged, twoLegged[0])
+	if !found || secondLeg.HomeTeamID != 1 || secondLeg.Leg != 2 {
+		t.Errorf("Expected the second leg hosted by team 1, got %+v (found: %v)", secondLeg, found)
+	}
+}
+
+// TestTieWinners checks that winners come from single matches and second legs only.
+func TestTieWinners(t *testing.T) {
+	winner := func(id int) *int { return &id }
+	goals := func(g int) *int { return &g }
+
+	roundMatches := []models.Match{
+		{ID: 1, HomeTeamID: 4, AwayTeamID: 1, HomeGoals: goals(3), AwayGoals: goals(0), IsPlayed: true, Leg: 1},
+		{ID: 2, HomeTeamID: 1, AwayTeamID: 4, HomeGoals: goals(1), AwayGoals: goals(0), IsPlayed: true, Leg: 2, WinnerTeamID: winner(4)},
+		{ID: 3, HomeTeamID: 5, AwayTeamID: 6, HomeGoals: goals(0), AwayGoals: goals(2), IsPlayed: true},
+	}
+	winners, err := tieWinners(roundMatches)
+	if err != nil {
+		t.Fatalf("Did not expect an error but got: %v", err)
+	}
+	if !reflect.DeepEqual(winners, []int{4, 6}) {
+		t.Errorf("Expected winners [4 6], got %v", winners)
+	}
+
+	roundMatches[2].IsPlayed = false
+	if _, err := tieWinners(roundMatches); err == nil {
+		t.Error("Expected an error for an unfinished round, got nil")
+	}
+}
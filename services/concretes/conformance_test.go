@@ -0,0 +1,1114 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/database"
+	"MatchSimulator_Insider/migrations"
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// testDatabaseURLEnv names the environment variable with a connection string for a disposable PostgreSQL
+// database. When it is set the conformance suite also runs against Postgres; every test empties the tables.
+const testDatabaseURLEnv = "MATCHSIM_TEST_DATABASE_URL"
+
+// storageBackend opens a fresh, empty team and match store for one test.
+// A new backend only needs an entry in storageBackends to be held to the same expectations.
+type storageBackend struct {
+	name string
+	open func(t *testing.T) (abstracts.TeamService, abstracts.IMatchService)
+}
+
+// storageBackends lists every storage implementation the conformance suite runs against.
+func storageBackends() []storageBackend {
+	return []storageBackend{
+		{
+			name: "Memory",
+			open: func(t *testing.T) (abstracts.TeamService, abstracts.IMatchService) {
+				return NewMemoryTeamService(), NewMemoryMatchService()
+			},
+		},
+		{
+			name: "SQLite",
+			open: func(t *testing.T) (abstracts.TeamService, abstracts.IMatchService) {
+				db := newSQLiteTestDB(t)
+				return NewSQLiteTeamService(db), NewSQLiteMatchService(db)
+			},
+		},
+		{
+			name: "Postgres",
+			open: func(t *testing.T) (abstracts.TeamService, abstracts.IMatchService) {
+				pool := newPostgresTestPool(t)
+				return NewPostgresTeamService(pool), NewPostgresMatchService(pool)
+			},
+		},
+	}
+}
+
+// newPostgresTestPool connects to the database in MATCHSIM_TEST_DATABASE_URL, migrates it and empties
+// the tables. The test is skipped when the variable is not set.
+func newPostgresTestPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	connStr := os.Getenv(testDatabaseURLEnv)
+	if connStr == "" {
+		t.Skipf("%s is not set", testDatabaseURLEnv)
+	}
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, connStr)
+	if err != nil {
+		t.Fatalf("Could not connect to the test database: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("Could not acquire a connection: %v", err)
+	}
+	defer conn.Release()
+	migrator, err := migrations.NewMigrator(conn.Conn())
+	if err != nil {
+		t.Fatalf("Could not load migrations: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Could not migrate the test database: %v", err)
+	}
+	if _, err := conn.Exec(ctx, `TRUNCATE season_archive, audit_log, points_adjustments, matches, teams RESTART IDENTITY CASCADE`); err != nil {
+		t.Fatalf("Could not empty the test database: %v", err)
+	}
+	return pool
+}
+
+// newSQLiteTestDB opens a migrated in-memory SQLite database that is closed when the test ends.
+func newSQLiteTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+	if err != nil {
+		t.Fatalf("Could not open SQLite: %v", err)
+	}
+	db.SetMaxOpenConns(1) // :memory: veritabanı bağlantıya özeldir
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := migrations.NewSQLiteMigrator(db)
+	if err != nil {
+		t.Fatalf("Could not load SQLite migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Could not migrate SQLite: %v", err)
+	}
+	return db
+}
+
+// forEachBackend runs fn as a subtest against a fresh store of every backend.
+func forEachBackend(t *testing.T, fn func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService)) {
+	for _, backend := range storageBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			teamService, matchService := backend.open(t)
+			fn(t, teamService, matchService)
+		})
+	}
+}
+
+// createTeams adds the teams and returns their IDs in the same order.
+func createTeams(t *testing.T, teamService abstracts.TeamService, teams ...models.Team) []int {
+	t.Helper()
+	ids := make([]int, len(teams))
+	for i, team := range teams {
+		id, err := teamService.CreateTeam(context.Background(), team)
+		if err != nil {
+			t.Fatalf("Could not create team %s: %v", team.Name, err)
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+// assertNotFound checks that a lookup of a missing record failed with one of the not-found sentinels,
+// which the API turns into 404 regardless of the backend.
+func assertNotFound(t *testing.T, operation string, err error) {
+	t.Helper()
+	if !errors.Is(err, abstracts.ErrTeamNotFound) && !errors.Is(err, abstracts.ErrMatchNotFound) && !errors.Is(err, abstracts.ErrAuditEntryNotFound) {
+		t.Errorf("%s: expected a not-found error, got: %v", operation, err)
+	}
+}
+
+// mustGetTeam returns the team or fails the test.
+func mustGetTeam(t *testing.T, teamService abstracts.TeamService, id int) models.Team {
+	t.Helper()
+	team, err := teamService.GetTeamByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Could not retrieve team %d: %v", id, err)
+	}
+	return *team
+}
+
+// teamStats returns the team without its version. Backends bump the version a different number of times
+// for the same change, so value comparisons leave it out.
+func teamStats(t *testing.T, teamService abstracts.TeamService, id int) models.Team {
+	t.Helper()
+	team := mustGetTeam(t, teamService, id)
+	team.Version = 0
+	return team
+}
+
+// mustGetMatch returns the match or fails the test.
+func mustGetMatch(t *testing.T, matchService abstracts.IMatchService, id int) models.Match {
+	t.Helper()
+	match, err := matchService.GetMatchByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Could not retrieve match %d: %v", id, err)
+	}
+	return *match
+}
+
+// TestTeamServiceConformance checks that every TeamService implementation behaves the same.
+func TestTeamServiceConformance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Create And Duplicate Names", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, _ abstracts.IMatchService) {
+			ids := createTeams(t, teamService, models.Team{Name: "Chelsea", Strength: 85}, models.Team{Name: "Arsenal", Strength: 82, Division: 2})
+			if ids[0] < 1 || ids[0] == ids[1] {
+				t.Fatalf("Expected distinct positive IDs, got %v", ids)
+			}
+			again, err := teamService.CreateTeam(ctx, models.Team{Name: "Chelsea", Strength: 10})
+			if err != nil || again != ids[0] {
+				t.Errorf("Expected creating an existing name to return ID %d, got %d (err: %v)", ids[0], again, err)
+			}
+			expected := models.Team{ID: ids[0], Name: "Chelsea", Strength: 85, Division: 1}
+			if got := teamStats(t, teamService, ids[0]); !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, got)
+			}
+			if got := mustGetTeam(t, teamService, ids[1]); got.Division != 2 {
+				t.Errorf("Expected Arsenal in division 2, got %d", got.Division)
+			}
+		})
+	})
+
+	t.Run("Table Ordering", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, _ abstracts.IMatchService) {
+			ids := createTeams(t, teamService,
+				models.Team{Name: "A", Strength: 50}, models.Team{Name: "B", Strength: 50}, models.Team{Name: "C", Strength: 50},
+				models.Team{Name: "D", Strength: 50}, models.Team{Name: "Zeta", Strength: 50}, models.Team{Name: "Eta", Strength: 50},
+			)
+			results := map[int][2]int{ids[0]: {1, 0}, ids[1]: {3, 2}, ids[2]: {2, 0}, ids[3]: {0, 0}}
+			for id, score := range results {
+				if err := teamService.UpdateTeamStatsAfterMatch(ctx, id, score[0], score[1]); err != nil {
+					t.Fatalf("Did not expect an error but got: %v", err)
+				}
+			}
+			table, err := teamService.GetAllTeams(ctx)
+			if err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			var names []string
+			for _, team := range table {
+				names = append(names, team.Name)
+			}
+			// Puan, averaj, atılan gol ve isim sırası
+			expected := []string{"C", "B", "A", "D", "Eta", "Zeta"}
+			if !reflect.DeepEqual(names, expected) {
+				t.Errorf("Expected table order %v, got %v", expected, names)
+			}
+		})
+	})
+
+	t.Run("Stats Accumulate", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, _ abstracts.IMatchService) {
+			id := createTeams(t, teamService, models.Team{Name: "Chelsea", Strength: 85})[0]
+			for _, score := range [][2]int{{3, 1}, {2, 2}, {0, 1}, {4, 0}} {
+				if err := teamService.UpdateTeamStatsAfterMatch(ctx, id, score[0], score[1]); err != nil {
+					t.Fatalf("Did not expect an error but got: %v", err)
+				}
+			}
+			expected := models.Team{ID: id, Name: "Chelsea", Strength: 85, Division: 1,
+				Played: 4, Wins: 2, Draws: 1, Losses: 1, GoalsFor: 9, GoalsAgainst: 4, GoalDifference: 5, Points: 7}
+			if got := teamStats(t, teamService, id); !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, got)
+			}
+		})
+	})
+
+	t.Run("Score Changes", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, _ abstracts.IMatchService) {
+			id := createTeams(t, teamService, models.Team{Name: "Chelsea", Strength: 85})[0]
+			if err := teamService.UpdateTeamStatsAfterMatch(ctx, id, 1, 0); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			before := teamStats(t, teamService, id)
+
+			// Skor değişikliği oynanan maç sayısını değiştirmez
+			if err := teamService.AdjustTeamStatsForScoreChange(ctx, id, 1, 0, 0, 2); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			expected := models.Team{ID: id, Name: "Chelsea", Strength: 85, Division: 1, Played: 1, Losses: 1, GoalsAgainst: 2, GoalDifference: -2}
+			if got := teamStats(t, teamService, id); !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, got)
+			}
+			if err := teamService.AdjustTeamStatsForScoreChange(ctx, id, 0, 2, 3, 3); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			expected = models.Team{ID: id, Name: "Chelsea", Strength: 85, Division: 1, Played: 1, Draws: 1, GoalsFor: 3, GoalsAgainst: 3, Points: 1}
+			if got := teamStats(t, teamService, id); !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, got)
+			}
+
+			// Orijinal skora dönmek başlangıç istatistiklerini geri getirir
+			if err := teamService.AdjustTeamStatsForScoreChange(ctx, id, 3, 3, 1, 0); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if got := teamStats(t, teamService, id); !reflect.DeepEqual(got, before) {
+				t.Errorf("Expected reverting the score to restore %+v, got %+v", before, got)
+			}
+		})
+	})
+
+	t.Run("Updates And Validation", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, _ abstracts.IMatchService) {
+			ids := createTeams(t, teamService, models.Team{Name: "Chelsea", Strength: 85}, models.Team{Name: "Arsenal", Strength: 82})
+			if err := teamService.UpdateTeamStrength(ctx, ids[0], 0, 0); !errors.Is(err, abstracts.ErrInvalidStrength) {
+				t.Errorf("Expected ErrInvalidStrength for strength 0, got %v", err)
+			}
+			if err := teamService.UpdateTeamName(ctx, ids[0], "   ", 0); !errors.Is(err, abstracts.ErrInvalidInput) {
+				t.Errorf("Expected ErrInvalidInput for an empty name, got %v", err)
+			}
+			if err := teamService.UpdateTeamName(ctx, ids[0], strings.Repeat("ş", maxTeamNameLength+1), 0); !errors.Is(err, abstracts.ErrInvalidInput) {
+				t.Errorf("Expected ErrInvalidInput for a name longer than %d characters, got %v", maxTeamNameLength, err)
+			}
+			// The limit counts characters like the teams.name column, not the bytes of multi-byte letters.
+			if err := teamService.UpdateTeamName(ctx, ids[1], strings.Repeat("ş", maxTeamNameLength), 0); err != nil {
+				t.Errorf("Did not expect an error for a %d-character name but got: %v", maxTeamNameLength, err)
+			}
+			if err := teamService.UpdateTeamName(ctx, ids[1], "Chelsea", 0); !errors.Is(err, abstracts.ErrDuplicateName) {
+				t.Errorf("Expected ErrDuplicateName renaming to a name in use, got %v", err)
+			}
+			if err := teamService.UpdateTeamDivision(ctx, ids[0], 0, 0); !errors.Is(err, abstracts.ErrInvalidInput) {
+				t.Errorf("Expected ErrInvalidInput for division 0, got %v", err)
+			}
+			if err := teamService.UpdateTeamName(ctx, ids[0], " Chelsea FC ", 0); err != nil {
+				t.Errorf("Did not expect an error but got: %v", err)
+			}
+			if err := teamService.UpdateTeamStrength(ctx, ids[0], 99, 0); err != nil {
+				t.Errorf("Did not expect an error but got: %v", err)
+			}
+			if err := teamService.UpdateTeamDivision(ctx, ids[0], 3, 0); err != nil {
+				t.Errorf("Did not expect an error but got: %v", err)
+			}
+			got := mustGetTeam(t, teamService, ids[0])
+			if got.Name != "Chelsea FC" || got.Strength != 99 || got.Division != 3 {
+				t.Errorf("Expected Chelsea FC with strength 99 in division 3, got %+v", got)
+			}
+		})
+	})
+
+	t.Run("Versions And Conditional Updates", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, _ abstracts.IMatchService) {
+			ids := createTeams(t, teamService, models.Team{Name: "Chelsea", Strength: 85})
+			created := mustGetTeam(t, teamService, ids[0])
+			if created.Version != 1 {
+				t.Fatalf("Expected a new team to start at version 1, got %d", created.Version)
+			}
+
+			if err := teamService.UpdateTeamStrength(ctx, ids[0], 90, created.Version); err != nil {
+				t.Fatalf("Expected the update with the current version to succeed, got %v", err)
+			}
+			afterStrength := mustGetTeam(t, teamService, ids[0])
+			if afterStrength.Version <= created.Version {
+				t.Errorf("Expected the version to advance past %d, got %d", created.Version, afterStrength.Version)
+			}
+
+			// Eski sürümle yapılan güncellemeler reddedilir ve takımı değiştirmez
+			stale := []struct {
+				name string
+				err  error
+			}{
+				{"UpdateTeamStrength", teamService.UpdateTeamStrength(ctx, ids[0], 50, created.Version)},
+				{"UpdateTeamName", teamService.UpdateTeamName(ctx, ids[0], "Stale", created.Version)},
+				{"UpdateTeamDivision", teamService.UpdateTeamDivision(ctx, ids[0], 2, created.Version)},
+			}
+			for _, update := range stale {
+				if !errors.Is(update.err, abstracts.ErrVersionConflict) {
+					t.Errorf("%s with a stale version: expected ErrVersionConflict, got %v", update.name, update.err)
+				}
+			}
+			if got := mustGetTeam(t, teamService, ids[0]); got != afterStrength {
+				t.Errorf("Expected rejected updates to leave %+v unchanged, got %+v", afterStrength, got)
+			}
+
+			// Sürüm verilmezse (0) kontrol yapılmaz; maç istatistikleri de sürümü ilerletir
+			if err := teamService.UpdateTeamName(ctx, ids[0], "Chelsea FC", 0); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if err := teamService.UpdateTeamStatsAfterMatch(ctx, ids[0], 1, 0); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if got := mustGetTeam(t, teamService, ids[0]); got.Version <= afterStrength.Version+1 {
+				t.Errorf("Expected the rename and the result to advance the version past %d, got %d", afterStrength.Version+1, got.Version)
+			}
+			assertNotFound(t, "UpdateTeamStrength with a version", teamService.UpdateTeamStrength(ctx, 9999, 50, 1))
+		})
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, _ abstracts.IMatchService) {
+			const missingID = 9999
+			_, err := teamService.GetTeamByID(ctx, missingID)
+			assertNotFound(t, "GetTeamByID", err)
+			assertNotFound(t, "UpdateTeamStatsAfterMatch", teamService.UpdateTeamStatsAfterMatch(ctx, missingID, 1, 0))
+			assertNotFound(t, "RevertTeamStatsAfterMatch", teamService.RevertTeamStatsAfterMatch(ctx, missingID, 1, 0))
+			assertNotFound(t, "AdjustTeamStatsForScoreChange", teamService.AdjustTeamStatsForScoreChange(ctx, missingID, 1, 0, 0, 1))
+			assertNotFound(t, "UpdateTeamStrength", teamService.UpdateTeamStrength(ctx, missingID, 50, 0))
+			assertNotFound(t, "UpdateTeamName", teamService.UpdateTeamName(ctx, missingID, "Nobody", 0))
+			assertNotFound(t, "UpdateTeamDivision", teamService.UpdateTeamDivision(ctx, missingID, 2, 0))
+			assertNotFound(t, "DeleteTeam", teamService.DeleteTeam(ctx, missingID))
+			_, err = teamService.ApplyPointsAdjustment(ctx, missingID, -3, "Breach")
+			assertNotFound(t, "ApplyPointsAdjustment", err)
+			if adjustments, err := teamService.GetPointsAdjustments(ctx, missingID); err != nil || len(adjustments) != 0 {
+				t.Errorf("GetPointsAdjustments: expected no adjustments and no error, got %v (err: %v)", adjustments, err)
+			}
+			if teams, err := teamService.GetAllTeams(ctx); err != nil || len(teams) != 0 {
+				t.Errorf("GetAllTeams: expected an empty table and no error, got %v (err: %v)", teams, err)
+			}
+		})
+	})
+
+	t.Run("Points Adjustments And Reset", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, _ abstracts.IMatchService) {
+			ids := createTeams(t, teamService, models.Team{Name: "Chelsea", Strength: 85}, models.Team{Name: "Arsenal", Strength: 82})
+			_ = teamService.UpdateTeamStatsAfterMatch(ctx, ids[1], 2, 0)
+			_ = teamService.UpdateTeamStatsAfterMatch(ctx, ids[0], 0, 2)
+
+			if _, err := teamService.ApplyPointsAdjustment(ctx, ids[1], 0, "Nothing"); !errors.Is(err, abstracts.ErrInvalidInput) {
+				t.Errorf("Expected ErrInvalidInput for a zero adjustment, got %v", err)
+			}
+			first, err := teamService.ApplyPointsAdjustment(ctx, ids[1], -5, " Administration ")
+			if err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if first.ID < 1 || first.TeamID != ids[1] || first.Reason != "Administration" || first.CreatedAt.IsZero() {
+				t.Errorf("Unexpected adjustment record: %+v", first)
+			}
+			if _, err := teamService.ApplyPointsAdjustment(ctx, ids[0], 1, "Appeal"); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+
+			arsenal := mustGetTeam(t, teamService, ids[1])
+			if arsenal.Points != -2 || arsenal.Adjustment != -5 {
+				t.Errorf("Expected -2 points with a -5 adjustment, got %d points and %d adjustment", arsenal.Points, arsenal.Adjustment)
+			}
+			table, _ := teamService.GetAllTeams(ctx)
+			if table[0].ID != ids[0] {
+				t.Errorf("Expected the deduction to move Chelsea to the top, got %s", table[0].Name)
+			}
+			all, _ := teamService.GetPointsAdjustments(ctx, 0)
+			forArsenal, _ := teamService.GetPointsAdjustments(ctx, ids[1])
+			if len(all) != 2 || all[0].ID != first.ID || len(forArsenal) != 1 {
+				t.Errorf("Expected 2 adjustments in order and 1 for Arsenal, got %v and %v", all, forArsenal)
+			}
+
+			if err := teamService.ResetAllTeamStats(ctx); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			all, _ = teamService.GetPointsAdjustments(ctx, 0)
+			expected := models.Team{ID: ids[1], Name: "Arsenal", Strength: 82, Division: 1}
+			if got := teamStats(t, teamService, ids[1]); !reflect.DeepEqual(got, expected) || len(all) != 0 {
+				t.Errorf("Expected the reset to clear stats and adjustments, got %+v and %d adjustments", got, len(all))
+			}
+		})
+	})
+}
+
+// TestMatchServiceConformance checks that every IMatchService implementation behaves the same.
+func TestMatchServiceConformance(t *testing.T) {
+	ctx := context.Background()
+	fourTeams := []models.Team{
+		{Name: "Chelsea", Strength: 85}, {Name: "Arsenal", Strength: 82},
+		{Name: "Manchester City", Strength: 90}, {Name: "Liverpool", Strength: 88},
+	}
+
+	t.Run("Fixture", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+			createTeams(t, teamService, fourTeams...)
+			teams, _ := teamService.GetAllTeams(ctx)
+			if err := matchService.GenerateAndStoreFixture(ctx, teams[:1]); err == nil {
+				t.Error("Expected an error for a single team, got nil")
+			}
+			if err := matchService.GenerateAndStoreFixture(ctx, teams); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			matches, err := matchService.GetAllMatches(ctx)
+			if err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if len(matches) != 12 {
+				t.Fatalf("Expected 12 matches, got %d", len(matches))
+			}
+			for i, match := range matches {
+				if match.IsPlayed || match.HomeGoals != nil || match.Stage != models.StageLeague {
+					t.Errorf("Expected an unplayed league match, got %+v", match)
+				}
+				if i > 0 && (match.Week < matches[i-1].Week || (match.Week == matches[i-1].Week && match.ID <= matches[i-1].ID)) {
+					t.Errorf("Expected matches ordered by week and ID, got %+v after %+v", match, matches[i-1])
+				}
+			}
+			weekOne, _ := matchService.GetMatchesByWeek(ctx, 1)
+			if len(weekOne) != 2 {
+				t.Errorf("Expected 2 matches in week 1, got %d", len(weekOne))
+			}
+
+			// Yeni fikstür eskisinin yerini alır
+			if err := matchService.GenerateAndStoreFixture(ctx, teams); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if again, _ := matchService.GetAllMatches(ctx); len(again) != 12 {
+				t.Errorf("Expected the new fixture to replace the old one, got %d matches", len(again))
+			}
+		})
+	})
+
+	t.Run("Results Edits And Forfeits", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+			ids := createTeams(t, teamService, fourTeams[:2]...)
+			if err := matchService.StoreMatches(ctx, []models.Match{{Week: 1, HomeTeamID: ids[0], AwayTeamID: ids[1]}}, true); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			matches, _ := matchService.GetAllMatches(ctx)
+			matchID := matches[0].ID
+
+			if err := matchService.UpdateMatchResult(ctx, matchID, 2, 1, true); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if err := matchService.SetMatchForfeit(ctx, matchID, true); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if got := mustGetMatch(t, matchService, matchID); !got.IsPlayed || !got.IsForfeit || *got.HomeGoals != 2 || *got.AwayGoals != 1 {
+				t.Errorf("Expected a played 2-1 forfeit, got %+v", got)
+			}
+
+			original, err := matchService.EditMatchScore(ctx, matchID, 0, 0, 0)
+			if err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if *original.HomeGoals != 2 || *original.AwayGoals != 1 || !original.IsForfeit {
+				t.Errorf("Expected the original 2-1 forfeit to be returned, got %+v", original)
+			}
+			if got := mustGetMatch(t, matchService, matchID); got.IsForfeit || *got.HomeGoals != 0 || *got.AwayGoals != 0 {
+				t.Errorf("Expected a 0-0 result with the forfeit cleared, got %+v", got)
+			}
+		})
+	})
+
+	t.Run("Knockout Matches", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+			ids := createTeams(t, teamService, fourTeams...)
+			league := []models.Match{{Week: 1, HomeTeamID: ids[0], AwayTeamID: ids[1]}}
+			knockout := []models.Match{{Week: 2, HomeTeamID: ids[2], AwayTeamID: ids[3], Stage: models.StageKnockout, GroupName: "A", Round: 2, Leg: 1}}
+			if err := matchService.StoreMatches(ctx, league, true); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if err := matchService.StoreMatches(ctx, knockout, false); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			matches, _ := matchService.GetAllMatches(ctx)
+			if len(matches) != 2 {
+				t.Fatalf("Expected appended matches to keep the existing ones, got %d matches", len(matches))
+			}
+			tie := matches[1]
+			if tie.Stage != models.StageKnockout || tie.GroupName != "A" || tie.Round != 2 || tie.Leg != 1 || tie.WinnerTeamID != nil {
+				t.Errorf("Expected the stored knockout details, got %+v", tie)
+			}
+
+			_ = matchService.UpdateMatchResult(ctx, tie.ID, 1, 1, true)
+			homeET, awayET, homePens, awayPens := 0, 0, 4, 3
+			if err := matchService.UpdateKnockoutResult(ctx, tie.ID, &homeET, &awayET, &homePens, &awayPens, ids[2]); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			got := mustGetMatch(t, matchService, tie.ID)
+			if got.HomeGoalsET == nil || *got.HomeGoalsET != 0 || got.HomePenalties == nil || *got.HomePenalties != 4 || *got.AwayPenalties != 3 ||
+				got.WinnerTeamID == nil || *got.WinnerTeamID != ids[2] {
+				t.Errorf("Expected the shootout result with %d advancing, got %+v", ids[2], got)
+			}
+			if err := matchService.UpdateKnockoutResult(ctx, tie.ID, nil, nil, nil, nil, ids[3]); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if got := mustGetMatch(t, matchService, tie.ID); got.HomeGoalsET != nil || got.HomePenalties != nil || *got.WinnerTeamID != ids[3] {
+				t.Errorf("Expected the deciders to be cleared, got %+v", got)
+			}
+		})
+	})
+
+	t.Run("Division Fixtures", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+			ids := createTeams(t, teamService,
+				models.Team{Name: "A", Strength: 60}, models.Team{Name: "B", Strength: 60},
+				models.Team{Name: "C", Strength: 60, Division: 2}, models.Team{Name: "D", Strength: 60, Division: 2},
+			)
+			teams, _ := teamService.GetAllTeams(ctx)
+			if err := matchService.GenerateAndStoreFixture(ctx, teams); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			division := map[int]int{ids[0]: 1, ids[1]: 1, ids[2]: 2, ids[3]: 2}
+			matches, _ := matchService.GetAllMatches(ctx)
+			if len(matches) != 4 {
+				t.Fatalf("Expected a home and away match in each division, got %d matches", len(matches))
+			}
+			for _, match := range matches {
+				if division[match.HomeTeamID] != division[match.AwayTeamID] {
+					t.Errorf("Expected matches only within a division, got %d vs %d", match.HomeTeamID, match.AwayTeamID)
+				}
+			}
+		})
+	})
+
+	t.Run("Score Edits", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+			ids := createTeams(t, teamService, fourTeams[:2]...)
+			if err := matchService.StoreMatches(ctx, []models.Match{{Week: 3, HomeTeamID: ids[0], AwayTeamID: ids[1]}}, true); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			matches, _ := matchService.GetMatchesByWeek(ctx, 3)
+			matchID := matches[0].ID
+			if matches[0].Stage != models.StageLeague {
+				t.Errorf("Expected an empty stage to be stored as %q, got %q", models.StageLeague, matches[0].Stage)
+			}
+
+			// Oynanmamış bir maçın skoru düzenlendiğinde maç oynanmış sayılır
+			original, err := matchService.EditMatchScore(ctx, matchID, 1, 1, 0)
+			if err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if original.IsPlayed || original.HomeGoals != nil {
+				t.Errorf("Expected the original unplayed match, got %+v", original)
+			}
+			original, err = matchService.EditMatchScore(ctx, matchID, 2, 0, 0)
+			if err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if !original.IsPlayed || *original.HomeGoals != 1 || *original.AwayGoals != 1 {
+				t.Errorf("Expected the previous 1-1 result, got %+v", original)
+			}
+			got := mustGetMatch(t, matchService, matchID)
+			if !got.IsPlayed || *got.HomeGoals != 2 || *got.AwayGoals != 0 || got.Week != 3 || got.HomeTeamID != ids[0] {
+				t.Errorf("Expected a played 2-0 week 3 match, got %+v", got)
+			}
+		})
+	})
+
+	t.Run("Score Edit Versions", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+			ids := createTeams(t, teamService, fourTeams[:2]...)
+			if err := matchService.StoreMatches(ctx, []models.Match{{Week: 1, HomeTeamID: ids[0], AwayTeamID: ids[1]}}, true); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			matches, _ := matchService.GetMatchesByWeek(ctx, 1)
+			stored := matches[0]
+			if stored.Version != 1 {
+				t.Fatalf("Expected a new match to start at version 1, got %d", stored.Version)
+			}
+
+			if _, err := matchService.EditMatchScore(ctx, stored.ID, 1, 0, stored.Version); err != nil {
+				t.Fatalf("Expected the edit with the current version to succeed, got %v", err)
+			}
+			edited := mustGetMatch(t, matchService, stored.ID)
+			if edited.Version <= stored.Version {
+				t.Errorf("Expected the version to advance past %d, got %d", stored.Version, edited.Version)
+			}
+			if _, err := matchService.EditMatchScore(ctx, stored.ID, 5, 5, stored.Version); !errors.Is(err, abstracts.ErrVersionConflict) {
+				t.Errorf("Expected ErrVersionConflict for a stale version, got %v", err)
+			}
+			if got := mustGetMatch(t, matchService, stored.ID); *got.HomeGoals != 1 || *got.AwayGoals != 0 || got.Version != edited.Version {
+				t.Errorf("Expected the rejected edit to leave the 1-0 result at version %d, got %+v", edited.Version, got)
+			}
+			if err := matchService.SetMatchForfeit(ctx, stored.ID, true); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if got := mustGetMatch(t, matchService, stored.ID); got.Version <= edited.Version {
+				t.Errorf("Expected the forfeit flag to advance the version past %d, got %d", edited.Version, got.Version)
+			}
+		})
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		forEachBackend(t, func(t *testing.T, _ abstracts.TeamService, matchService abstracts.IMatchService) {
+			const missingID = 9999
+			_, err := matchService.GetMatchByID(ctx, missingID)
+			assertNotFound(t, "GetMatchByID", err)
+			assertNotFound(t, "UpdateMatchResult", matchService.UpdateMatchResult(ctx, missingID, 1, 0, true))
+			_, err = matchService.EditMatchScore(ctx, missingID, 1, 0, 0)
+			assertNotFound(t, "EditMatchScore", err)
+			assertNotFound(t, "SetMatchForfeit", matchService.SetMatchForfeit(ctx, missingID, true))
+			assertNotFound(t, "ClearMatchResult", matchService.ClearMatchResult(ctx, missingID))
+			assertNotFound(t, "UpdateKnockoutResult", matchService.UpdateKnockoutResult(ctx, missingID, nil, nil, nil, nil, 1))
+			if matches, err := matchService.GetMatchesByWeek(ctx, 1); err != nil || len(matches) != 0 {
+				t.Errorf("GetMatchesByWeek: expected no matches and no error, got %v (err: %v)", matches, err)
+			}
+			if matches, err := matchService.GetAllMatches(ctx); err != nil || len(matches) != 0 {
+				t.Errorf("GetAllMatches: expected no matches and no error, got %v (err: %v)", matches, err)
+			}
+		})
+	})
+}
+
+// assertTableMatchesResults rebuilds every team's statistics from the played league matches
+// and compares them with the table held by the team service.
+func assertTableMatchesResults(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+	t.Helper()
+	ctx := context.Background()
+	teams, err := teamService.GetAllTeams(ctx)
+	if err != nil {
+		t.Fatalf("Could not retrieve teams: %v", err)
+	}
+	matches, err := matchService.GetAllMatches(ctx)
+	if err != nil {
+		t.Fatalf("Could not retrieve matches: %v", err)
+	}
+
+	expected := make(map[int]*models.Team)
+	for _, team := range teams {
+		expected[team.ID] = &models.Team{ID: team.ID, Name: team.Name, Strength: team.Strength, Division: team.Division, Adjustment: team.Adjustment, Points: team.Adjustment, Version: team.Version}
+	}
+	for _, match := range matches {
+		if !match.IsPlayed || !countsTowardsStandings(match) {
+			continue
+		}
+		updateTeamStatsInMemory(expected[match.HomeTeamID], *match.HomeGoals, *match.AwayGoals)
+		updateTeamStatsInMemory(expected[match.AwayTeamID], *match.AwayGoals, *match.HomeGoals)
+	}
+	for _, team := range teams {
+		if !reflect.DeepEqual(team, *expected[team.ID]) {
+			t.Errorf("Team %s does not match its results:\nExpected: %+v\nGot:      %+v", team.Name, *expected[team.ID], team)
+		}
+	}
+}
+
+// TestLeagueService_Season plays a full season on every backend, then edits a score, awards a forfeit
+// and applies a deduction, checking the table against the match results after each step.
+func TestLeagueService_Season(t *testing.T) {
+	ctx := context.Background()
+	forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+		createTeams(t, teamService,
+			models.Team{Name: "Chelsea", Strength: 85}, models.Team{Name: "Arsenal", Strength: 82},
+			models.Team{Name: "Manchester City", Strength: 90}, models.Team{Name: "Liverpool", Strength: 88},
+		)
+		leagueService := NewLeagueService(teamService, matchService, nil)
+		if err := leagueService.ResetLeague(ctx); err != nil {
+			t.Fatalf("Could not start the league: %v", err)
+		}
+
+		if _, _, err := leagueService.PlayAllRemainingWeeks(ctx); err != nil {
+			t.Fatalf("Did not expect an error but got: %v", err)
+		}
+		if week, err := leagueService.GetCurrentWeek(ctx); err != nil || week != -1 {
+			t.Fatalf("Expected the season to be finished (week -1), got %d (err: %v)", week, err)
+		}
+		table, _ := leagueService.GetLeagueTable(ctx)
+		for _, team := range table {
+			if team.Played != 6 {
+				t.Errorf("Expected %s to have played 6 matches, got %d", team.Name, team.Played)
+			}
+		}
+		assertTableMatchesResults(t, teamService, matchService)
+
+		matches, _ := matchService.GetAllMatches(ctx)
+		if err := leagueService.HandleMatchScoreEdit(ctx, matches[0].ID, 5, 0, 0); err != nil {
+			t.Fatalf("Did not expect an error editing a score but got: %v", err)
+		}
+		assertTableMatchesResults(t, teamService, matchService)
+
+		if err := leagueService.AwardForfeit(ctx, matches[1].ID, matches[1].AwayTeamID); err != nil {
+			t.Fatalf("Did not expect an error awarding a forfeit but got: %v", err)
+		}
+		forfeited := mustGetMatch(t, matchService, matches[1].ID)
+		if !forfeited.IsForfeit || *forfeited.HomeGoals != 0 || *forfeited.AwayGoals != forfeitGoals {
+			t.Errorf("Expected a 0-%d forfeit, got %d-%d (forfeit: %v)", forfeitGoals, *forfeited.HomeGoals, *forfeited.AwayGoals, forfeited.IsForfeit)
+		}
+		assertTableMatchesResults(t, teamService, matchService)
+
+		table, _ = leagueService.GetLeagueTable(ctx)
+		if _, err := teamService.ApplyPointsAdjustment(ctx, table[0].ID, -20, "Financial breach"); err != nil {
+			t.Fatalf("Did not expect an error applying a deduction but got: %v", err)
+		}
+		assertTableMatchesResults(t, teamService, matchService)
+		adjustedTable, _ := leagueService.GetLeagueTable(ctx)
+		if adjustedTable[len(adjustedTable)-1].ID != table[0].ID {
+			t.Errorf("Expected a 20 point deduction to drop %s to the bottom of the table", table[0].Name)
+		}
+	})
+}
+
+// TestPyramidService_EndSeasonArchivesPlayoffs closes a season with a promotion playoff on every backend and checks
+// that the playoff matches are kept in the season archive after the next season's fixture replaces them.
+func TestPyramidService_EndSeasonArchivesPlayoffs(t *testing.T) {
+	ctx := context.Background()
+	forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+		createTeams(t, teamService,
+			models.Team{Name: "A", Strength: 80}, models.Team{Name: "B", Strength: 70},
+			models.Team{Name: "C", Strength: 60, Division: 2}, models.Team{Name: "D", Strength: 50, Division: 2},
+		)
+		leagueService := NewLeagueService(teamService, matchService, nil)
+		pyramidService := NewPyramidService(teamService, matchService, leagueService, models.PyramidRules{PromotionSpots: 1, Playoffs: true, PlayoffTeams: 2})
+		if err := leagueService.ResetLeague(ctx); err != nil {
+			t.Fatalf("Could not start the league: %v", err)
+		}
+		if _, _, err := leagueService.PlayAllRemainingWeeks(ctx); err != nil {
+			t.Fatalf("Could not play the season: %v", err)
+		}
+		played, err := pyramidService.PlayPlayoffs(ctx)
+		if err != nil || len(played) != 1 {
+			t.Fatalf("Expected a one-match playoff final, got %d matches (err: %v)", len(played), err)
+		}
+
+		if _, err := pyramidService.EndSeason(ctx); err != nil {
+			t.Fatalf("Did not expect an error ending the season but got: %v", err)
+		}
+		if current, _ := pyramidService.GetPlayoffMatches(ctx); len(current) != 0 {
+			t.Errorf("Expected the new season to start without playoff matches, got %d", len(current))
+		}
+		archive, err := pyramidService.GetSeasonArchive(ctx, 1)
+		if err != nil {
+			t.Fatalf("Did not expect an error reading season 1 but got: %v", err)
+		}
+		latest, err := pyramidService.GetSeasonArchive(ctx, 0)
+		if err != nil || latest.Season != 1 {
+			t.Errorf("Expected season 1 to be the latest archived season, got %+v (err: %v)", latest, err)
+		}
+		if len(archive.PlayoffMatches) != 1 {
+			t.Fatalf("Expected the playoff final to survive EndSeason, got %d archived matches", len(archive.PlayoffMatches))
+		}
+		final := archive.PlayoffMatches[0]
+		if final.ID != played[0].ID || !final.IsPlayed || final.WinnerTeamID == nil || *final.WinnerTeamID != *played[0].WinnerTeamID ||
+			*final.HomeGoals != *played[0].HomeGoals || *final.AwayGoals != *played[0].AwayGoals {
+			t.Errorf("Expected the archived final %+v to match the played one %+v", final, played[0])
+		}
+		if archive.ClosedAt.IsZero() {
+			t.Error("Expected the archive to record when the season was closed")
+		}
+		if _, err := pyramidService.GetSeasonArchive(ctx, 2); !errors.Is(err, abstracts.ErrSeasonNotFound) {
+			t.Errorf("Expected ErrSeasonNotFound for a season that was not closed yet, got %v", err)
+		}
+	})
+}
+
+// TestLeagueService_Revert plays weeks on every backend and reverts them one by one, checking that the table
+// always matches the remaining results and that the reverted week becomes the current week again.
+func TestLeagueService_Revert(t *testing.T) {
+	ctx := context.Background()
+	forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+		createTeams(t, teamService,
+			models.Team{Name: "Chelsea", Strength: 85}, models.Team{Name: "Arsenal", Strength: 82},
+			models.Team{Name: "Manchester City", Strength: 90}, models.Team{Name: "Liverpool", Strength: 88},
+		)
+		leagueService := NewLeagueService(teamService, matchService, nil)
+		if err := leagueService.ResetLeague(ctx); err != nil {
+			t.Fatalf("Could not start the league: %v", err)
+		}
+		if _, _, err := leagueService.RevertLastPlayedWeek(ctx); !errors.Is(err, abstracts.ErrCannotRevert) {
+			t.Fatalf("Expected ErrCannotRevert before any week is played, got %v", err)
+		}
+
+		for i := 0; i < 2; i++ {
+			if _, _, _, err := leagueService.PlayNextWeek(ctx); err != nil {
+				t.Fatalf("Could not play a week: %v", err)
+			}
+		}
+		weekTwo, _ := matchService.GetMatchesByWeek(ctx, 2)
+		week, reverted, err := leagueService.RevertLastPlayedWeek(ctx)
+		if err != nil {
+			t.Fatalf("Did not expect an error reverting a week but got: %v", err)
+		}
+		if week != 2 || len(reverted) != len(weekTwo) || reverted[0].HomeGoals == nil {
+			t.Errorf("Expected week 2 with its %d played matches, got week %d with %+v", len(weekTwo), week, reverted)
+		}
+		for _, match := range weekTwo {
+			if got := mustGetMatch(t, matchService, match.ID); got.IsPlayed || got.HomeGoals != nil || got.AwayGoals != nil {
+				t.Errorf("Expected match %d to be unplayed after the revert, got %+v", match.ID, got)
+			}
+		}
+		if current, _ := leagueService.GetCurrentWeek(ctx); current != 2 {
+			t.Errorf("Expected week 2 to be playable again, got %d", current)
+		}
+		assertTableMatchesResults(t, teamService, matchService)
+
+		weekOne, _ := matchService.GetMatchesByWeek(ctx, 1)
+		if err := leagueService.RevertMatchResult(ctx, weekOne[0].ID, weekOne[0].Version+1); !errors.Is(err, abstracts.ErrVersionConflict) {
+			t.Errorf("Expected a version conflict for a stale version, got %v", err)
+		}
+		if err := leagueService.RevertMatchResult(ctx, weekOne[0].ID, weekOne[0].Version); err != nil {
+			t.Fatalf("Did not expect an error reverting a match but got: %v", err)
+		}
+		if err := leagueService.RevertMatchResult(ctx, weekOne[0].ID, 0); !errors.Is(err, abstracts.ErrCannotRevert) {
+			t.Errorf("Expected ErrCannotRevert for an unplayed match, got %v", err)
+		}
+		assertTableMatchesResults(t, teamService, matchService)
+
+		if _, _, err := leagueService.RevertLastPlayedWeek(ctx); err != nil {
+			t.Fatalf("Did not expect an error reverting week 1 but got: %v", err)
+		}
+		table, _ := leagueService.GetLeagueTable(ctx)
+		for _, team := range table {
+			if team.Played != 0 || team.Points != 0 || team.GoalsFor != 0 {
+				t.Errorf("Expected %s to have no results left, got %+v", team.Name, team)
+			}
+		}
+	})
+}
+
+// TestLeagueService_TeamLifecycle adds and removes teams on every backend, checking the validation rules,
+// that the fixture is rebuilt for the new set of teams and that the roster is frozen once a match is played.
+func TestLeagueService_TeamLifecycle(t *testing.T) {
+	ctx := context.Background()
+	forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+		ids := createTeams(t, teamService,
+			models.Team{Name: "Chelsea", Strength: 85}, models.Team{Name: "Arsenal", Strength: 82},
+			models.Team{Name: "Manchester City", Strength: 90},
+		)
+		leagueService := NewLeagueService(teamService, matchService, nil)
+		if err := leagueService.ResetLeague(ctx); err != nil {
+			t.Fatalf("Could not start the league: %v", err)
+		}
+		if _, err := teamService.ApplyPointsAdjustment(ctx, ids[2], -3, "Financial breach"); err != nil {
+			t.Fatalf("Could not apply an adjustment: %v", err)
+		}
+
+		for _, tt := range []struct {
+			team models.Team
+			want error
+		}{
+			{models.Team{Name: "  ", Strength: 50}, abstracts.ErrInvalidInput},
+			{models.Team{Name: strings.Repeat("é", maxTeamNameLength+1), Strength: 50}, abstracts.ErrInvalidInput},
+			{models.Team{Name: "Everton", Strength: 0}, abstracts.ErrInvalidStrength},
+			{models.Team{Name: " Arsenal ", Strength: 50}, abstracts.ErrDuplicateName},
+			{models.Team{Name: "Everton", Strength: 50, Division: 2}, abstracts.ErrInvalidInput}, // Tek takımlı lig kademesinin fikstürü olmaz
+		} {
+			if _, err := leagueService.AddTeam(ctx, tt.team); !errors.Is(err, tt.want) {
+				t.Errorf("AddTeam(%+v): expected %v, got %v", tt.team, tt.want, err)
+			}
+		}
+		if teams, _ := teamService.GetAllTeams(ctx); len(teams) != 3 {
+			t.Fatalf("Expected rejected teams not to be created, got %d teams", len(teams))
+		}
+
+		added, err := leagueService.AddTeam(ctx, models.Team{Name: " Liverpool ", Strength: 88})
+		if err != nil {
+			t.Fatalf("Did not expect an error adding a team but got: %v", err)
+		}
+		if added.ID == 0 || added.Name != "Liverpool" || added.Division != 1 || added.Played != 0 {
+			t.Errorf("Unexpected created team: %+v", added)
+		}
+		if matches, _ := matchService.GetAllMatches(ctx); len(matches) != 12 {
+			t.Errorf("Expected a 12-match fixture for 4 teams, got %d matches", len(matches))
+		}
+
+		removed, err := leagueService.RemoveTeam(ctx, ids[2])
+		if err != nil {
+			t.Fatalf("Did not expect an error removing a team but got: %v", err)
+		}
+		if removed.Name != "Manchester City" {
+			t.Errorf("Expected the removed team to be returned, got %+v", removed)
+		}
+		_, err = teamService.GetTeamByID(ctx, ids[2])
+		assertNotFound(t, "GetTeamByID after RemoveTeam", err)
+		if adjustments, _ := teamService.GetPointsAdjustments(ctx, 0); len(adjustments) != 0 {
+			t.Errorf("Expected the removed team's adjustments to be deleted, got %+v", adjustments)
+		}
+		matches, _ := matchService.GetAllMatches(ctx)
+		if len(matches) != 6 {
+			t.Errorf("Expected a 6-match fixture for 3 teams, got %d matches", len(matches))
+		}
+		for _, match := range matches {
+			if match.HomeTeamID == ids[2] || match.AwayTeamID == ids[2] {
+				t.Errorf("Expected no matches for the removed team, got %+v", match)
+			}
+		}
+		_, err = leagueService.RemoveTeam(ctx, 9999)
+		assertNotFound(t, "RemoveTeam", err)
+
+		if _, _, _, err := leagueService.PlayNextWeek(ctx); err != nil {
+			t.Fatalf("Could not play a week: %v", err)
+		}
+		if _, err := leagueService.AddTeam(ctx, models.Team{Name: "Everton", Strength: 70}); !errors.Is(err, abstracts.ErrSeasonInProgress) {
+			t.Errorf("Expected ErrSeasonInProgress adding a team mid-season, got %v", err)
+		}
+		if _, err := leagueService.RemoveTeam(ctx, ids[0]); !errors.Is(err, abstracts.ErrSeasonInProgress) {
+			t.Errorf("Expected ErrSeasonInProgress removing a team mid-season, got %v", err)
+		}
+
+		if err := leagueService.ResetLeague(ctx); err != nil {
+			t.Fatalf("Could not reset the league: %v", err)
+		}
+		if _, err := leagueService.RemoveTeam(ctx, ids[0]); err != nil {
+			t.Fatalf("Did not expect an error removing a team after the reset but got: %v", err)
+		}
+		if _, err := leagueService.RemoveTeam(ctx, ids[1]); !errors.Is(err, abstracts.ErrInvalidInput) {
+			t.Errorf("Expected ErrInvalidInput removing one of the last 2 teams, got %v", err)
+		}
+	})
+}
+
+// TestLeagueService_ReplaceTeams swaps the roster of a league in progress for a preset on every backend.
+func TestLeagueService_ReplaceTeams(t *testing.T) {
+	ctx := context.Background()
+	forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+		ids := createTeams(t, teamService,
+			models.Team{Name: "Chelsea", Strength: 85}, models.Team{Name: "Arsenal", Strength: 82},
+			models.Team{Name: "Manchester City", Strength: 90}, models.Team{Name: "Liverpool", Strength: 88},
+		)
+		leagueService := NewLeagueService(teamService, matchService, nil)
+		if err := leagueService.ResetLeague(ctx); err != nil {
+			t.Fatalf("Could not start the league: %v", err)
+		}
+		if _, _, _, err := leagueService.PlayNextWeek(ctx); err != nil {
+			t.Fatalf("Could not play a week: %v", err)
+		}
+		if _, err := teamService.ApplyPointsAdjustment(ctx, ids[0], -3, "Financial breach"); err != nil {
+			t.Fatalf("Could not apply an adjustment: %v", err)
+		}
+
+		for _, roster := range [][]models.Team{
+			{{Name: "Solo", Strength: 50}},
+			{{Name: "A", Strength: 50}, {Name: " A ", Strength: 60}},
+			{{Name: "A", Strength: 50}, {Name: "B", Strength: 101}},
+			{{Name: "A", Strength: 50}, {Name: "B", Strength: 60}, {Name: "C", Strength: 70, Division: 2}},
+		} {
+			if _, err := leagueService.ReplaceTeams(ctx, roster); err == nil {
+				t.Errorf("ReplaceTeams(%+v): expected an error, got nil", roster)
+			}
+		}
+		if got := mustGetTeam(t, teamService, ids[0]); got.Played != 1 || got.Adjustment != -3 {
+			t.Fatalf("Expected a rejected roster to leave the league untouched, got %+v", got)
+		}
+
+		teams, err := leagueService.ReplaceTeams(ctx, []models.Team{
+			{Name: "Real Madrid", Strength: 91}, {Name: "Barcelona", Strength: 89}, {Name: "Sevilla", Strength: 78},
+		})
+		if err != nil {
+			t.Fatalf("Did not expect an error replacing the teams but got: %v", err)
+		}
+		if len(teams) != 3 || teams[0].Name != "Real Madrid" || teams[2].Name != "Sevilla" {
+			t.Fatalf("Expected the preset teams in order, got %+v", teams)
+		}
+		for _, team := range teams {
+			if team.Played != 0 || team.Points != 0 || team.Adjustment != 0 || team.Division != 1 {
+				t.Errorf("Expected %s to start with no results, got %+v", team.Name, team)
+			}
+		}
+		if adjustments, _ := teamService.GetPointsAdjustments(ctx, 0); len(adjustments) != 0 {
+			t.Errorf("Expected the old adjustments to be gone, got %+v", adjustments)
+		}
+		matches, _ := matchService.GetAllMatches(ctx)
+		if len(matches) != 6 {
+			t.Errorf("Expected a 6-match fixture for 3 teams, got %d matches", len(matches))
+		}
+		for _, match := range matches {
+			if match.IsPlayed || match.HomeTeamID < teams[0].ID || match.AwayTeamID < teams[0].ID {
+				t.Errorf("Expected an unplayed fixture between the new teams, got %+v", match)
+			}
+		}
+	})
+}
+
+// TestLeagueService_ConcurrentReads plays a season on every backend while other goroutines read the table and fixture.
+func TestLeagueService_ConcurrentReads(t *testing.T) {
+	ctx := context.Background()
+	forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+		createTeams(t, teamService,
+			models.Team{Name: "A", Strength: 70}, models.Team{Name: "B", Strength: 75}, models.Team{Name: "C", Strength: 80},
+			models.Team{Name: "D", Strength: 85}, models.Team{Name: "E", Strength: 90}, models.Team{Name: "F", Strength: 95},
+		)
+		leagueService := NewLeagueService(teamService, matchService, nil)
+		if err := leagueService.ResetLeague(ctx); err != nil {
+			t.Fatalf("Could not start the league: %v", err)
+		}
+
+		done := make(chan struct{})
+		var readers sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			readers.Add(1)
+			go func() {
+				defer readers.Done()
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					if _, err := leagueService.GetLeagueTable(ctx); err != nil {
+						t.Errorf("Concurrent table read failed: %v", err)
+						return
+					}
+					if _, err := matchService.GetAllMatches(ctx); err != nil {
+						t.Errorf("Concurrent match read failed: %v", err)
+						return
+					}
+				}
+			}()
+		}
+		_, _, err := leagueService.PlayAllRemainingWeeks(ctx)
+		close(done)
+		readers.Wait()
+		if err != nil {
+			t.Fatalf("Did not expect an error but got: %v", err)
+		}
+		assertTableMatchesResults(t, teamService, matchService)
+	})
+}
+
+// TestSQLiteTransactor checks that a unit of work on the SQLite services is applied or undone as a whole,
+// and that a service's own transaction inside it only undoes its own statements when it fails.
+func TestSQLiteTransactor(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteTestDB(t)
+	teamService := NewSQLiteTeamService(db)
+	transactor := database.NewSQLiteTransactor(db)
+	teamID := createTeams(t, teamService, models.Team{Name: "Kept", Strength: 80})[0]
+	errFailed := errors.New("request failed")
+
+	err := transactor.RunInTx(ctx, func(txCtx context.Context) error {
+		if _, err := teamService.CreateTeam(txCtx, models.Team{Name: "Rolled Back", Strength: 70}); err != nil {
+			return err
+		}
+		if _, err := teamService.ApplyPointsAdjustment(txCtx, teamID, -3, "rolled back"); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("Expected the unit of work's error, got: %v", err)
+	}
+	teams, err := teamService.GetAllTeams(ctx)
+	if err != nil {
+		t.Fatalf("Could not read teams: %v", err)
+	}
+	if len(teams) != 1 || teams[0].Points != 0 {
+		t.Fatalf("Expected the failed unit of work to leave only the untouched team, got: %+v", teams)
+	}
+
+	err = transactor.RunInTx(ctx, func(txCtx context.Context) error {
+		if _, err := teamService.ApplyPointsAdjustment(txCtx, teamID, 2, "kept"); err != nil {
+			return err
+		}
+		if _, err := teamService.ApplyPointsAdjustment(txCtx, teamID+100, 5, "unknown team"); !errors.Is(err, abstracts.ErrTeamNotFound) {
+			t.Errorf("Expected ErrTeamNotFound for an unknown team, got: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Did not expect an error but got: %v", err)
+	}
+	adjustments, err := teamService.GetPointsAdjustments(ctx, 0)
+	if err != nil {
+		t.Fatalf("Could not read adjustments: %v", err)
+	}
+	if len(adjustments) != 1 || adjustments[0].Points != 2 {
+		t.Errorf("Expected only the committed adjustment to remain, got: %+v", adjustments)
+	}
+}
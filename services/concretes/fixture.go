@@ -0,0 +1,98 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"fmt"
+	"sort"
+)
+
+// buildSeasonFixture creates a separate double round-robin for every division among the given teams.
+// All divisions start in week 1 and play their weeks side by side.
+func buildSeasonFixture(teams []models.Team) ([]models.Match, error) {
+	teamIDsByDivision := make(map[int][]int)
+	for _, t := range teams {
+		teamIDsByDivision[divisionOf(t)] = append(teamIDsByDivision[divisionOf(t)], t.ID)
+	}
+
+	var divisions []int
+	for division := range teamIDsByDivision {
+		divisions = append(divisions, division)
+	}
+	sort.Ints(divisions)
+
+	var matches []models.Match
+	for _, division := range divisions {
+		teamIDs := teamIDsByDivision[division]
+		if len(teamIDs) < 2 {
+			return nil, fmt.Errorf("%w: division %d has %d team(s); at least 2 are required to generate a fixture", abstracts.ErrInvalidInput, division, len(teamIDs))
+		}
+		matches = append(matches, buildDoubleRoundRobin(teamIDs, 1)...)
+	}
+	return matches, nil
+}
+
+// divisionOf returns the team's division, treating an unset division as the top tier.
+func divisionOf(team models.Team) int {
+	if team.Division < 1 {
+		return 1
+	}
+	return team.Division
+}
+
+// buildDoubleRoundRobin creates a home-and-away round-robin fixture for the given teams, starting at startWeek.
+// It uses the circle method: the first team stays in place while the others rotate one slot per week.
+// With an odd number of teams a bye slot is added, so every team sits out one week in each half of the season.
+func buildDoubleRoundRobin(teamIDs []int, startWeek int) []models.Match {
+	const bye = 0
+
+	ids := append([]int(nil), teamIDs...)
+	if len(ids)%2 == 1 {
+		ids = append(ids, bye)
+	}
+	n := len(ids)
+
+	var firstHalf [][][2]int
+	for round := 0; round < n-1; round++ {
+		var pairs [][2]int
+		for i := 0; i < n/2; i++ {
+			home, away := ids[i], ids[n-1-i]
+			// Sabit takımın ev/deplasman sırası her hafta değişir
+			if i == 0 && round%2 == 1 {
+				home, away = away, home
+			}
+			if home == bye || away == bye {
+				continue
+			}
+			pairs = append(pairs, [2]int{home, away})
+		}
+		firstHalf = append(firstHalf, pairs)
+
+		last := ids[n-1]
+		copy(ids[2:], ids[1:n-1])
+		ids[1] = last
+	}
+
+	var matches []models.Match
+	week := startWeek
+	// İlk yarı olduğu gibi, ikinci yarı ev sahibi/deplasman yer değiştirerek yazılır
+	for half := 0; half < 2; half++ {
+		for _, pairs := range firstHalf {
+			for _, pair := range pairs {
+				home, away := pair[0], pair[1]
+				if half == 1 {
+					home, away = away, home
+				}
+				matches = append(matches, models.Match{
+					Week:       week,
+					HomeTeamID: home,
+					AwayTeamID: away,
+					IsPlayed:   false,
+					Stage:      models.StageLeague,
+				})
+			}
+			week++
+		}
+	}
+	return matches
+}
@@ -0,0 +1,89 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/models"
+	"fmt"
+	"testing"
+)
+
+// TestBuildDoubleRoundRobin checks that every team meets every other team once at home and once away,
+// and that no team plays twice in the same week.
+func TestBuildDoubleRoundRobin(t *testing.T) {
+	for _, teamCount := range []int{2, 3, 4, 5, 6, 20} {
+		t.Run(fmt.Sprintf("%d teams", teamCount), func(t *testing.T) {
+			teamIDs := make([]int, teamCount)
+			for i := range teamIDs {
+				teamIDs[i] = i + 1
+			}
+
+			matches := buildDoubleRoundRobin(teamIDs, 1)
+
+			expectedMatches := teamCount * (teamCount - 1)
+			if len(matches) != expectedMatches {
+				t.Fatalf("Expected %d matches, got %d", expectedMatches, len(matches))
+			}
+
+			pairings := make(map[[2]int]int)
+			playingInWeek := make(map[int]map[int]bool)
+			for _, m := range matches {
+				if m.HomeTeamID == m.AwayTeamID {
+					t.Errorf("Team %d scheduled against itself in week %d", m.HomeTeamID, m.Week)
+				}
+				pairings[[2]int{m.HomeTeamID, m.AwayTeamID}]++
+				if playingInWeek[m.Week] == nil {
+					playingInWeek[m.Week] = make(map[int]bool)
+				}
+				for _, id := range []int{m.HomeTeamID, m.AwayTeamID} {
+					if playingInWeek[m.Week][id] {
+						t.Errorf("Team %d plays more than once in week %d", id, m.Week)
+					}
+					playingInWeek[m.Week][id] = true
+				}
+			}
+			for home := 1; home <= teamCount; home++ {
+				for away := 1; away <= teamCount; away++ {
+					if home != away && pairings[[2]int{home, away}] != 1 {
+						t.Errorf("Expected exactly one %d vs %d match, got %d", home, away, pairings[[2]int{home, away}])
+					}
+				}
+			}
+
+			expectedWeeks := 2 * (teamCount - 1)
+			if teamCount%2 == 1 {
+				expectedWeeks = 2 * teamCount
+			}
+			if len(playingInWeek) != expectedWeeks {
+				t.Errorf("Expected %d weeks, got %d", expectedWeeks, len(playingInWeek))
+			}
+		})
+	}
+}
+
+// TestBuildSeasonFixture checks that each division gets its own round-robin and teams never meet across divisions.
+func TestBuildSeasonFixture(t *testing.T) {
+	teams := []models.Team{
+		{ID: 1, Division: 1}, {ID: 2, Division: 1}, {ID: 3, Division: 1}, {ID: 4, Division: 1},
+		{ID: 5, Division: 2}, {ID: 6, Division: 2}, {ID: 7, Division: 2},
+	}
+	divisionByTeam := make(map[int]int)
+	for _, team := range teams {
+		divisionByTeam[team.ID] = team.Division
+	}
+
+	matches, err := buildSeasonFixture(teams)
+	if err != nil {
+		t.Fatalf("Did not expect an error but got: %v", err)
+	}
+	if len(matches) != 4*3+3*2 {
+		t.Errorf("Expected %d matches, got %d", 4*3+3*2, len(matches))
+	}
+	for _, m := range matches {
+		if divisionByTeam[m.HomeTeamID] != divisionByTeam[m.AwayTeamID] {
+			t.Errorf("Match %d vs %d crosses divisions", m.HomeTeamID, m.AwayTeamID)
+		}
+	}
+
+	if _, err := buildSeasonFixture(append(teams, models.Team{ID: 8, Division: 3})); err == nil {
+		t.Errorf("Expected an error for a single-team division but got nil")
+	}
+}
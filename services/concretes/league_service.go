@@ -1,26 +1,65 @@
 package concretes
 
 import (
+	"MatchSimulator_Insider/logging"
+	"MatchSimulator_Insider/metrics"
 	"MatchSimulator_Insider/models"
 	"MatchSimulator_Insider/services/abstracts"
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 )
 
 // LeagueService manages the overall league progression, simulations, and state.
 type LeagueService struct {
 	teamService  abstracts.TeamService
 	matchService abstracts.IMatchService
+
+	// State-changing operations are serialised: mu guards this process, locker (optional) other instances.
+	mu     sync.Mutex
+	locker abstracts.LeagueLocker
 }
 
 // NewLeagueService creates a new instance of LeagueService.
-func NewLeagueService(ts abstracts.TeamService, ms abstracts.IMatchService) abstracts.ILeagueService {
+// locker serialises league changes across server instances; nil is enough for a single process.
+func NewLeagueService(ts abstracts.TeamService, ms abstracts.IMatchService, locker abstracts.LeagueLocker) abstracts.ILeagueService {
 	return &LeagueService{
 		teamService:  ts,
 		matchService: ms,
+		locker:       locker,
+	}
+}
+
+// leagueLockHeldKey marks a context whose operation already holds the league lock,
+// so nested calls such as PlayAllRemainingWeeks -> PlayNextWeek do not lock again.
+type leagueLockHeldKey struct{}
+
+// lockLeague takes the league lock without waiting. It returns abstracts.ErrLeagueBusy when another
+// operation holds it, otherwise a context for the locked operation and a function that releases the lock.
+func (s *LeagueService) lockLeague(ctx context.Context) (context.Context, func(), error) {
+	if ctx.Value(leagueLockHeldKey{}) != nil {
+		return ctx, func() {}, nil
 	}
+	if !s.mu.TryLock() {
+		return nil, nil, abstracts.ErrLeagueBusy
+	}
+	release := func() {}
+	if s.locker != nil {
+		var err error
+		if release, err = s.locker.TryLockLeague(ctx); err != nil {
+			s.mu.Unlock()
+			return nil, nil, err
+		}
+	}
+	return context.WithValue(ctx, leagueLockHeldKey{}, true), func() {
+		release()
+		s.mu.Unlock()
+	}, nil
 }
 
 // GetCurrentWeek determines the earliest unplayed week in the league.
@@ -72,8 +111,14 @@ func (s *LeagueService) GetCurrentWeek(ctx context.Context) (int, error) {
 }
 
 // PlayNextWeek simulates the next unplayed week, updates stats, and returns results.
-// Returns playedWeekNum=0 if the league is finished.
+// Returns abstracts.ErrLeagueFinished together with the final table if every match has been played.
 func (s *LeagueService) PlayNextWeek(ctx context.Context) (playedWeekNum int, weekMatches []models.Match, leagueTable []models.Team, err error) {
+	ctx, unlock, err := s.lockLeague(ctx)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("LeagueService.PlayNextWeek: %w", err)
+	}
+	defer unlock()
+
 	currentWeek, err := s.GetCurrentWeek(ctx)
 	if err != nil {
 		return 0, nil, nil, fmt.Errorf("LeagueService.PlayNextWeek: Error determining week to play: %w", err)
@@ -81,8 +126,12 @@ func (s *LeagueService) PlayNextWeek(ctx context.Context) (playedWeekNum int, we
 	// rest of the logic is the same
 	if currentWeek == -1 { // Lig bitmiş
 		finalTable, errTable := s.GetLeagueTable(ctx)
-		return 0, nil, finalTable, errTable
+		if errTable != nil {
+			return 0, nil, nil, fmt.Errorf("LeagueService.PlayNextWeek: Error retrieving final league table: %w", errTable)
+		}
+		return 0, nil, finalTable, fmt.Errorf("LeagueService.PlayNextWeek: %w", abstracts.ErrLeagueFinished)
 	}
+	ctx = logging.With(ctx, logging.KeyWeek, currentWeek)
 
 	matchesForThisWeek, err := s.matchService.GetMatchesByWeek(ctx, currentWeek)
 	if err != nil {
@@ -92,13 +141,17 @@ func (s *LeagueService) PlayNextWeek(ctx context.Context) (playedWeekNum int, we
 	if len(matchesForThisWeek) == 0 {
 		currentTable, tableErr := s.GetLeagueTable(ctx)
 		if tableErr != nil {
-			log.Printf("LeagueService.PlayNextWeek: Additionally, error getting league table: %v", tableErr)
+			slog.WarnContext(ctx, "no matches found for the week and the league table could not be retrieved", "error", tableErr)
 		}
-		return currentWeek, nil, currentTable, fmt.Errorf("LeagueService.PlayNextWeek: ℹ️ No matches found for week %d. Fixture might be missing or incomplete", currentWeek)
+		return currentWeek, nil, currentTable, fmt.Errorf("LeagueService.PlayNextWeek: No matches found for week %d: %w", currentWeek, abstracts.ErrFixtureMissing)
 	}
 
 	playedMatchesResult := make([]models.Match, 0, len(matchesForThisWeek))
 	for _, matchToPlay := range matchesForThisWeek {
+		// Sunucu kapanırken iptal edilen istek haftayı yarıda bırakır; işlem geri alınır.
+		if err := ctx.Err(); err != nil {
+			return currentWeek, nil, nil, fmt.Errorf("LeagueService.PlayNextWeek: Simulation of week %d cancelled: %w", currentWeek, err)
+		}
 		if matchToPlay.IsPlayed {
 			updatedMatch, _ := s.matchService.GetMatchByID(ctx, matchToPlay.ID)
 			if updatedMatch != nil {
@@ -127,19 +180,33 @@ func (s *LeagueService) PlayNextWeek(ctx context.Context) (playedWeekNum int, we
 		if errUpdate != nil {
 			return currentWeek, nil, nil, fmt.Errorf("LeagueService.PlayNextWeek: Error updating match (ID: %d) result: %w", matchToPlay.ID, errUpdate)
 		}
+		stage := matchToPlay.Stage
+		if stage == "" {
+			stage = models.StageLeague
+		}
+		metrics.MatchesSimulated.Inc(stage)
+		metrics.GoalsPerMatch.Observe(float64(homeGoals + awayGoals))
 
-		errHTStats := s.teamService.UpdateTeamStatsAfterMatch(ctx, homeTeam.ID, homeGoals, awayGoals)
-		if errHTStats != nil {
-			return currentWeek, nil, nil, fmt.Errorf("LeagueService.PlayNextWeek: Error updating stats for home team (%s): %w", homeTeam.Name, errHTStats)
+		if isKnockoutStage(matchToPlay.Stage) {
+			if errDecide := s.settleKnockoutMatch(ctx, matchToPlay, *homeTeam, *awayTeam, homeGoals, awayGoals); errDecide != nil {
+				return currentWeek, nil, nil, fmt.Errorf("LeagueService.PlayNextWeek: Error deciding knockout match (ID: %d): %w", matchToPlay.ID, errDecide)
+			}
 		}
-		errATStats := s.teamService.UpdateTeamStatsAfterMatch(ctx, awayTeam.ID, awayGoals, homeGoals)
-		if errATStats != nil {
-			return currentWeek, nil, nil, fmt.Errorf("LeagueService.PlayNextWeek: Error updating stats for away team (%s): %w", awayTeam.Name, errATStats)
+
+		if countsTowardsStandings(matchToPlay) {
+			errHTStats := s.teamService.UpdateTeamStatsAfterMatch(ctx, homeTeam.ID, homeGoals, awayGoals)
+			if errHTStats != nil {
+				return currentWeek, nil, nil, fmt.Errorf("LeagueService.PlayNextWeek: Error updating stats for home team (%s): %w", homeTeam.Name, errHTStats)
+			}
+			errATStats := s.teamService.UpdateTeamStatsAfterMatch(ctx, awayTeam.ID, awayGoals, homeGoals)
+			if errATStats != nil {
+				return currentWeek, nil, nil, fmt.Errorf("LeagueService.PlayNextWeek: Error updating stats for away team (%s): %w", awayTeam.Name, errATStats)
+			}
 		}
 
 		updatedMatch, errGetMatch := s.matchService.GetMatchByID(ctx, matchToPlay.ID)
 		if errGetMatch != nil {
-			log.Printf("LeagueService.PlayNextWeek: Warning! Error retrieving updated match info (ID: %d) after playing: %v. Using simulated scores.", matchToPlay.ID, errGetMatch)
+			slog.WarnContext(ctx, "played match could not be re-read, using the simulated score", logging.KeyMatchID, matchToPlay.ID, "error", errGetMatch)
 			matchToPlay.HomeGoals = &homeGoals
 			matchToPlay.AwayGoals = &awayGoals
 			matchToPlay.IsPlayed = true
@@ -153,6 +220,8 @@ func (s *LeagueService) PlayNextWeek(ctx context.Context) (playedWeekNum int, we
 	if errTable != nil {
 		return currentWeek, playedMatchesResult, nil, fmt.Errorf("LeagueService.PlayNextWeek: Error retrieving league table after playing week: %w", errTable)
 	}
+	metrics.WeeksPlayed.Inc()
+	slog.InfoContext(ctx, "week played", "matches", len(playedMatchesResult))
 	return currentWeek, playedMatchesResult, finalLeagueTable, nil
 }
 
@@ -164,6 +233,13 @@ func (s *LeagueService) GetLeagueTable(ctx context.Context) ([]models.Team, erro
 		return nil, fmt.Errorf("LeagueService.GetLeagueTable: Could not retrieve teams for league table: %w", err)
 	}
 
+	sortLeagueTable(teams)
+	return teams, nil
+}
+
+// sortLeagueTable orders teams by Premier League rules: points, then goal difference, then goals scored.
+// The same ranking is used for the league table, group standings and simulated tables.
+func sortLeagueTable(teams []models.Team) {
 	sort.SliceStable(teams, func(i, j int) bool {
 		if teams[i].Points != teams[j].Points {
 			return teams[i].Points > teams[j].Points
@@ -173,7 +249,80 @@ func (s *LeagueService) GetLeagueTable(ctx context.Context) ([]models.Team, erro
 		}
 		return teams[i].GoalsFor > teams[j].GoalsFor
 	})
-	return teams, nil
+}
+
+// settleKnockoutMatch decides a knockout match once regular time is played. A first leg only counts
+// towards the aggregate; a second leg is decided on the aggregate score of both legs.
+// A level result that was already settled by extra time or penalties keeps its decision.
+func (s *LeagueService) settleKnockoutMatch(ctx context.Context, match models.Match, homeTeam, awayTeam models.Team, homeGoals, awayGoals int) error {
+	if match.Leg == 1 {
+		return nil
+	}
+	homeTotal, awayTotal := homeGoals, awayGoals
+	if match.Leg == 2 {
+		allMatches, err := s.matchService.GetAllMatches(ctx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve first leg: %w", err)
+		}
+		firstLeg, found := findFirstLeg(allMatches, match)
+		if !found || !firstLeg.IsPlayed || firstLeg.HomeGoals == nil || firstLeg.AwayGoals == nil {
+			return fmt.Errorf("first leg of match ID %d has not been played", match.ID)
+		}
+		// İlk ayakta ev sahibi olan takım, ikinci ayağın deplasman takımıdır
+		homeTotal += *firstLeg.AwayGoals
+		awayTotal += *firstLeg.HomeGoals
+	}
+	if homeTotal == awayTotal && match.WinnerTeamID != nil && match.HomeGoalsET != nil {
+		return nil
+	}
+	return s.decideKnockoutMatch(ctx, match.ID, homeTeam, awayTeam, homeTotal, awayTotal)
+}
+
+// decideKnockoutMatch settles a knockout match after regular time. A level score goes to extra time
+// and, if still level, to a penalty shootout. The deciders and the advancing team are stored on the match.
+func (s *LeagueService) decideKnockoutMatch(ctx context.Context, matchID int, homeTeam, awayTeam models.Team, homeGoals, awayGoals int) error {
+	if homeGoals != awayGoals {
+		winnerID := homeTeam.ID
+		if awayGoals > homeGoals {
+			winnerID = awayTeam.ID
+		}
+		return s.matchService.UpdateKnockoutResult(ctx, matchID, nil, nil, nil, nil, winnerID)
+	}
+
+	homeGoalsET, awayGoalsET, err := s.matchService.SimulateExtraTime(ctx, homeTeam, awayTeam)
+	if err != nil {
+		return fmt.Errorf("extra time simulation failed: %w", err)
+	}
+	if homeGoalsET != awayGoalsET {
+		winnerID := homeTeam.ID
+		if awayGoalsET > homeGoalsET {
+			winnerID = awayTeam.ID
+		}
+		slog.InfoContext(ctx, "knockout match decided in extra time", logging.KeyMatchID, matchID, "home_goals", homeGoalsET, "away_goals", awayGoalsET)
+		return s.matchService.UpdateKnockoutResult(ctx, matchID, &homeGoalsET, &awayGoalsET, nil, nil, winnerID)
+	}
+
+	homePenalties, awayPenalties, err := s.matchService.SimulatePenaltyShootout(ctx, homeTeam, awayTeam)
+	if err != nil {
+		return fmt.Errorf("penalty shootout simulation failed: %w", err)
+	}
+	winnerID := homeTeam.ID
+	if awayPenalties > homePenalties {
+		winnerID = awayTeam.ID
+	}
+	slog.InfoContext(ctx, "knockout match decided on penalties", logging.KeyMatchID, matchID, "home_penalties", homePenalties, "away_penalties", awayPenalties)
+	return s.matchService.UpdateKnockoutResult(ctx, matchID, &homeGoalsET, &awayGoalsET, &homePenalties, &awayPenalties, winnerID)
+}
+
+// countsTowardsStandings reports whether a match result feeds into team statistics.
+// Knockout and playoff matches only decide who advances, so they leave the table untouched.
+func countsTowardsStandings(match models.Match) bool {
+	return !isKnockoutStage(match.Stage)
+}
+
+// isKnockoutStage reports whether matches of the given stage must produce a winner.
+func isKnockoutStage(stage string) bool {
+	return stage == models.StageKnockout || stage == models.StagePlayoff
 }
 
 // updateTeamStatsInMemory is a helper to update a team's stats in-memory for simulations.
@@ -211,8 +360,9 @@ func (s *LeagueService) GetChampionshipPredictions(ctx context.Context) (map[int
 		if errTable != nil || len(finalTable) == 0 {
 			return nil, fmt.Errorf("LeagueService.GetChampionshipPredictions: League finished but final table could not be retrieved: %w", errTable)
 		}
-		for index, team := range finalTable {
-			if index == 0 {
+		championID := topDivisionLeader(finalTable)
+		for _, team := range finalTable {
+			if team.ID == championID {
 				predictions[team.ID] = 1.0
 			} else {
 				predictions[team.ID] = 0.0
@@ -222,7 +372,7 @@ func (s *LeagueService) GetChampionshipPredictions(ctx context.Context) (map[int
 	}
 
 	if nextPlayableWeek <= 4 && nextPlayableWeek > 0 {
-		return nil, fmt.Errorf("championship predictions are available after at least 4 weeks are completed. Current playable week: %d", nextPlayableWeek)
+		return nil, fmt.Errorf("LeagueService.GetChampionshipPredictions: %w: they are available after at least 4 weeks are completed. Current playable week: %d", abstracts.ErrPredictionsUnavailable, nextPlayableWeek)
 	}
 
 	originalTeamsFromDB, err := s.teamService.GetAllTeams(ctx)
@@ -245,28 +395,40 @@ func (s *LeagueService) GetChampionshipPredictions(ctx context.Context) (map[int
 
 	var unplayedMatches []models.Match
 	for _, match := range allMatchesFromDB {
-		if !match.IsPlayed {
+		if !match.IsPlayed && countsTowardsStandings(match) {
 			unplayedMatches = append(unplayedMatches, match)
 		}
 	}
 
+	currentTable := append([]models.Team(nil), originalTeamsFromDB...)
+	sortLeagueTable(currentTable)
+	if championID := clinchedChampion(currentTable, unplayedMatches); championID != 0 {
+		slog.DebugContext(ctx, "title already clinched, skipping simulation", logging.KeyTeamID, championID)
+		for _, team := range currentTable {
+			predictions[team.ID] = 0.0
+		}
+		predictions[championID] = 1.0
+		return predictions, nil
+	}
+
 	if len(unplayedMatches) == 0 && nextPlayableWeek != -1 {
-		log.Println("LeagueService.GetChampionshipPredictions: Warning - No unplayed matches found, but league appears unfinished according to GetCurrentWeek. Predicting based on current table.")
+		slog.WarnContext(ctx, "no unplayed matches but the league is not finished, predicting from the current table", logging.KeyWeek, nextPlayableWeek)
 		finalTable, _ := s.GetLeagueTable(ctx)
 		if len(finalTable) > 0 {
 			for _, team := range finalTable {
 				predictions[team.ID] = 0.0
 			}
-			predictions[finalTable[0].ID] = 1.0
+			predictions[topDivisionLeader(finalTable)] = 1.0
 		}
 		return predictions, nil
 	}
 	if len(unplayedMatches) == 0 {
-		log.Println("LeagueService.GetChampionshipPredictions: No unplayed matches to simulate, league is considered finished.")
+		slog.DebugContext(ctx, "no unplayed matches to simulate, the league is finished")
 		finalTable, _ := s.GetLeagueTable(ctx)
 		if len(finalTable) > 0 {
-			for index, team := range finalTable {
-				if index == 0 {
+			championID := topDivisionLeader(finalTable)
+			for _, team := range finalTable {
+				if team.ID == championID {
 					predictions[team.ID] = 1.0
 				} else {
 					predictions[team.ID] = 0.0
@@ -277,12 +439,23 @@ func (s *LeagueService) GetChampionshipPredictions(ctx context.Context) (map[int
 	}
 
 	numberOfSimulations := 2000
+	start := time.Now()
+	defer func() {
+		metrics.PredictionDuration.ObserveSince(start)
+		metrics.PredictionIterations.Add(float64(numberOfSimulations))
+	}()
+	// Tahminler kendi rastgele kaynağını kullanır; sorulmaları bir sonraki haftanın sonuçlarını değiştirmez.
+	predictionRandom := newPredictionRandom(nextPlayableWeek)
 	championshipWinsCount := make(map[int]int)
 	for _, team := range originalTeamsFromDB {
 		championshipWinsCount[team.ID] = 0
 	}
 
 	for simCount := 0; simCount < numberOfSimulations; simCount++ {
+		if err := ctx.Err(); err != nil {
+			numberOfSimulations = simCount // metrik yalnızca tamamlanan simülasyonları sayar
+			return nil, fmt.Errorf("LeagueService.GetChampionshipPredictions: Prediction cancelled after %d simulations: %w", simCount, err)
+		}
 		currentSimTeamStats := make(map[int]models.Team)
 		for _, team := range originalTeamsFromDB {
 			copiedTeam := team
@@ -293,7 +466,7 @@ func (s *LeagueService) GetChampionshipPredictions(ctx context.Context) (map[int
 			homeTeamOriginal := teamsMapOriginal[matchToSimulate.HomeTeamID]
 			awayTeamOriginal := teamsMapOriginal[matchToSimulate.AwayTeamID]
 
-			homeGoals, awayGoals, _ := s.matchService.SimulateMatchOutcome(ctx, homeTeamOriginal, awayTeamOriginal)
+			homeGoals, awayGoals := simulateGoals(predictionRandom, homeTeamOriginal, awayTeamOriginal, 6) // SimulateMatchOutcome ile aynı motor
 
 			homeTeamSimStats := currentSimTeamStats[matchToSimulate.HomeTeamID]
 			updateTeamStatsInMemory(&homeTeamSimStats, homeGoals, awayGoals)
@@ -309,18 +482,10 @@ func (s *LeagueService) GetChampionshipPredictions(ctx context.Context) (map[int
 			simTable = append(simTable, teamStats)
 		}
 
-		sort.SliceStable(simTable, func(k, l int) bool {
-			if simTable[k].Points != simTable[l].Points {
-				return simTable[k].Points > simTable[l].Points
-			}
-			if simTable[k].GoalDifference != simTable[l].GoalDifference {
-				return simTable[k].GoalDifference > simTable[l].GoalDifference
-			}
-			return simTable[k].GoalsFor > simTable[l].GoalsFor
-		})
+		sortLeagueTable(simTable)
 
 		if len(simTable) > 0 {
-			championTeamID := simTable[0].ID
+			championTeamID := topDivisionLeader(simTable)
 			championshipWinsCount[championTeamID]++
 		}
 	}
@@ -333,65 +498,154 @@ func (s *LeagueService) GetChampionshipPredictions(ctx context.Context) (map[int
 	return predictions, nil
 }
 
+// topDivisionLeader returns the ID of the best-placed team of the top division in a sorted table.
+// Only the top division can produce the champion when several divisions play side by side.
+func topDivisionLeader(sortedTable []models.Team) int {
+	topDivision := 0
+	for _, team := range sortedTable {
+		if topDivision == 0 || divisionOf(team) < topDivision {
+			topDivision = divisionOf(team)
+		}
+	}
+	for _, team := range sortedTable {
+		if divisionOf(team) == topDivision {
+			return team.ID
+		}
+	}
+	return 0
+}
+
+// clinchedChampion returns the ID of the top-division leader once no rival can catch it, or 0 while the title is open.
+// Points already include adjustments, so a deduction can reopen a race and a bonus can settle it early.
+// A rival that could still draw level on points is not counted out, because goal difference may yet decide.
+func clinchedChampion(sortedTable []models.Team, unplayedMatches []models.Match) int {
+	leaderID := topDivisionLeader(sortedTable)
+	remaining := make(map[int]int)
+	for _, match := range unplayedMatches {
+		if countsTowardsStandings(match) {
+			remaining[match.HomeTeamID]++
+			remaining[match.AwayTeamID]++
+		}
+	}
+	var leader models.Team
+	for _, team := range sortedTable {
+		if team.ID == leaderID {
+			leader = team
+			break
+		}
+	}
+	for _, team := range sortedTable {
+		if team.ID == leaderID || divisionOf(team) != divisionOf(leader) {
+			continue
+		}
+		if team.Points+3*remaining[team.ID] >= leader.Points {
+			return 0
+		}
+	}
+	return leaderID
+}
+
+// GetClinchedChampion returns the ID of the team that has mathematically won the title, or 0 while it is still open.
+// Once the league is finished the champion is the top-division leader, even when it is ahead only on goal difference.
+func (s *LeagueService) GetClinchedChampion(ctx context.Context) (int, error) {
+	currentWeek, err := s.GetCurrentWeek(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("LeagueService.GetClinchedChampion: Could not determine current week: %w", err)
+	}
+	table, err := s.GetLeagueTable(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("LeagueService.GetClinchedChampion: %w", err)
+	}
+	if len(table) == 0 {
+		return 0, nil
+	}
+	if currentWeek == -1 {
+		return topDivisionLeader(table), nil
+	}
+	allMatches, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("LeagueService.GetClinchedChampion: Could not retrieve matches: %w", err)
+	}
+	var unplayedMatches []models.Match
+	for _, match := range allMatches {
+		if !match.IsPlayed {
+			unplayedMatches = append(unplayedMatches, match)
+		}
+	}
+	return clinchedChampion(table, unplayedMatches), nil
+}
+
 // ResetLeague resets all team statistics and regenerates the fixture.
+// Every division gets its own round-robin, so a multi-division pyramid is restarted as a whole.
 func (s *LeagueService) ResetLeague(ctx context.Context) error {
+	ctx, unlock, err := s.lockLeague(ctx)
+	if err != nil {
+		return fmt.Errorf("LeagueService.ResetLeague: %w", err)
+	}
+	defer unlock()
 
-	log.Println("LeagueService.ResetLeague: League reset process STARTED.")
-	err := s.teamService.ResetAllTeamStats(ctx)
+	err = s.teamService.ResetAllTeamStats(ctx)
 	if err != nil {
-		log.Printf("LeagueService.ResetLeague ERROR: Could not reset team statistics: %v", err)
 		return fmt.Errorf("LeagueService.ResetLeague: Error while resetting team statistics: %w", err)
 	}
-	log.Println("LeagueService.ResetLeague: Call to reset team statistics made.")
 
 	teams, err := s.teamService.GetAllTeams(ctx)
 	if err != nil {
-		log.Printf("LeagueService.ResetLeague ERROR: Could not fetch reset teams: %v", err)
 		return fmt.Errorf("LeagueService.ResetLeague: Error retrieving teams for fixture (after stats reset): %w", err)
 	}
 
-	var teamsForFixture []models.Team
-	if len(teams) >= 4 {
-		teamsForFixture = teams[:4]
-	} else {
-		err := fmt.Errorf("LeagueService.ResetLeague: Insufficient teams to generate fixture. At least 4 teams required, found: %d", len(teams))
-		log.Printf("LeagueService.ResetLeague ERROR: %v", err)
-		return err
+	if len(teams) < 2 {
+		return fmt.Errorf("LeagueService.ResetLeague: Insufficient teams to generate fixture. At least 2 teams required, found: %d", len(teams))
 	}
-	log.Printf("LeagueService.ResetLeague: %d teams will be used for the fixture.", len(teamsForFixture))
 
-	err = s.matchService.GenerateAndStoreFixture(ctx, teamsForFixture)
+	err = s.matchService.GenerateAndStoreFixture(ctx, teams)
 	if err != nil {
-		log.Printf("LeagueService.ResetLeague ERROR: Could not regenerate fixture: %v", err)
 		return fmt.Errorf("LeagueService.ResetLeague: Error regenerating fixture: %w", err)
 	}
-	log.Println("LeagueService.ResetLeague: League successfully reset (statistics and fixture).")
+	slog.InfoContext(ctx, "league reset", "teams", len(teams))
 	return nil
 }
 
 // PlayAllRemainingWeeks plays all remaining unplayed weeks in the league.
 func (s *LeagueService) PlayAllRemainingWeeks(ctx context.Context) (map[int][]models.Match, []models.Team, error) {
+	ctx, unlock, err := s.lockLeague(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("LeagueService.PlayAllRemainingWeeks: %w", err)
+	}
+	defer unlock()
 
 	allPlayedMatchesByWeek := make(map[int][]models.Match)
 	var finalLeagueTable []models.Team
 	var lastSuccessfullyPlayedWeek int
 
-	log.Println("LeagueService.PlayAllRemainingWeeks: Playing all remaining weeks...")
-	for i := 0; i < 10; i++ {
+	// Her iterasyonda bir hafta oynanır; fikstürdeki hafta sayısı döngünün üst sınırıdır
+	allMatches, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return allPlayedMatchesByWeek, nil, fmt.Errorf("LeagueService.PlayAllRemainingWeeks: Error retrieving matches: %w", err)
+	}
+	distinctWeeks := make(map[int]bool)
+	for _, match := range allMatches {
+		distinctWeeks[match.Week] = true
+	}
+
+	for i := 0; i <= len(distinctWeeks); i++ {
+		if err := ctx.Err(); err != nil {
+			return allPlayedMatchesByWeek, finalLeagueTable, fmt.Errorf("LeagueService.PlayAllRemainingWeeks: Cancelled after week %d: %w", lastSuccessfullyPlayedWeek, err)
+		}
 		nextWeekToPlay, err := s.GetCurrentWeek(ctx)
 		if err != nil {
 			return allPlayedMatchesByWeek, finalLeagueTable, fmt.Errorf("LeagueService.PlayAllRemainingWeeks: Error determining current week: %w", err)
 		}
 
 		if nextWeekToPlay == -1 {
-			log.Println("LeagueService.PlayAllRemainingWeeks: League already completed.")
+			slog.DebugContext(ctx, "league already completed, no weeks left to play")
 			break
 		}
 
 		playedWeek, weekMatches, currentLeagueTable, playErr := s.PlayNextWeek(ctx)
 
 		if playErr != nil {
-			log.Printf("LeagueService.PlayAllRemainingWeeks: Error playing week %d: %v. Halting simulation.", nextWeekToPlay, playErr)
+			slog.WarnContext(ctx, "playing all weeks halted", logging.KeyWeek, nextWeekToPlay, "error", playErr)
 			if currentLeagueTable != nil {
 				finalLeagueTable = currentLeagueTable
 			} else if lastSuccessfullyPlayedWeek > 0 {
@@ -401,7 +655,6 @@ func (s *LeagueService) PlayAllRemainingWeeks(ctx context.Context) (map[int][]mo
 		}
 
 		if playedWeek == 0 {
-			log.Println("LeagueService.PlayAllRemainingWeeks: League completed as of this iteration.")
 			finalLeagueTable = currentLeagueTable
 			if len(weekMatches) > 0 && nextWeekToPlay > 0 {
 				allPlayedMatchesByWeek[nextWeekToPlay] = weekMatches
@@ -424,27 +677,65 @@ func (s *LeagueService) PlayAllRemainingWeeks(ctx context.Context) (map[int][]mo
 		}
 	}
 
-	log.Printf("LeagueService.PlayAllRemainingWeeks: All remaining weeks played (last successfully played week: %d).", lastSuccessfullyPlayedWeek)
+	slog.InfoContext(ctx, "all remaining weeks played", "weeks", len(allPlayedMatchesByWeek), "last_week", lastSuccessfullyPlayedWeek)
 	return allPlayedMatchesByWeek, finalLeagueTable, nil
 }
 
 // HandleMatchScoreEdit manages editing a match score and adjusting team statistics.
-func (s *LeagueService) HandleMatchScoreEdit(ctx context.Context, matchID int, newHomeGoals int, newAwayGoals int) error {
+// expectedVersion is the match version the caller last saw (If-Match); 0 skips the check.
+func (s *LeagueService) HandleMatchScoreEdit(ctx context.Context, matchID int, newHomeGoals int, newAwayGoals int, expectedVersion int) error {
+	ctx, unlock, err := s.lockLeague(ctx)
+	if err != nil {
+		return fmt.Errorf("LeagueService.HandleMatchScoreEdit: %w", err)
+	}
+	defer unlock()
 
-	log.Printf("LeagueService.HandleMatchScoreEdit: Score edit process started for Match ID %d. New score: %d-%d", matchID, newHomeGoals, newAwayGoals)
+	ctx = logging.With(ctx, logging.KeyMatchID, matchID)
 
-	originalMatch, err := s.matchService.EditMatchScore(ctx, matchID, newHomeGoals, newAwayGoals)
+	if err := s.checkResultEditable(ctx, matchID); err != nil {
+		return fmt.Errorf("HandleMatchScoreEdit: %w", err)
+	}
+	originalMatch, err := s.matchService.EditMatchScore(ctx, matchID, newHomeGoals, newAwayGoals, expectedVersion)
 	if err != nil {
 		return fmt.Errorf("HandleMatchScoreEdit: Error updating match score via MatchService: %w", err)
 	}
 
+	if !countsTowardsStandings(originalMatch) {
+		slog.DebugContext(ctx, "knockout score edited, team statistics are not affected", "stage", originalMatch.Stage)
+		matchToSettle, homeGoals, awayGoals := originalMatch, newHomeGoals, newAwayGoals
+		if originalMatch.Leg == 1 {
+			// İlk ayak düzenlendiğinde, oynanmışsa ikinci ayağın kararı yeni toplam skora göre yeniden verilir
+			allMatches, errAll := s.matchService.GetAllMatches(ctx)
+			if errAll != nil {
+				return fmt.Errorf("HandleMatchScoreEdit: Could not retrieve second leg: %w", errAll)
+			}
+			secondLeg, found := findSecondLeg(allMatches, originalMatch)
+			if !found || !secondLeg.IsPlayed || secondLeg.HomeGoals == nil || secondLeg.AwayGoals == nil {
+				return nil
+			}
+			matchToSettle, homeGoals, awayGoals = secondLeg, *secondLeg.HomeGoals, *secondLeg.AwayGoals
+		}
+		homeTeam, errHT := s.teamService.GetTeamByID(ctx, matchToSettle.HomeTeamID)
+		if errHT != nil {
+			return fmt.Errorf("HandleMatchScoreEdit: Could not retrieve home team (ID: %d): %w", matchToSettle.HomeTeamID, errHT)
+		}
+		awayTeam, errAT := s.teamService.GetTeamByID(ctx, matchToSettle.AwayTeamID)
+		if errAT != nil {
+			return fmt.Errorf("HandleMatchScoreEdit: Could not retrieve away team (ID: %d): %w", matchToSettle.AwayTeamID, errAT)
+		}
+		if err := s.settleKnockoutMatch(ctx, matchToSettle, *homeTeam, *awayTeam, homeGoals, awayGoals); err != nil {
+			return fmt.Errorf("HandleMatchScoreEdit: Error re-deciding knockout match (ID: %d): %w", matchToSettle.ID, err)
+		}
+		return nil
+	}
+
 	var oldHomeScoreForStatAdjust, oldAwayScoreForStatAdjust int
 	if originalMatch.IsPlayed && originalMatch.HomeGoals != nil && originalMatch.AwayGoals != nil {
 		oldHomeScoreForStatAdjust = *originalMatch.HomeGoals
 		oldAwayScoreForStatAdjust = *originalMatch.AwayGoals
 	} else {
 		if !originalMatch.IsPlayed {
-			log.Printf("HandleMatchScoreEdit: Warning - Match ID %d was not previously marked as played. Its old contribution to stats is 0. The edit will mark it as played.", matchID)
+			slog.WarnContext(ctx, "edited match was not played before, the edit marks it as played")
 		}
 		oldHomeScoreForStatAdjust = 0
 		oldAwayScoreForStatAdjust = 0
@@ -456,8 +747,6 @@ func (s *LeagueService) HandleMatchScoreEdit(ctx context.Context, matchID int, n
 		}
 	}
 
-	log.Printf("  Adjusting stats for home team (ID: %d)... Old: %d-%d, New: %d-%d",
-		originalMatch.HomeTeamID, oldHomeScoreForStatAdjust, oldAwayScoreForStatAdjust, newHomeGoals, newAwayGoals)
 	err = s.teamService.AdjustTeamStatsForScoreChange(ctx, originalMatch.HomeTeamID,
 		oldHomeScoreForStatAdjust, oldAwayScoreForStatAdjust,
 		newHomeGoals, newAwayGoals,
@@ -466,8 +755,6 @@ func (s *LeagueService) HandleMatchScoreEdit(ctx context.Context, matchID int, n
 		return fmt.Errorf("HandleMatchScoreEdit: Error adjusting stats for home team (ID: %d): %w", originalMatch.HomeTeamID, err)
 	}
 
-	log.Printf("  Adjusting stats for away team (ID: %d)... Old: %d-%d, New: %d-%d",
-		originalMatch.AwayTeamID, oldAwayScoreForStatAdjust, oldHomeScoreForStatAdjust, newAwayGoals, newHomeGoals)
 	err = s.teamService.AdjustTeamStatsForScoreChange(ctx, originalMatch.AwayTeamID,
 		oldAwayScoreForStatAdjust, oldHomeScoreForStatAdjust,
 		newAwayGoals, newHomeGoals,
@@ -476,6 +763,420 @@ func (s *LeagueService) HandleMatchScoreEdit(ctx context.Context, matchID int, n
 		return fmt.Errorf("HandleMatchScoreEdit: Error adjusting stats for away team (ID: %d): %w", originalMatch.AwayTeamID, err)
 	}
 
-	log.Printf("LeagueService.HandleMatchScoreEdit: Score edit and stat adjustment completed for Match ID %d.", matchID)
+	slog.InfoContext(ctx, "match score edited",
+		"old_home_goals", oldHomeScoreForStatAdjust, "old_away_goals", oldAwayScoreForStatAdjust, "home_goals", newHomeGoals, "away_goals", newAwayGoals)
+	return nil
+}
+
+// forfeitGoals is the score awarded to the winning side of a forfeited match.
+const forfeitGoals = 3
+
+// AwardForfeit records a match as a 3-0 win for winnerTeamID. An unplayed match is completed with that score;
+// an already played match is re-scored through HandleMatchScoreEdit so team statistics stay consistent.
+// In knockout ties the forfeit counts as a 3-0 result towards the aggregate.
+func (s *LeagueService) AwardForfeit(ctx context.Context, matchID int, winnerTeamID int) error {
+	ctx, unlock, err := s.lockLeague(ctx)
+	if err != nil {
+		return fmt.Errorf("LeagueService.AwardForfeit: %w", err)
+	}
+	defer unlock()
+
+	match, err := s.matchService.GetMatchByID(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("LeagueService.AwardForfeit: Could not retrieve match (ID: %d): %w", matchID, err)
+	}
+	if match == nil {
+		return fmt.Errorf("LeagueService.AwardForfeit: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	if err := s.checkResultEditable(ctx, matchID); err != nil {
+		return fmt.Errorf("LeagueService.AwardForfeit: %w", err)
+	}
+
+	var homeGoals, awayGoals int
+	switch winnerTeamID {
+	case match.HomeTeamID:
+		homeGoals = forfeitGoals
+	case match.AwayTeamID:
+		awayGoals = forfeitGoals
+	default:
+		return fmt.Errorf("LeagueService.AwardForfeit: %w: team %d did not take part in match %d", abstracts.ErrInvalidInput, winnerTeamID, matchID)
+	}
+
+	if match.IsPlayed {
+		if err := s.HandleMatchScoreEdit(ctx, matchID, homeGoals, awayGoals, 0); err != nil {
+			return fmt.Errorf("LeagueService.AwardForfeit: %w", err)
+		}
+	} else {
+		homeTeam, errHT := s.teamService.GetTeamByID(ctx, match.HomeTeamID)
+		if errHT != nil {
+			return fmt.Errorf("LeagueService.AwardForfeit: Could not retrieve home team (ID: %d): %w", match.HomeTeamID, errHT)
+		}
+		awayTeam, errAT := s.teamService.GetTeamByID(ctx, match.AwayTeamID)
+		if errAT != nil {
+			return fmt.Errorf("LeagueService.AwardForfeit: Could not retrieve away team (ID: %d): %w", match.AwayTeamID, errAT)
+		}
+		if err := s.matchService.UpdateMatchResult(ctx, matchID, homeGoals, awayGoals, true); err != nil {
+			return fmt.Errorf("LeagueService.AwardForfeit: Error recording forfeit result (ID: %d): %w", matchID, err)
+		}
+		if isKnockoutStage(match.Stage) {
+			if err := s.settleKnockoutMatch(ctx, *match, *homeTeam, *awayTeam, homeGoals, awayGoals); err != nil {
+				return fmt.Errorf("LeagueService.AwardForfeit: Error deciding knockout match (ID: %d): %w", matchID, err)
+			}
+		}
+		if countsTowardsStandings(*match) {
+			if err := s.teamService.UpdateTeamStatsAfterMatch(ctx, homeTeam.ID, homeGoals, awayGoals); err != nil {
+				return fmt.Errorf("LeagueService.AwardForfeit: Error updating stats for home team (%s): %w", homeTeam.Name, err)
+			}
+			if err := s.teamService.UpdateTeamStatsAfterMatch(ctx, awayTeam.ID, awayGoals, homeGoals); err != nil {
+				return fmt.Errorf("LeagueService.AwardForfeit: Error updating stats for away team (%s): %w", awayTeam.Name, err)
+			}
+		}
+	}
+
+	if err := s.matchService.SetMatchForfeit(ctx, matchID, true); err != nil {
+		return fmt.Errorf("LeagueService.AwardForfeit: %w", err)
+	}
+	slog.InfoContext(ctx, "match awarded by forfeit", logging.KeyMatchID, matchID, "winner_team_id", winnerTeamID, "home_goals", homeGoals, "away_goals", awayGoals)
+	return nil
+}
+
+// RevertLastPlayedWeek undoes the most recent week with played matches: the matches become unplayed again
+// and their results are taken out of the team statistics, so that week is the next one to be played.
+// It returns the week and its matches as they were before the revert.
+func (s *LeagueService) RevertLastPlayedWeek(ctx context.Context) (int, []models.Match, error) {
+	ctx, unlock, err := s.lockLeague(ctx)
+	if err != nil {
+		return 0, nil, fmt.Errorf("LeagueService.RevertLastPlayedWeek: %w", err)
+	}
+	defer unlock()
+
+	allMatches, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return 0, nil, fmt.Errorf("LeagueService.RevertLastPlayedWeek: Error retrieving matches: %w", err)
+	}
+	lastPlayedWeek := 0
+	for _, match := range allMatches {
+		if match.IsPlayed && match.Week > lastPlayedWeek {
+			lastPlayedWeek = match.Week
+		}
+	}
+	if lastPlayedWeek == 0 {
+		return 0, nil, fmt.Errorf("LeagueService.RevertLastPlayedWeek: No week has been played yet: %w", abstracts.ErrCannotRevert)
+	}
+
+	var revertedMatches []models.Match
+	for _, match := range allMatches {
+		if match.Week != lastPlayedWeek || !match.IsPlayed {
+			continue
+		}
+		if err := checkMatchRevertable(allMatches, match); err != nil {
+			return 0, nil, fmt.Errorf("LeagueService.RevertLastPlayedWeek: Week %d: %w", lastPlayedWeek, err)
+		}
+		revertedMatches = append(revertedMatches, match)
+	}
+	for _, match := range revertedMatches {
+		if err := s.revertMatch(ctx, match); err != nil {
+			return 0, nil, fmt.Errorf("LeagueService.RevertLastPlayedWeek: %w", err)
+		}
+	}
+	slog.InfoContext(ctx, "week reverted", logging.KeyWeek, lastPlayedWeek, "matches", len(revertedMatches))
+	return lastPlayedWeek, revertedMatches, nil
+}
+
+// RevertMatchResult makes a played match unplayed again and takes its result out of the team statistics.
+// expectedVersion is the match version the caller last saw; 0 skips the check.
+func (s *LeagueService) RevertMatchResult(ctx context.Context, matchID int, expectedVersion int) error {
+	ctx, unlock, err := s.lockLeague(ctx)
+	if err != nil {
+		return fmt.Errorf("LeagueService.RevertMatchResult: %w", err)
+	}
+	defer unlock()
+
+	allMatches, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return fmt.Errorf("LeagueService.RevertMatchResult: Error retrieving matches: %w", err)
+	}
+	var match *models.Match
+	for i := range allMatches {
+		if allMatches[i].ID == matchID {
+			match = &allMatches[i]
+			break
+		}
+	}
+	if match == nil {
+		return fmt.Errorf("LeagueService.RevertMatchResult: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	if expectedVersion != 0 && match.Version != expectedVersion {
+		return versionConflict("LeagueService.RevertMatchResult", "Match", matchID, expectedVersion, match.Version)
+	}
+	if !match.IsPlayed {
+		return fmt.Errorf("LeagueService.RevertMatchResult: Match (ID: %d) has not been played: %w", matchID, abstracts.ErrCannotRevert)
+	}
+	if err := checkMatchRevertable(allMatches, *match); err != nil {
+		return fmt.Errorf("LeagueService.RevertMatchResult: %w", err)
+	}
+	return s.revertMatch(ctx, *match)
+}
+
+// checkMatchRevertable refuses to revert a result that later fixtures were already built on: group and league
+// results once knockout matches have been drawn after them, a knockout result once a later round exists,
+// and the first leg of a tie whose second leg has been played.
+func checkMatchRevertable(allMatches []models.Match, match models.Match) error {
+	if err := checkLaterRoundDrawn(allMatches, match); err != nil {
+		return err
+	}
+	for _, other := range allMatches {
+		if !isKnockoutStage(match.Stage) && isKnockoutStage(other.Stage) && other.Week > match.Week {
+			return fmt.Errorf("match (ID: %d) decided the %s matches drawn after it: %w", match.ID, other.Stage, abstracts.ErrCannotRevert)
+		}
+	}
+	if match.Leg == 1 {
+		if secondLeg, found := findSecondLeg(allMatches, match); found && secondLeg.IsPlayed {
+			return fmt.Errorf("match (ID: %d) is the first leg of a tie whose second leg (ID: %d) has been played: %w", match.ID, secondLeg.ID, abstracts.ErrCannotRevert)
+		}
+	}
+	return nil
+}
+
+// checkLaterRoundDrawn refuses to change a knockout or playoff result once a later round of its stage has been
+// drawn, since that round keeps the winner it was built from.
+func checkLaterRoundDrawn(allMatches []models.Match, match models.Match) error {
+	if !isKnockoutStage(match.Stage) {
+		return nil
+	}
+	for _, other := range allMatches {
+		if other.Stage == match.Stage && other.Round > match.Round {
+			return fmt.Errorf("match (ID: %d) decided a later %s round that has already been drawn: %w", match.ID, match.Stage, abstracts.ErrCannotRevert)
+		}
+	}
+	return nil
+}
+
+// checkResultEditable applies checkLaterRoundDrawn before a score edit or forfeit changes a match result.
+// A first leg stays editable after its second leg: the edit re-decides the tie through the second leg.
+func (s *LeagueService) checkResultEditable(ctx context.Context, matchID int) error {
+	allMatches, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return fmt.Errorf("Could not retrieve matches: %w", err)
+	}
+	for _, match := range allMatches {
+		if match.ID == matchID {
+			return checkLaterRoundDrawn(allMatches, match)
+		}
+	}
+	return nil
+}
+
+// revertMatch takes a played match's result out of the standings and marks it unplayed.
+func (s *LeagueService) revertMatch(ctx context.Context, match models.Match) error {
+	if countsTowardsStandings(match) && match.HomeGoals != nil && match.AwayGoals != nil {
+		if err := s.teamService.RevertTeamStatsAfterMatch(ctx, match.HomeTeamID, *match.HomeGoals, *match.AwayGoals); err != nil {
+			return fmt.Errorf("Error reverting stats for home team (ID: %d): %w", match.HomeTeamID, err)
+		}
+		if err := s.teamService.RevertTeamStatsAfterMatch(ctx, match.AwayTeamID, *match.AwayGoals, *match.HomeGoals); err != nil {
+			return fmt.Errorf("Error reverting stats for away team (ID: %d): %w", match.AwayTeamID, err)
+		}
+	}
+	if err := s.matchService.ClearMatchResult(ctx, match.ID); err != nil {
+		return fmt.Errorf("Error clearing result of match (ID: %d): %w", match.ID, err)
+	}
+	return nil
+}
+
+// maxTeamNameLength is the length of the teams.name column, in characters.
+const maxTeamNameLength = 100
+
+// normalizeTeamName trims a team name and checks that it is not empty and fits the teams.name column.
+// Creating, renaming and importing teams all go through it, so a name that is too long is rejected with
+// ErrInvalidInput instead of failing in the database.
+func normalizeTeamName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return "", fmt.Errorf("%w: team name cannot be empty", abstracts.ErrInvalidInput)
+	case utf8.RuneCountInString(name) > maxTeamNameLength:
+		return "", fmt.Errorf("%w: team name is longer than %d characters", abstracts.ErrInvalidInput, maxTeamNameLength)
+	}
+	return name, nil
+}
+
+// AddTeam validates and creates a team, then regenerates the fixture so the new team is scheduled.
+// Teams can only join before the first match of the season is played; afterwards ErrSeasonInProgress
+// is returned and the league has to be reset first. A division below 1 puts the team in the top division.
+func (s *LeagueService) AddTeam(ctx context.Context, team models.Team) (models.Team, error) {
+	ctx, unlock, err := s.lockLeague(ctx)
+	if err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.AddTeam: %w", err)
+	}
+	defer unlock()
+
+	team, err = normalizeNewTeam(team)
+	if err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.AddTeam: %w", err)
+	}
+	if err := s.ensureNoMatchPlayed(ctx); err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.AddTeam: %w", err)
+	}
+	teams, err := s.teamService.GetAllTeams(ctx)
+	if err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.AddTeam: Error retrieving teams: %w", err)
+	}
+	for _, existing := range teams {
+		if existing.Name == team.Name {
+			return models.Team{}, fmt.Errorf("LeagueService.AddTeam: %w: '%s' is already in use by team ID %d", abstracts.ErrDuplicateName, team.Name, existing.ID)
+		}
+	}
+	if _, err := buildSeasonFixture(append(teams, team)); err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.AddTeam: %w", err)
+	}
+
+	id, err := s.teamService.CreateTeam(ctx, team)
+	if err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.AddTeam: %w", err)
+	}
+	if err := s.regenerateFixture(ctx); err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.AddTeam: %w", err)
+	}
+	created, err := s.teamService.GetTeamByID(ctx, id)
+	if err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.AddTeam: %w", err)
+	}
+	slog.InfoContext(ctx, "team added, fixture regenerated", logging.KeyTeamID, created.ID, "name", created.Name, logging.KeyLeagueID, created.Division)
+	return *created, nil
+}
+
+// RemoveTeam deletes a team together with its points adjustments and regenerates the fixture for the
+// remaining teams. Like AddTeam it returns ErrSeasonInProgress once a match has been played, and it
+// refuses to leave fewer than 2 teams. The removed team is returned as it was before the deletion.
+func (s *LeagueService) RemoveTeam(ctx context.Context, teamID int) (models.Team, error) {
+	ctx, unlock, err := s.lockLeague(ctx)
+	if err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.RemoveTeam: %w", err)
+	}
+	defer unlock()
+
+	team, err := s.teamService.GetTeamByID(ctx, teamID)
+	if err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.RemoveTeam: %w", err)
+	}
+	if err := s.ensureNoMatchPlayed(ctx); err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.RemoveTeam: %w", err)
+	}
+	teams, err := s.teamService.GetAllTeams(ctx)
+	if err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.RemoveTeam: Error retrieving teams: %w", err)
+	}
+	if len(teams) <= 2 {
+		return models.Team{}, fmt.Errorf("LeagueService.RemoveTeam: %w: at least 2 teams are required, found %d", abstracts.ErrInvalidInput, len(teams))
+	}
+	remaining := make([]models.Team, 0, len(teams)-1)
+	for _, other := range teams {
+		if other.ID != teamID {
+			remaining = append(remaining, other)
+		}
+	}
+	if _, err := buildSeasonFixture(remaining); err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.RemoveTeam: %w", err)
+	}
+
+	if err := s.teamService.DeleteTeam(ctx, teamID); err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.RemoveTeam: %w", err)
+	}
+	if err := s.regenerateFixture(ctx); err != nil {
+		return models.Team{}, fmt.Errorf("LeagueService.RemoveTeam: %w", err)
+	}
+	slog.InfoContext(ctx, "team removed, fixture regenerated", logging.KeyTeamID, team.ID, "name", team.Name, logging.KeyLeagueID, team.Division)
+	return *team, nil
+}
+
+// ReplaceTeams deletes every team, match and points adjustment and starts a new league with the given
+// roster, e.g. a seed preset. The roster is validated before anything is deleted. The new teams are returned in ID order.
+func (s *LeagueService) ReplaceTeams(ctx context.Context, roster []models.Team) ([]models.Team, error) {
+	ctx, unlock, err := s.lockLeague(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("LeagueService.ReplaceTeams: %w", err)
+	}
+	defer unlock()
+
+	if len(roster) < 2 {
+		return nil, fmt.Errorf("LeagueService.ReplaceTeams: %w: at least 2 teams are required, got %d", abstracts.ErrInvalidInput, len(roster))
+	}
+	normalized := make([]models.Team, 0, len(roster))
+	seen := make(map[string]bool, len(roster))
+	for _, team := range roster {
+		team, err := normalizeNewTeam(team)
+		if err != nil {
+			return nil, fmt.Errorf("LeagueService.ReplaceTeams: %w", err)
+		}
+		if seen[team.Name] {
+			return nil, fmt.Errorf("LeagueService.ReplaceTeams: %w: '%s' appears more than once", abstracts.ErrDuplicateName, team.Name)
+		}
+		seen[team.Name] = true
+		normalized = append(normalized, team)
+	}
+	if _, err := buildSeasonFixture(normalized); err != nil {
+		return nil, fmt.Errorf("LeagueService.ReplaceTeams: %w", err)
+	}
+
+	if err := s.teamService.DeleteAllTeams(ctx); err != nil {
+		return nil, fmt.Errorf("LeagueService.ReplaceTeams: %w", err)
+	}
+	for _, team := range normalized {
+		if _, err := s.teamService.CreateTeam(ctx, team); err != nil {
+			return nil, fmt.Errorf("LeagueService.ReplaceTeams: %w", err)
+		}
+	}
+	if err := s.ResetLeague(ctx); err != nil {
+		return nil, fmt.Errorf("LeagueService.ReplaceTeams: %w", err)
+	}
+	teams, err := s.teamService.GetAllTeams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("LeagueService.ReplaceTeams: Error retrieving teams: %w", err)
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].ID < teams[j].ID })
+	slog.InfoContext(ctx, "league restarted with new teams", "teams", len(teams))
+	return teams, nil
+}
+
+// normalizeNewTeam validates a team about to be created and returns it with a trimmed name,
+// zeroed statistics and a division of at least 1.
+func normalizeNewTeam(team models.Team) (models.Team, error) {
+	name, err := normalizeTeamName(team.Name)
+	if err != nil {
+		return models.Team{}, err
+	}
+	if team.Strength < 1 || team.Strength > 100 {
+		return models.Team{}, fmt.Errorf("%w: %d. Strength must be between 1 and 100", abstracts.ErrInvalidStrength, team.Strength)
+	}
+	return models.Team{Name: name, Strength: team.Strength, Division: divisionOf(team)}, nil
+}
+
+// ensureNoMatchPlayed returns ErrSeasonInProgress if any match of the current fixture has been played.
+func (s *LeagueService) ensureNoMatchPlayed(ctx context.Context) error {
+	allMatches, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return fmt.Errorf("Error retrieving matches: %w", err)
+	}
+	for _, match := range allMatches {
+		if match.IsPlayed {
+			return fmt.Errorf("%w: match ID %d has already been played; reset the league first", abstracts.ErrSeasonInProgress, match.ID)
+		}
+	}
+	return nil
+}
+
+// regenerateFixture replaces the fixture with a new one for the current teams. Team statistics are
+// left alone, since it is only called while no match has been played. A single team has no fixture.
+func (s *LeagueService) regenerateFixture(ctx context.Context) error {
+	teams, err := s.teamService.GetAllTeams(ctx)
+	if err != nil {
+		return fmt.Errorf("Error retrieving teams for fixture: %w", err)
+	}
+	if len(teams) < 2 {
+		return nil
+	}
+	if err := s.matchService.GenerateAndStoreFixture(ctx, teams); err != nil {
+		return fmt.Errorf("Error regenerating fixture: %w", err)
+	}
 	return nil
 }
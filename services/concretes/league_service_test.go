@@ -2,6 +2,7 @@ package concretes
 
 import (
 	"MatchSimulator_Insider/models" // Path to your models package
+	"MatchSimulator_Insider/services/abstracts"
 	"context"
 	"errors"  // For creating test errors
 	"reflect" // For DeepEqual
@@ -92,6 +93,8 @@ type mockTeamService struct {
 	// GetAllTeamsFunc allows defining a custom function for GetAllTeams for each test case.
 	GetAllTeamsFunc func(ctx context.Context) ([]models.Team, error)
 	// Other ITeamService methods can be added here if needed for other tests.
+	GetTeamByIDFunc               func(ctx context.Context, id int) (*models.Team, error)
+	UpdateTeamStatsAfterMatchFunc func(ctx context.Context, teamID int, goalsScored int, goalsConceded int) error
 }
 
 // CreateTeam is a mock implementation.
@@ -101,34 +104,63 @@ func (m *mockTeamService) CreateTeam(ctx context.Context, team models.Team) (int
 
 // GetTeamByID is a mock implementation.
 func (m *mockTeamService) GetTeamByID(ctx context.Context, id int) (*models.Team, error) {
+	if m.GetTeamByIDFunc != nil {
+		return m.GetTeamByIDFunc(ctx, id)
+	}
 	return nil, nil
 }
 
 // UpdateTeamStatsAfterMatch is a mock implementation.
 func (m *mockTeamService) UpdateTeamStatsAfterMatch(ctx context.Context, teamID int, goalsScored int, goalsConceded int) error {
+	if m.UpdateTeamStatsAfterMatchFunc != nil {
+		return m.UpdateTeamStatsAfterMatchFunc(ctx, teamID, goalsScored, goalsConceded)
+	}
+	return nil
+}
+
+// RevertTeamStatsAfterMatch is a mock implementation.
+func (m *mockTeamService) RevertTeamStatsAfterMatch(ctx context.Context, teamID int, goalsScored int, goalsConceded int) error {
 	return nil
 }
 
 // ResetAllTeamStats is a mock implementation.
 func (m *mockTeamService) ResetAllTeamStats(ctx context.Context) error { return nil }
 
+// DeleteAllTeams is a mock implementation.
+func (m *mockTeamService) DeleteAllTeams(ctx context.Context) error { return nil }
+
+// DeleteTeam is a mock implementation.
+func (m *mockTeamService) DeleteTeam(ctx context.Context, teamID int) error { return nil }
+
 // AdjustTeamStatsForScoreChange is a mock implementation.
 func (m *mockTeamService) AdjustTeamStatsForScoreChange(ctx context.Context, teamID int, oldGS, oldGA, newGS, newGA int) error {
 	return nil
 }
 
 // UpdateTeamStrength is a mock implementation.
-func (m *mockTeamService) UpdateTeamStrength(ctx context.Context, teamID int, newStrength int) error {
+func (m *mockTeamService) UpdateTeamStrength(ctx context.Context, teamID int, newStrength int, expectedVersion int) error {
 	return nil
 }
 
 // UpdateTeamName is a mock implementation.
-func (m *mockTeamService) UpdateTeamName(ctx context.Context, teamID int, newName string) error {
+func (m *mockTeamService) UpdateTeamName(ctx context.Context, teamID int, newName string, expectedVersion int) error {
 	return nil
 }
 
-// ResetTeamsToDefaults is a mock implementation.
-func (m *mockTeamService) ResetTeamsToDefaults(ctx context.Context) error { return nil }
+// UpdateTeamDivision is a mock implementation.
+func (m *mockTeamService) UpdateTeamDivision(ctx context.Context, teamID int, division int, expectedVersion int) error {
+	return nil
+}
+
+// ApplyPointsAdjustment is a mock implementation.
+func (m *mockTeamService) ApplyPointsAdjustment(ctx context.Context, teamID int, points int, reason string) (models.PointsAdjustment, error) {
+	return models.PointsAdjustment{}, nil
+}
+
+// GetPointsAdjustments is a mock implementation.
+func (m *mockTeamService) GetPointsAdjustments(ctx context.Context, teamID int) ([]models.PointsAdjustment, error) {
+	return nil, nil
+}
 
 // GetAllTeams provides the mock implementation for ITeamService.GetAllTeams.
 // It calls GetAllTeamsFunc if defined, otherwise returns an error.
@@ -143,6 +175,12 @@ func (m *mockTeamService) GetAllTeams(ctx context.Context) ([]models.Team, error
 // mockMatchService is a mock implementation of the IMatchService interface.
 type mockMatchService struct {
 	// Add Func fields for IMatchService methods if they need to be mocked in other tests.
+	GetAllMatchesFunc           func(ctx context.Context) ([]models.Match, error)
+	GetMatchByIDFunc            func(ctx context.Context, id int) (*models.Match, error)
+	SetMatchForfeitFunc         func(ctx context.Context, matchID int, isForfeit bool) error
+	SimulateExtraTimeFunc       func(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (int, int, error)
+	SimulatePenaltyShootoutFunc func(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (int, int, error)
+	UpdateKnockoutResultFunc    func(ctx context.Context, matchID int, homeGoalsET, awayGoalsET, homePenalties, awayPenalties *int, winnerTeamID int) error
 }
 
 // Implement IMatchService methods (those not used can return nil or default values).
@@ -153,20 +191,62 @@ func (m *mockMatchService) GetMatchesByWeek(ctx context.Context, week int) ([]mo
 	return nil, nil
 }
 func (m *mockMatchService) GetMatchByID(ctx context.Context, id int) (*models.Match, error) {
+	if m.GetMatchByIDFunc != nil {
+		return m.GetMatchByIDFunc(ctx, id)
+	}
 	return nil, nil
 }
 func (m *mockMatchService) UpdateMatchResult(ctx context.Context, matchID int, homeGoals, awayGoals int, isPlayed bool) error {
 	return nil
 }
 func (m *mockMatchService) GetAllMatches(ctx context.Context) ([]models.Match, error) {
+	if m.GetAllMatchesFunc != nil {
+		return m.GetAllMatchesFunc(ctx)
+	}
 	return nil, nil
 }
 func (m *mockMatchService) SimulateMatchOutcome(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (int, int, error) {
 	return 0, 0, nil
 }
-func (m *mockMatchService) EditMatchScore(ctx context.Context, matchID int, newHomeGoals int, newAwayGoals int) (models.Match, error) {
+func (m *mockMatchService) EditMatchScore(ctx context.Context, matchID int, newHomeGoals int, newAwayGoals int, expectedVersion int) (models.Match, error) {
 	return models.Match{}, nil
 }
+func (m *mockMatchService) StoreMatches(ctx context.Context, matches []models.Match, replaceExisting bool) error {
+	return nil
+}
+func (m *mockMatchService) SimulateExtraTime(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (int, int, error) {
+	if m.SimulateExtraTimeFunc != nil {
+		return m.SimulateExtraTimeFunc(ctx, homeTeam, awayTeam)
+	}
+	return 0, 0, nil
+}
+func (m *mockMatchService) SimulatePenaltyShootout(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (int, int, error) {
+	if m.SimulatePenaltyShootoutFunc != nil {
+		return m.SimulatePenaltyShootoutFunc(ctx, homeTeam, awayTeam)
+	}
+	return 0, 0, nil
+}
+func (m *mockMatchService) UpdateKnockoutResult(ctx context.Context, matchID int, homeGoalsET, awayGoalsET, homePenalties, awayPenalties *int, winnerTeamID int) error {
+	if m.UpdateKnockoutResultFunc != nil {
+		return m.UpdateKnockoutResultFunc(ctx, matchID, homeGoalsET, awayGoalsET, homePenalties, awayPenalties, winnerTeamID)
+	}
+	return nil
+}
+func (m *mockMatchService) ClearMatchResult(ctx context.Context, matchID int) error {
+	return nil
+}
+func (m *mockMatchService) SetMatchForfeit(ctx context.Context, matchID int, isForfeit bool) error {
+	if m.SetMatchForfeitFunc != nil {
+		return m.SetMatchForfeitFunc(ctx, matchID, isForfeit)
+	}
+	return nil
+}
+func (m *mockMatchService) ArchiveSeason(ctx context.Context, playoffMatches []models.Match) (int, error) {
+	return 0, nil
+}
+func (m *mockMatchService) GetSeasonArchive(ctx context.Context, season int) (*models.SeasonArchive, error) {
+	return nil, nil
+}
 
 // TestLeagueService_GetLeagueTable tests the sorting logic of the GetLeagueTable method.
 func TestLeagueService_GetLeagueTable(t *testing.T) {
@@ -175,7 +255,7 @@ func TestLeagueService_GetLeagueTable(t *testing.T) {
 	mockMS := &mockMatchService{} // GetLeagueTable doesn't directly depend on MatchService, but LeagueService constructor needs it.
 
 	// Initialize LeagueService with mock dependencies
-	leagueService := NewLeagueService(mockTS, mockMS)
+	leagueService := NewLeagueService(mockTS, mockMS, nil)
 
 	testCases := []struct {
 		name          string
@@ -286,3 +366,433 @@ func TestLeagueService_GetLeagueTable(t *testing.T) {
 		})
 	}
 }
+
+// TestLeagueService_DecideKnockoutMatch checks that level knockout matches go to extra time and then penalties.
+func TestLeagueService_DecideKnockoutMatch(t *testing.T) {
+	home := models.Team{ID: 1, Name: "Home", Strength: 80}
+	away := models.Team{ID: 2, Name: "Away", Strength: 80}
+
+	testCases := []struct {
+		name            string
+		homeGoals       int
+		awayGoals       int
+		extraTime       [2]int
+		penalties       [2]int
+		expectedWinner  int
+		expectExtraTime bool
+		expectPenalties bool
+	}{
+		{name: "Decided In Regular Time", homeGoals: 1, awayGoals: 2, expectedWinner: 2},
+		{name: "Decided In Extra Time", homeGoals: 1, awayGoals: 1, extraTime: [2]int{1, 0}, expectedWinner: 1, expectExtraTime: true},
+		{name: "Decided On Penalties", homeGoals: 0, awayGoals: 0, extraTime: [2]int{1, 1}, penalties: [2]int{3, 4}, expectedWinner: 2, expectExtraTime: true, expectPenalties: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotWinner int
+			var gotET, gotPens bool
+			mockMS := &mockMatchService{
+				SimulateExtraTimeFunc: func(ctx context.Context, h, a models.Team) (int, int, error) {
+					return tc.extraTime[0], tc.extraTime[1], nil
+				},
+				SimulatePenaltyShootoutFunc: func(ctx context.Context, h, a models.Team) (int, int, error) {
+					return tc.penalties[0], tc.penalties[1], nil
+				},
+				UpdateKnockoutResultFunc: func(ctx context.Context, matchID int, homeET, awayET, homePens, awayPens *int, winnerTeamID int) error {
+					gotWinner = winnerTeamID
+					gotET = homeET != nil && awayET != nil
+					gotPens = homePens != nil && awayPens != nil
+					return nil
+				},
+			}
+			leagueService := &LeagueService{teamService: &mockTeamService{}, matchService: mockMS}
+
+			if err := leagueService.decideKnockoutMatch(context.Background(), 10, home, away, tc.homeGoals, tc.awayGoals); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if gotWinner != tc.expectedWinner {
+				t.Errorf("Expected winner %d, got %d", tc.expectedWinner, gotWinner)
+			}
+			if gotET != tc.expectExtraTime {
+				t.Errorf("Expected extra time recorded: %v, got %v", tc.expectExtraTime, gotET)
+			}
+			if gotPens != tc.expectPenalties {
+				t.Errorf("Expected penalties recorded: %v, got %v", tc.expectPenalties, gotPens)
+			}
+		})
+	}
+}
+
+// TestLeagueService_SettleKnockoutMatch_TwoLegs checks that first legs are never decided on their own
+// and that second legs are decided on the aggregate score.
+func TestLeagueService_SettleKnockoutMatch_TwoLegs(t *testing.T) {
+	higherSeed := models.Team{ID: 1, Name: "Higher Seed", Strength: 80}
+	lowerSeed := models.Team{ID: 2, Name: "Lower Seed", Strength: 80}
+	firstLegHome, firstLegAway := 2, 1 // Lower seed won the first leg 2-1 at home
+	firstLeg := models.Match{ID: 20, Week: 7, HomeTeamID: 2, AwayTeamID: 1, HomeGoals: &firstLegHome, AwayGoals: &firstLegAway, IsPlayed: true, Stage: models.StagePlayoff, Round: 1, Leg: 1}
+	secondLeg := models.Match{ID: 21, Week: 8, HomeTeamID: 1, AwayTeamID: 2, Stage: models.StagePlayoff, Round: 1, Leg: 2}
+
+	testCases := []struct {
+		name            string
+		match           models.Match
+		homeGoals       int
+		awayGoals       int
+		expectDecision  bool
+		expectedWinner  int
+		expectExtraTime bool
+	}{
+		{name: "First Leg Is Not Decided", match: firstLeg, homeGoals: 2, awayGoals: 1},
+		{name: "Higher Seed Wins On Aggregate", match: secondLeg, homeGoals: 2, awayGoals: 0, expectDecision: true, expectedWinner: 1},
+		{name: "Level On Aggregate Goes To Extra Time", match: secondLeg, homeGoals: 1, awayGoals: 0, expectDecision: true, expectedWinner: 2, expectExtraTime: true},
+		{name: "Lower Seed Wins Second Leg", match: secondLeg, homeGoals: 0, awayGoals: 1, expectDecision: true, expectedWinner: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decided := false
+			var gotWinner int
+			var gotET bool
+			mockMS := &mockMatchService{
+				GetAllMatchesFunc: func(ctx context.Context) ([]models.Match, error) {
+					return []models.Match{firstLeg, secondLeg}, nil
+				},
+				// Toplam skor eşitse uzatmada deplasman takımı (alt seri başı) kazanır
+				SimulateExtraTimeFunc: func(ctx context.Context, h, a models.Team) (int, int, error) {
+					return 0, 1, nil
+				},
+				UpdateKnockoutResultFunc: func(ctx context.Context, matchID int, homeET, awayET, homePens, awayPens *int, winnerTeamID int) error {
+					decided = true
+					gotWinner = winnerTeamID
+					gotET = homeET != nil
+					return nil
+				},
+			}
+			leagueService := &LeagueService{teamService: &mockTeamService{}, matchService: mockMS}
+
+			home, away := higherSeed, lowerSeed
+			if tc.match.Leg == 1 {
+				home, away = lowerSeed, higherSeed
+			}
+			if err := leagueService.settleKnockoutMatch(context.Background(), tc.match, home, away, tc.homeGoals, tc.awayGoals); err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if decided != tc.expectDecision {
+				t.Fatalf("Expected decision recorded: %v, got %v", tc.expectDecision, decided)
+			}
+			if gotWinner != tc.expectedWinner {
+				t.Errorf("Expected winner %d, got %d", tc.expectedWinner, gotWinner)
+			}
+			if gotET != tc.expectExtraTime {
+				t.Errorf("Expected extra time recorded: %v, got %v", tc.expectExtraTime, gotET)
+			}
+		})
+	}
+}
+
+// TestLeagueService_AwardForfeit checks that an unplayed league match is completed 3-0 for the awarded team,
+// its statistics are updated and the match is flagged as a forfeit.
+func TestLeagueService_AwardForfeit(t *testing.T) {
+	match := models.Match{ID: 5, Week: 3, HomeTeamID: 1, AwayTeamID: 2, Stage: models.StageLeague}
+
+	testCases := []struct {
+		name          string
+		winnerTeamID  int
+		expectedStats map[int][2]int // teamID -> {goalsScored, goalsConceded}
+		expectError   bool
+	}{
+		{name: "Awarded To Away Team", winnerTeamID: 2, expectedStats: map[int][2]int{1: {0, 3}, 2: {3, 0}}},
+		{name: "Awarded To Home Team", winnerTeamID: 1, expectedStats: map[int][2]int{1: {3, 0}, 2: {0, 3}}},
+		{name: "Team Not In Match", winnerTeamID: 9, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotStats := make(map[int][2]int)
+			forfeitFlagged := false
+			mockTS := &mockTeamService{
+				GetTeamByIDFunc: func(ctx context.Context, id int) (*models.Team, error) {
+					return &models.Team{ID: id, Name: "Team", Strength: 80}, nil
+				},
+				UpdateTeamStatsAfterMatchFunc: func(ctx context.Context, teamID int, goalsScored int, goalsConceded int) error {
+					gotStats[teamID] = [2]int{goalsScored, goalsConceded}
+					return nil
+				},
+			}
+			mockMS := &mockMatchService{
+				GetMatchByIDFunc: func(ctx context.Context, id int) (*models.Match, error) {
+					copied := match
+					return &copied, nil
+				},
+				SetMatchForfeitFunc: func(ctx context.Context, matchID int, isForfeit bool) error {
+					forfeitFlagged = isForfeit
+					return nil
+				},
+			}
+			leagueService := &LeagueService{teamService: mockTS, matchService: mockMS}
+
+			err := leagueService.AwardForfeit(context.Background(), match.ID, tc.winnerTeamID)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("Expected an error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if !reflect.DeepEqual(gotStats, tc.expectedStats) {
+				t.Errorf("Incorrect stat updates:\nExpected: %v\nGot:      %v", tc.expectedStats, gotStats)
+			}
+			if !forfeitFlagged {
+				t.Error("Expected the match to be flagged as a forfeit")
+			}
+		})
+	}
+}
+
+// TestClinchedChampion checks the mathematical title check, including points adjustments.
+func TestClinchedChampion(t *testing.T) {
+	// Every team has one league match left; the knockout match never counts towards the title race.
+	unplayed := []models.Match{
+		{ID: 1, HomeTeamID: 1, AwayTeamID: 2, Stage: models.StageLeague},
+		{ID: 2, HomeTeamID: 3, AwayTeamID: 4, Stage: models.StageLeague},
+		{ID: 3, HomeTeamID: 2, AwayTeamID: 3, Stage: models.StageKnockout},
+	}
+
+	testCases := []struct {
+		name     string
+		table    []models.Team
+		expected int
+	}{
+		{
+			name:     "Out Of Reach",
+			table:    []models.Team{{ID: 1, Points: 13}, {ID: 2, Points: 9}, {ID: 3, Points: 7}, {ID: 4, Points: 2}},
+			expected: 1,
+		},
+		{
+			name:     "Rival Can Draw Level",
+			table:    []models.Team{{ID: 1, Points: 12}, {ID: 2, Points: 9}, {ID: 3, Points: 7}, {ID: 4, Points: 2}},
+			expected: 0,
+		},
+		{
+			name:     "Deduction Reopens The Race",
+			table:    []models.Team{{ID: 1, Points: 10, Adjustment: -3}, {ID: 2, Points: 9}, {ID: 3, Points: 7}, {ID: 4, Points: 2}},
+			expected: 0,
+		},
+		{
+			name:     "Bonus Settles The Race",
+			table:    []models.Team{{ID: 1, Points: 13, Adjustment: 1}, {ID: 2, Points: 9}, {ID: 3, Points: 7}, {ID: 4, Points: 2}},
+			expected: 1,
+		},
+		{
+			name:     "Lower Division Ignored",
+			table:    []models.Team{{ID: 3, Points: 20, Division: 2}, {ID: 1, Points: 13}, {ID: 2, Points: 9}, {ID: 4, Points: 2, Division: 2}},
+			expected: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := clinchedChampion(tc.table, unplayed); got != tc.expected {
+				t.Errorf("clinchedChampion() = %d, want %d", got, tc.expected)
+			}
+		})
+	}
+}
+
+// blockingLeagueLocker holds the first caller inside TryLockLeague until release is closed,
+// and refuses the lock with ErrLeagueBusy when busy is set.
+type blockingLeagueLocker struct {
+	entered chan struct{}
+	release chan struct{}
+	busy    bool
+	calls   int
+}
+
+func (l *blockingLeagueLocker) TryLockLeague(ctx context.Context) (func(), error) {
+	l.calls++
+	if l.busy {
+		return nil, abstracts.ErrLeagueBusy
+	}
+	if l.calls == 1 && l.entered != nil {
+		close(l.entered)
+		<-l.release
+	}
+	return func() {}, nil
+}
+
+// TestLeagueService_LeagueLock checks that state-changing operations never run side by side.
+func TestLeagueService_LeagueLock(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Concurrent Operation Gets ErrLeagueBusy", func(t *testing.T) {
+		locker := &blockingLeagueLocker{entered: make(chan struct{}), release: make(chan struct{})}
+		teamService, matchService := NewMemoryTeamService(), NewMemoryMatchService()
+		createTeams(t, teamService, models.Team{Name: "A", Strength: 80}, models.Team{Name: "B", Strength: 70})
+		teams, _ := teamService.GetAllTeams(ctx)
+		if err := matchService.GenerateAndStoreFixture(ctx, teams); err != nil {
+			t.Fatalf("GenerateAndStoreFixture failed: %v", err)
+		}
+		leagueService := NewLeagueService(teamService, matchService, locker)
+
+		done := make(chan error)
+		go func() {
+			_, _, err := leagueService.PlayAllRemainingWeeks(ctx)
+			done <- err
+		}()
+		<-locker.entered
+
+		if _, _, _, err := leagueService.PlayNextWeek(ctx); !errors.Is(err, abstracts.ErrLeagueBusy) {
+			t.Errorf("PlayNextWeek while play-all runs: expected ErrLeagueBusy, got %v", err)
+		}
+		if err := leagueService.ResetLeague(ctx); !errors.Is(err, abstracts.ErrLeagueBusy) {
+			t.Errorf("ResetLeague while play-all runs: expected ErrLeagueBusy, got %v", err)
+		}
+
+		close(locker.release)
+		if err := <-done; err != nil {
+			t.Fatalf("PlayAllRemainingWeeks failed: %v", err)
+		}
+		if locker.calls != 1 {
+			t.Errorf("Expected the nested PlayNextWeek calls to reuse the lock (1 TryLockLeague call), got %d", locker.calls)
+		}
+		if err := leagueService.ResetLeague(ctx); err != nil {
+			t.Errorf("ResetLeague after the lock was released: unexpected error %v", err)
+		}
+	})
+
+	t.Run("Tournament And Pyramid Share The Lock", func(t *testing.T) {
+		locker := &blockingLeagueLocker{entered: make(chan struct{}), release: make(chan struct{})}
+		teamService, matchService := NewMemoryTeamService(), NewMemoryMatchService()
+		createTeams(t, teamService,
+			models.Team{Name: "A", Strength: 80, Division: 1}, models.Team{Name: "B", Strength: 70, Division: 1},
+			models.Team{Name: "C", Strength: 60, Division: 2}, models.Team{Name: "D", Strength: 50, Division: 2},
+		)
+		leagueService := NewLeagueService(teamService, matchService, locker)
+		tournamentService := NewTournamentService(teamService, matchService, leagueService)
+		pyramidService := NewPyramidService(teamService, matchService, leagueService, models.PyramidRules{PromotionSpots: 1, Playoffs: true, PlayoffTeams: 2})
+
+		done := make(chan error)
+		go func() {
+			done <- leagueService.ResetLeague(ctx)
+		}()
+		<-locker.entered
+
+		if _, err := tournamentService.CreateTournament(ctx, 1, nil); !errors.Is(err, abstracts.ErrLeagueBusy) {
+			t.Errorf("CreateTournament while the league is locked: expected ErrLeagueBusy, got %v", err)
+		}
+		if _, _, _, err := tournamentService.AdvanceTournament(ctx, 1); !errors.Is(err, abstracts.ErrLeagueBusy) {
+			t.Errorf("AdvanceTournament while the league is locked: expected ErrLeagueBusy, got %v", err)
+		}
+		if _, err := pyramidService.PlayPlayoffs(ctx); !errors.Is(err, abstracts.ErrLeagueBusy) {
+			t.Errorf("PlayPlayoffs while the league is locked: expected ErrLeagueBusy, got %v", err)
+		}
+		if _, err := pyramidService.EndSeason(ctx); !errors.Is(err, abstracts.ErrLeagueBusy) {
+			t.Errorf("EndSeason while the league is locked: expected ErrLeagueBusy, got %v", err)
+		}
+
+		close(locker.release)
+		if err := <-done; err != nil {
+			t.Fatalf("ResetLeague failed: %v", err)
+		}
+		if _, _, err := leagueService.PlayAllRemainingWeeks(ctx); err != nil {
+			t.Fatalf("PlayAllRemainingWeeks failed: %v", err)
+		}
+		callsBefore := locker.calls
+		if _, err := pyramidService.EndSeason(ctx); err != nil {
+			t.Fatalf("EndSeason after the lock was released: unexpected error %v", err)
+		}
+		if locker.calls != callsBefore+1 {
+			t.Errorf("Expected EndSeason's playoffs and reset to reuse its lock (1 TryLockLeague call), got %d", locker.calls-callsBefore)
+		}
+	})
+
+	t.Run("Lock Held By Another Instance", func(t *testing.T) {
+		locker := &blockingLeagueLocker{busy: true}
+		leagueService := NewLeagueService(NewMemoryTeamService(), NewMemoryMatchService(), locker)
+
+		if _, _, _, err := leagueService.PlayNextWeek(ctx); !errors.Is(err, abstracts.ErrLeagueBusy) {
+			t.Errorf("Expected ErrLeagueBusy, got %v", err)
+		}
+		// The in-process lock must have been given back, so the next attempt reaches the locker again.
+		if err := leagueService.AwardForfeit(ctx, 1, 1); !errors.Is(err, abstracts.ErrLeagueBusy) {
+			t.Errorf("Expected ErrLeagueBusy, got %v", err)
+		}
+		if locker.calls != 2 {
+			t.Errorf("Expected 2 TryLockLeague calls, got %d", locker.calls)
+		}
+	})
+}
+
+// TestLeagueService_Cancelled checks that a cancelled context (e.g. on server shutdown) stops the simulations before any match is played.
+func TestLeagueService_Cancelled(t *testing.T) {
+	teamService, matchService := NewMemoryTeamService(), NewMemoryMatchService()
+	createTeams(t, teamService, models.Team{Name: "A", Strength: 80}, models.Team{Name: "B", Strength: 70})
+	teams, _ := teamService.GetAllTeams(context.Background())
+	if err := matchService.GenerateAndStoreFixture(context.Background(), teams); err != nil {
+		t.Fatalf("GenerateAndStoreFixture failed: %v", err)
+	}
+	leagueService := NewLeagueService(teamService, matchService, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, _, err := leagueService.PlayNextWeek(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("PlayNextWeek: expected context.Canceled, got %v", err)
+	}
+	if _, _, err := leagueService.PlayAllRemainingWeeks(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("PlayAllRemainingWeeks: expected context.Canceled, got %v", err)
+	}
+	matches, _ := matchService.GetAllMatches(context.Background())
+	for _, match := range matches {
+		if match.IsPlayed {
+			t.Errorf("Match %d was played after the context was cancelled", match.ID)
+		}
+	}
+}
+
+// TestLeagueService_KnockoutEditsAfterNextRound checks that a knockout result can no longer be edited or forfeited
+// once the next round has been drawn from it, while the drawn round itself stays editable.
+func TestLeagueService_KnockoutEditsAfterNextRound(t *testing.T) {
+	ctx := context.Background()
+	teamService, matchService := NewMemoryTeamService(), NewMemoryMatchService()
+	ids := createTeams(t, teamService,
+		models.Team{Name: "A", Strength: 80}, models.Team{Name: "B", Strength: 70},
+		models.Team{Name: "C", Strength: 60}, models.Team{Name: "D", Strength: 50},
+	)
+	semiFinals := []models.Match{
+		{Week: 1, HomeTeamID: ids[0], AwayTeamID: ids[3], Stage: models.StageKnockout, Round: 1},
+		{Week: 1, HomeTeamID: ids[1], AwayTeamID: ids[2], Stage: models.StageKnockout, Round: 1},
+	}
+	if err := matchService.StoreMatches(ctx, semiFinals, true); err != nil {
+		t.Fatalf("StoreMatches failed: %v", err)
+	}
+	leagueService := NewLeagueService(teamService, matchService, nil)
+	if _, _, _, err := leagueService.PlayNextWeek(ctx); err != nil {
+		t.Fatalf("Could not play the semi-finals: %v", err)
+	}
+	played, _ := matchService.GetMatchesByWeek(ctx, 1)
+	semiFinal := played[0]
+	if err := leagueService.HandleMatchScoreEdit(ctx, semiFinal.ID, 0, 4, 0); err != nil {
+		t.Fatalf("Expected the semi-final to be editable before the final is drawn, got %v", err)
+	}
+
+	final := []models.Match{{Week: 2, HomeTeamID: *played[0].WinnerTeamID, AwayTeamID: *played[1].WinnerTeamID, Stage: models.StageKnockout, Round: 2}}
+	if err := matchService.StoreMatches(ctx, final, false); err != nil {
+		t.Fatalf("StoreMatches failed: %v", err)
+	}
+	before := mustGetMatch(t, matchService, semiFinal.ID)
+	if err := leagueService.HandleMatchScoreEdit(ctx, semiFinal.ID, 5, 0, 0); !errors.Is(err, abstracts.ErrCannotRevert) {
+		t.Errorf("HandleMatchScoreEdit after the final was drawn: expected ErrCannotRevert, got %v", err)
+	}
+	if err := leagueService.AwardForfeit(ctx, semiFinal.ID, semiFinal.HomeTeamID); !errors.Is(err, abstracts.ErrCannotRevert) {
+		t.Errorf("AwardForfeit after the final was drawn: expected ErrCannotRevert, got %v", err)
+	}
+	if after := mustGetMatch(t, matchService, semiFinal.ID); after.Version != before.Version || *after.WinnerTeamID != *before.WinnerTeamID {
+		t.Errorf("Expected the refused changes to leave the semi-final untouched, got %+v", after)
+	}
+
+	drawnFinal, _ := matchService.GetMatchesByWeek(ctx, 2)
+	if err := leagueService.AwardForfeit(ctx, drawnFinal[0].ID, drawnFinal[0].AwayTeamID); err != nil {
+		t.Errorf("Expected the final to accept a forfeit, got %v", err)
+	}
+}
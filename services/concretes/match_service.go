@@ -1,100 +1,106 @@
 package concretes
 
 import (
+	"MatchSimulator_Insider/database"
+	"MatchSimulator_Insider/logging"
 	"MatchSimulator_Insider/models"
 	"MatchSimulator_Insider/queries"
 	"MatchSimulator_Insider/services/abstracts"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
-	"math/rand"
+	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
 
 
 type PostgresMatchService struct {
-	DB *pgx.Conn
+	DB database.DB // Bağlantı havuzu; istek bir transaction içindeyse sorgular o transaction üzerinden çalışır
 }
 
 
-func NewPostgresMatchService(db *pgx.Conn) abstracts.IMatchService {
+func NewPostgresMatchService(db database.DB) abstracts.IMatchService {
 	return &PostgresMatchService{DB: db}
 }
 
+// conn, context'te bir transaction varsa onu, yoksa havuzu döndürür.
+func (s *PostgresMatchService) conn(ctx context.Context) database.DB {
+	return database.Conn(ctx, s.DB)
+}
+
 
 func (s *PostgresMatchService) GenerateAndStoreFixture(ctx context.Context, teams []models.Team) error {
-	if len(teams) != 4 {
-		return fmt.Errorf("PostgresMatchService.GenerateAndStoreFixture: Fixture generation is currently only supported for 4 teams, received: %d", len(teams))
+	if len(teams) < 2 {
+		return fmt.Errorf("PostgresMatchService.GenerateAndStoreFixture: At least 2 teams are required to generate a fixture, received: %d", len(teams))
 	}
-	_, err := s.DB.Exec(ctx, queries.DeleteAllMatchesSQL)
+
+	// her lig için ayrı çift devreli fikstür oluşturulur, eski fikstür silinerek yenisi yazılır
+	matchesToCreate, err := buildSeasonFixture(teams)
 	if err != nil {
-		return fmt.Errorf("PostgresMatchService.GenerateAndStoreFixture: Error clearing existing fixture: %w", err)
-	}
-
-	var matchesToCreate []models.Match
-	teamIDs := make([]int, len(teams))
-	for i, t := range teams {
-		teamIDs[i] = t.ID
-	}
-
-	// 6 hafta totalde 12 maçın fikstür yapısı
-	schedule := [][][2]int{
-		{{teamIDs[0], teamIDs[1]}, {teamIDs[2], teamIDs[3]}}, // 1. hafta
-		{{teamIDs[0], teamIDs[2]}, {teamIDs[1], teamIDs[3]}}, // 2. hafta
-		{{teamIDs[0], teamIDs[3]}, {teamIDs[1], teamIDs[2]}}, // 3. hafta
-		{{teamIDs[1], teamIDs[0]}, {teamIDs[3], teamIDs[2]}}, // 4. hafta
-		{{teamIDs[2], teamIDs[0]}, {teamIDs[3], teamIDs[1]}}, // 5. hafta
-		{{teamIDs[3], teamIDs[0]}, {teamIDs[2], teamIDs[1]}}, // 6. hafta
-	}
-
-	currentWeek := 1
-
-	// iç içe for döngüsüyle her maç ayrı ayrı bilgileriyle matchesToCreate slice'ına yazdırılır
-	for _, weeklyMatches := range schedule {
-		for _, matchPair := range weeklyMatches {
-			matchesToCreate = append(matchesToCreate, models.Match{
-				Week:       currentWeek,
-				HomeTeamID: matchPair[0],
-				AwayTeamID: matchPair[1],
-				IsPlayed:   false,
-				HomeGoals:  nil, // henüz oynanmadı
-				AwayGoals:  nil, // henüz oynanmadı
-			})
-		}
-		currentWeek++
+		return fmt.Errorf("PostgresMatchService.GenerateAndStoreFixture: %w", err)
+	}
+	if err := s.StoreMatches(ctx, matchesToCreate, true); err != nil {
+		return fmt.Errorf("PostgresMatchService.GenerateAndStoreFixture: %w", err)
 	}
+	return nil
+}
 
+// Maçları tek bir transaction içinde veritabanına yazar. replaceExisting true ise önce mevcut fikstür silinir.
+func (s *PostgresMatchService) StoreMatches(ctx context.Context, matches []models.Match, replaceExisting bool) error {
 	// transaction başlatılır
-	tx, err := s.DB.Begin(ctx)
+	tx, err := s.conn(ctx).Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("PostgresMatchService.GenerateAndStoreFixture: Could not begin transaction: %w", err)
+		return fmt.Errorf("PostgresMatchService.StoreMatches: Could not begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
+	if replaceExisting {
+		if _, err = tx.Exec(ctx, queries.DeleteAllMatchesSQL); err != nil {
+			return fmt.Errorf("PostgresMatchService.StoreMatches: Error clearing existing fixture: %w", err)
+		}
+	}
+
 	// maçlar veritabanına insert edilir
-	for _, match := range matchesToCreate {
+	for _, match := range matches {
+		stage := match.Stage
+		if stage == "" {
+			stage = models.StageLeague
+		}
 		_, err = tx.Exec(ctx, queries.InsertMatchSQL,
 			match.Week, match.HomeTeamID, match.AwayTeamID,
 			match.IsPlayed, match.HomeGoals, match.AwayGoals,
+			stage, match.GroupName, match.Round, match.Leg,
 		)
 		if err != nil {
-			return fmt.Errorf("PostgresMatchService.GenerateAndStoreFixture: Error adding match for week %d (%d vs %d): %w", match.Week, match.HomeTeamID, match.AwayTeamID, err)
+			return fmt.Errorf("PostgresMatchService.StoreMatches: Error adding match for week %d (%d vs %d): %w", match.Week, match.HomeTeamID, match.AwayTeamID, err)
 		}
 	}
 
 	if err = tx.Commit(ctx); err != nil {
-		return fmt.Errorf("PostgresMatchService.GenerateAndStoreFixture: Could not commit transaction: %w", err)
+		return fmt.Errorf("PostgresMatchService.StoreMatches: Could not commit transaction: %w", err)
 	}
 	return nil
 }
 
+// pgx ve database/sql satırları için ortak maç okuma yardımcısı; kolon sırası queries paketindeki SELECT'lerle aynıdır.
+func scanMatch(row pgx.Row, match *models.Match) error {
+	return row.Scan(
+		&match.ID, &match.Week, &match.HomeTeamID, &match.AwayTeamID,
+		&match.HomeGoals, &match.AwayGoals, &match.IsPlayed,
+		&match.Stage, &match.GroupName, &match.Round, &match.Leg,
+		&match.HomeGoalsET, &match.AwayGoalsET, &match.HomePenalties, &match.AwayPenalties, &match.WinnerTeamID,
+		&match.IsForfeit, &match.Version,
+	)
+}
+
 // Belirli bir haftanın maçlarını getirir
 func (s *PostgresMatchService) GetMatchesByWeek(ctx context.Context, week int) ([]models.Match, error) {
 	
 	// maçlar veritabanından çekilir
-	rows, err := s.DB.Query(ctx, queries.GetMatchesByWeekSQL, week)
+	rows, err := s.conn(ctx).Query(ctx, queries.GetMatchesByWeekSQL, week)
 	if err != nil {
 		return nil, fmt.Errorf("PostgresMatchService.GetMatchesByWeek: Error retrieving matches for week %d: %w", week, err)
 	}
@@ -105,10 +111,7 @@ func (s *PostgresMatchService) GetMatchesByWeek(ctx context.Context, week int) (
 	// rows nesnesi üstünden match verileri alınır ve matches slice'ına yazdırılır.
 	for rows.Next() {
 		var match models.Match
-		if err := rows.Scan(
-			&match.ID, &match.Week, &match.HomeTeamID, &match.AwayTeamID,
-			&match.HomeGoals, &match.AwayGoals, &match.IsPlayed,
-		); err != nil {
+		if err := scanMatch(rows, &match); err != nil {
 			return nil, fmt.Errorf("PostgresMatchService.GetMatchesByWeek: Error scanning match row: %w", err)
 		}
 		matches = append(matches, match)
@@ -124,13 +127,10 @@ func (s *PostgresMatchService) GetMatchesByWeek(ctx context.Context, week int) (
 // doğrudan models.Match olarak döndürseydi gerçekten boş bir maç mı döndü yoksa maç mı bulunamadı ayrımını yapmak daha zor olurdu.
 func (s *PostgresMatchService) GetMatchByID(ctx context.Context, id int) (*models.Match, error) {
 	var match models.Match
-	err := s.DB.QueryRow(ctx, queries.GetMatchByIDSQL, id).Scan(
-		&match.ID, &match.Week, &match.HomeTeamID, &match.AwayTeamID,
-		&match.HomeGoals, &match.AwayGoals, &match.IsPlayed,
-	)
+	err := scanMatch(s.conn(ctx).QueryRow(ctx, queries.GetMatchByIDSQL, id), &match)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("PostgresMatchService.GetMatchByID: Match with ID %d not found", id)
+			return nil, fmt.Errorf("PostgresMatchService.GetMatchByID: %w (ID: %d)", abstracts.ErrMatchNotFound, id)
 		}
 		return nil, fmt.Errorf("PostgresMatchService.GetMatchByID: Error retrieving match (ID: %d): %w", id, err)
 	}
@@ -139,19 +139,19 @@ func (s *PostgresMatchService) GetMatchByID(ctx context.Context, id int) (*model
 
 
 func (s *PostgresMatchService) UpdateMatchResult(ctx context.Context, matchID int, homeGoals, awayGoals int, isPlayed bool) error {
-	cmdTag, err := s.DB.Exec(ctx, queries.UpdateMatchResultSQL, homeGoals, awayGoals, isPlayed, matchID)
+	cmdTag, err := s.conn(ctx).Exec(ctx, queries.UpdateMatchResultSQL, homeGoals, awayGoals, isPlayed, matchID)
 	if err != nil {
 		return fmt.Errorf("PostgresMatchService.UpdateMatchResult: Error updating match result (ID: %d): %w", matchID, err)
 	}
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("PostgresMatchService.UpdateMatchResult: Match (ID: %d) not found or not updated", matchID)
+		return fmt.Errorf("PostgresMatchService.UpdateMatchResult: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
 	}
 	return nil
 }
 
 // veritabanındaki tüm maçları hafta ve id ye göre sıralı şekilde alır
 func (s *PostgresMatchService) GetAllMatches(ctx context.Context) ([]models.Match, error) {
-	rows, err := s.DB.Query(ctx, queries.GetAllMatchesSQL)
+	rows, err := s.conn(ctx).Query(ctx, queries.GetAllMatchesSQL)
 	if err != nil {
 		return nil, fmt.Errorf("PostgresMatchService.GetAllMatches: Error retrieving all matches: %w", err)
 	}
@@ -160,10 +160,7 @@ func (s *PostgresMatchService) GetAllMatches(ctx context.Context) ([]models.Matc
 	var matches []models.Match
 	for rows.Next() {
 		var match models.Match
-		if err := rows.Scan(
-			&match.ID, &match.Week, &match.HomeTeamID, &match.AwayTeamID,
-			&match.HomeGoals, &match.AwayGoals, &match.IsPlayed,
-		); err != nil {
+		if err := scanMatch(rows, &match); err != nil {
 			return nil, fmt.Errorf("PostgresMatchService.GetAllMatches: Error scanning match row: %w", err)
 		}
 		matches = append(matches, match)
@@ -176,8 +173,26 @@ func (s *PostgresMatchService) GetAllMatches(ctx context.Context) ([]models.Matc
 
 // İki takım arasındaki oynanan maçları simüle eder
 func (s *PostgresMatchService) SimulateMatchOutcome(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (homeGoals int, awayGoals int, err error) {
-	
 	maxPotentialGoals := 6 // Atılabilecek maksimum potansiyel gol (her iki takım için ayrı ayrı)
+	homeGoals, awayGoals = simulateGoals(sharedRandom{}, homeTeam, awayTeam, maxPotentialGoals)
+	return homeGoals, awayGoals, nil
+}
+
+// Uzatma devrelerini simüle eder. 30 dakika normal sürenin üçte biri olduğu için gol şansı da üçte birdir.
+func (s *PostgresMatchService) SimulateExtraTime(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (homeGoals int, awayGoals int, err error) {
+	maxPotentialGoals := 2
+	homeGoals, awayGoals = simulateGoals(sharedRandom{}, homeTeam, awayTeam, maxPotentialGoals)
+	return homeGoals, awayGoals, nil
+}
+
+// Penaltı atışlarını tek tek simüle eder.
+func (s *PostgresMatchService) SimulatePenaltyShootout(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (homeScore int, awayScore int, err error) {
+	homeScore, awayScore = simulatePenaltyShootout(sharedRandom{}, homeTeam, awayTeam)
+	return homeScore, awayScore, nil
+}
+
+// simulateGoals, her iki takım için maxPotentialGoals kadar gol şansı üretir. Rastgele sayılar rng'den çekilir.
+func simulateGoals(rng matchRandom, homeTeam models.Team, awayTeam models.Team, maxPotentialGoals int) (homeGoals int, awayGoals int) {
 	strengthDivisor := 140
 	homeAdvantage := 10 // Ev sahibi takım için +10 bonus strength verilir
 
@@ -195,35 +210,173 @@ func (s *PostgresMatchService) SimulateMatchOutcome(ctx context.Context, homeTea
 	// gol hesaplama
 	for i := 0; i < maxPotentialGoals; i++ {
 		// strengthDivisor ile rastgele bir sayı üretilir (0-140) bu sayı efektif güçten düşükse takım gol attı kabul edilir
-		if rand.Intn(strengthDivisor) < effectiveHomeStrength {
+		if rng.Intn(strengthDivisor) < effectiveHomeStrength {
 			homeGoals++
 		}
 		
-		if rand.Intn(strengthDivisor) < effectiveAwayStrength {
+		if rng.Intn(strengthDivisor) < effectiveAwayStrength {
 			awayGoals++
 		}
 	}
 	
-	return homeGoals, awayGoals, nil
+	return homeGoals, awayGoals
 }
 
+// penaltyConversionChance, bir takımın penaltıyı gole çevirme ihtimalini verir (güç 1 -> ~%60, güç 100 -> %80).
+func penaltyConversionChance(team models.Team) float64 {
+	strength := team.Strength
+	if strength < 0 {
+		strength = 0
+	}
+	if strength > 100 {
+		strength = 100
+	}
+	return 0.60 + float64(strength)/500
+}
 
-func (s *PostgresMatchService) EditMatchScore(ctx context.Context, matchID int, newHomeGoals int, newAwayGoals int) (originalMatch models.Match, err error) {
-	log.Printf("PostgresMatchService.EditMatchScore: Initiating score edit for Match ID %d. New score: %d-%d", matchID, newHomeGoals, newAwayGoals)
+// simulatePenaltyShootout, önce beşer atış yaptırır; bir takım artık yetişemeyecekse atışlar erken biter.
+// Beş atış sonunda eşitlik varsa ani ölüm turlarına geçilir.
+func simulatePenaltyShootout(rng matchRandom, homeTeam models.Team, awayTeam models.Team) (homeScore int, awayScore int) {
+	const regulationKicks = 5
+	homeChance := penaltyConversionChance(homeTeam)
+	awayChance := penaltyConversionChance(awayTeam)
 
-	
+	for kick := 1; kick <= regulationKicks; kick++ {
+		if rng.Float64() < homeChance {
+			homeScore++
+		}
+		// Deplasman takımı kalan atışlarının hepsini atsa bile yetişemiyorsa atışlar biter
+		if homeScore > awayScore+(regulationKicks-kick+1) || awayScore > homeScore+(regulationKicks-kick) {
+			return homeScore, awayScore
+		}
+		if rng.Float64() < awayChance {
+			awayScore++
+		}
+		if homeScore > awayScore+(regulationKicks-kick) || awayScore > homeScore+(regulationKicks-kick) {
+			return homeScore, awayScore
+		}
+	}
+
+	// Ani ölüm: iki takım da birer atış yapar, biri atıp diğeri kaçırana kadar devam edilir
+	for homeScore == awayScore {
+		homeScored := rng.Float64() < homeChance
+		awayScored := rng.Float64() < awayChance
+		if homeScored {
+			homeScore++
+		}
+		if awayScored {
+			awayScore++
+		}
+	}
+	return homeScore, awayScore
+}
+
+// Eleme maçının uzatma/penaltı sonucunu ve tur atlayan takımı kaydeder.
+func (s *PostgresMatchService) UpdateKnockoutResult(ctx context.Context, matchID int, homeGoalsET, awayGoalsET, homePenalties, awayPenalties *int, winnerTeamID int) error {
+	cmdTag, err := s.conn(ctx).Exec(ctx, queries.UpdateKnockoutResultSQL, homeGoalsET, awayGoalsET, homePenalties, awayPenalties, winnerTeamID, matchID)
+	if err != nil {
+		return fmt.Errorf("PostgresMatchService.UpdateKnockoutResult: Error updating knockout result (ID: %d): %w", matchID, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("PostgresMatchService.UpdateKnockoutResult: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	return nil
+}
+
+// ClearMatchResult, maçı oynanmamış hâle getirir. Takım istatistiklerini geri almak çağıranın işidir.
+func (s *PostgresMatchService) ClearMatchResult(ctx context.Context, matchID int) error {
+	cmdTag, err := s.conn(ctx).Exec(ctx, queries.ClearMatchResultSQL, matchID)
+	if err != nil {
+		return fmt.Errorf("PostgresMatchService.ClearMatchResult: Error clearing match result (ID: %d): %w", matchID, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("PostgresMatchService.ClearMatchResult: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	return nil
+}
+
+// Maçın hükmen sonuçlandığını işaretler veya işareti kaldırır.
+func (s *PostgresMatchService) SetMatchForfeit(ctx context.Context, matchID int, isForfeit bool) error {
+	cmdTag, err := s.conn(ctx).Exec(ctx, queries.UpdateMatchForfeitSQL, isForfeit, matchID)
+	if err != nil {
+		return fmt.Errorf("PostgresMatchService.SetMatchForfeit: Error updating forfeit flag (ID: %d): %w", matchID, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("PostgresMatchService.SetMatchForfeit: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	return nil
+}
+
+
+func (s *PostgresMatchService) EditMatchScore(ctx context.Context, matchID int, newHomeGoals int, newAwayGoals int, expectedVersion int) (originalMatch models.Match, err error) {
 	originalMatchPtr, err := s.GetMatchByID(ctx, matchID)
 	if err != nil {
 		return models.Match{}, fmt.Errorf("PostgresMatchService.EditMatchScore: Could not find or retrieve match to edit (ID: %d): %w", matchID, err)
 	}
 	originalMatch = *originalMatchPtr
+	if expectedVersion != 0 && originalMatch.Version != expectedVersion {
+		return models.Match{}, versionConflict("PostgresMatchService.EditMatchScore", "Match", matchID, expectedVersion, originalMatch.Version)
+	}
 
-	err = s.UpdateMatchResult(ctx, matchID, newHomeGoals, newAwayGoals, true)
+	// Skor yalnızca okunan sürüm hâlâ güncelse yazılır; arada başka bir düzenleme olduysa hiçbir satır etkilenmez
+	cmdTag, err := s.conn(ctx).Exec(ctx, queries.EditMatchScoreSQL, newHomeGoals, newAwayGoals, matchID, originalMatch.Version)
 	if err != nil {
-		
 		return originalMatch, fmt.Errorf("PostgresMatchService.EditMatchScore: Error updating score for match (ID: %d): %w", matchID, err)
 	}
+	if cmdTag.RowsAffected() == 0 {
+		return models.Match{}, fmt.Errorf("PostgresMatchService.EditMatchScore: Match (ID: %d) changed while it was being edited: %w", matchID, abstracts.ErrVersionConflict)
+	}
 
-	log.Printf("PostgresMatchService.EditMatchScore: Score for Match ID %d successfully updated to %d-%d.", matchID, newHomeGoals, newAwayGoals)
+	slog.DebugContext(ctx, "match score updated", logging.KeyMatchID, matchID, "home_goals", newHomeGoals, "away_goals", newAwayGoals)
 	return originalMatch, nil
 }
+
+// ArchiveSeason, kapanan sezonun play-off maçlarını arşive yazar ve sezon numarasını döndürür.
+func (s *PostgresMatchService) ArchiveSeason(ctx context.Context, playoffMatches []models.Match) (int, error) {
+	encoded, closedAt, err := encodeSeasonArchive(playoffMatches)
+	if err != nil {
+		return 0, fmt.Errorf("PostgresMatchService.ArchiveSeason: %w", err)
+	}
+	var season int
+	if err := s.conn(ctx).QueryRow(ctx, queries.InsertSeasonArchiveSQL, encoded, closedAt).Scan(&season); err != nil {
+		return 0, fmt.Errorf("PostgresMatchService.ArchiveSeason: Error archiving season: %w", err)
+	}
+	return season, nil
+}
+
+// GetSeasonArchive, arşivlenmiş bir sezonu döndürür; season 0 en son kapanan sezondur.
+func (s *PostgresMatchService) GetSeasonArchive(ctx context.Context, season int) (*models.SeasonArchive, error) {
+	archive, err := scanSeasonArchive(s.conn(ctx).QueryRow(ctx, queries.GetSeasonArchiveSQL, season))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("PostgresMatchService.GetSeasonArchive: %w (season: %d)", abstracts.ErrSeasonNotFound, season)
+		}
+		return nil, fmt.Errorf("PostgresMatchService.GetSeasonArchive: Error retrieving season %d: %w", season, err)
+	}
+	return archive, nil
+}
+
+// encodeSeasonArchive, play-off maçlarını arşivde saklanan JSON biçimine çevirir ve kapanış zamanını (UTC) döndürür.
+func encodeSeasonArchive(playoffMatches []models.Match) (string, time.Time, error) {
+	if playoffMatches == nil {
+		playoffMatches = []models.Match{}
+	}
+	encoded, err := json.Marshal(playoffMatches)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("Could not encode playoff matches: %w", err)
+	}
+	return string(encoded), time.Now().UTC().Truncate(time.Microsecond), nil
+}
+
+// scanSeasonArchive, season_archive satırını okur. PostgreSQL ve SQLite servisleri birlikte kullanır.
+func scanSeasonArchive(row pgx.Row) (*models.SeasonArchive, error) {
+	var archive models.SeasonArchive
+	var encoded string
+	if err := row.Scan(&archive.Season, &encoded, &archive.ClosedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(encoded), &archive.PlayoffMatches); err != nil {
+		return nil, fmt.Errorf("Could not decode the playoff matches of season %d: %w", archive.Season, err)
+	}
+	return &archive, nil
+}
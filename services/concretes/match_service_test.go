@@ -88,3 +88,47 @@ func TestPostgresMatchService_SimulateMatchOutcome(t *testing.T) {
 		})
 	}
 }
+
+// TestSimulatePenaltyShootout checks that every shootout produces a winner with a plausible score.
+func TestSimulatePenaltyShootout(t *testing.T) {
+	testCases := []struct {
+		name     string
+		homeTeam models.Team
+		awayTeam models.Team
+	}{
+		{name: "Equal Strengths", homeTeam: models.Team{ID: 1, Strength: 80}, awayTeam: models.Team{ID: 2, Strength: 80}},
+		{name: "Strong vs Weak", homeTeam: models.Team{ID: 3, Strength: 100}, awayTeam: models.Team{ID: 4, Strength: 1}},
+		{name: "Weak vs Strong", homeTeam: models.Team{ID: 5, Strength: 1}, awayTeam: models.Team{ID: 6, Strength: 100}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 200; i++ {
+				homeScore, awayScore := simulatePenaltyShootout(sharedRandom{}, tc.homeTeam, tc.awayTeam)
+				if homeScore == awayScore {
+					t.Fatalf("Shootout ended level: %d-%d", homeScore, awayScore)
+				}
+				// Beş atıştan sonra yalnızca ani ölüm devam eder, dolayısıyla fark bir olmalıdır
+				if (homeScore > 5 || awayScore > 5) && (homeScore-awayScore > 1 || awayScore-homeScore > 1) {
+					t.Errorf("Sudden death shootout ended with a margin greater than one: %d-%d", homeScore, awayScore)
+				}
+			}
+		})
+	}
+}
+
+// TestPostgresMatchService_SimulateExtraTime checks the bounds of extra-time goals.
+func TestPostgresMatchService_SimulateExtraTime(t *testing.T) {
+	var dummyDbConn *pgx.Conn
+	service := NewPostgresMatchService(dummyDbConn)
+
+	for i := 0; i < 50; i++ {
+		homeGoals, awayGoals, err := service.SimulateExtraTime(context.Background(), models.Team{ID: 1, Strength: 100}, models.Team{ID: 2, Strength: 100})
+		if err != nil {
+			t.Fatalf("SimulateExtraTime returned an error: %v", err)
+		}
+		if homeGoals < 0 || awayGoals < 0 || homeGoals > 2 || awayGoals > 2 {
+			t.Errorf("Extra-time goals out of range: %d-%d", homeGoals, awayGoals)
+		}
+	}
+}
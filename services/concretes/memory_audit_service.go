@@ -0,0 +1,67 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// MemoryAuditService keeps audit entries in memory in the order they were recorded.
+type MemoryAuditService struct {
+	mu      sync.RWMutex
+	entries []models.AuditEntry
+	nextID  int
+}
+
+// NewMemoryAuditService creates an empty in-memory audit log.
+func NewMemoryAuditService() abstracts.AuditService {
+	return &MemoryAuditService{nextID: 1}
+}
+
+// Record stores the entry and returns it with its ID and timestamp.
+func (s *MemoryAuditService) Record(ctx context.Context, entry models.AuditEntry) (models.AuditEntry, error) {
+	entry, err := prepareAuditEntry(entry)
+	if err != nil {
+		return models.AuditEntry{}, fmt.Errorf("MemoryAuditService.Record: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry.ID = s.nextID
+	s.nextID++
+	s.entries = append(s.entries, entry)
+	return entry, nil
+}
+
+// GetByID returns a single audit entry.
+func (s *MemoryAuditService) GetByID(ctx context.Context, id int) (*models.AuditEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, entry := range s.entries {
+		if entry.ID == id {
+			return &entry, nil
+		}
+	}
+	return nil, fmt.Errorf("MemoryAuditService.GetByID: %w (ID: %d)", abstracts.ErrAuditEntryNotFound, id)
+}
+
+// List returns the entries matching filter, oldest first.
+func (s *MemoryAuditService) List(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var entries []models.AuditEntry
+	for _, entry := range s.entries {
+		if filter.EntityType != "" && entry.EntityType != filter.EntityType {
+			continue
+		}
+		if filter.EntityID != 0 && entry.EntityID != filter.EntityID {
+			continue
+		}
+		if entry.CreatedAt.Before(filter.Since) || (!filter.Until.IsZero() && !entry.CreatedAt.Before(filter.Until)) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,288 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/logging"
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryMatchService keeps the fixture in memory. It uses the same fixture builder and match engine as
+// PostgresMatchService and is safe for concurrent use.
+type MemoryMatchService struct {
+	mu          sync.RWMutex
+	matches     map[int]*models.Match
+	nextMatchID int
+	seasons     []models.SeasonArchive // index i holds season i+1
+}
+
+// NewMemoryMatchService creates an empty in-memory match store.
+func NewMemoryMatchService() abstracts.IMatchService {
+	return &MemoryMatchService{
+		matches:     make(map[int]*models.Match),
+		nextMatchID: 1,
+	}
+}
+
+// GenerateAndStoreFixture replaces the fixture with a new double round-robin per division.
+func (s *MemoryMatchService) GenerateAndStoreFixture(ctx context.Context, teams []models.Team) error {
+	if len(teams) < 2 {
+		return fmt.Errorf("MemoryMatchService.GenerateAndStoreFixture: At least 2 teams are required to generate a fixture, received: %d", len(teams))
+	}
+	matchesToCreate, err := buildSeasonFixture(teams)
+	if err != nil {
+		return fmt.Errorf("MemoryMatchService.GenerateAndStoreFixture: %w", err)
+	}
+	if err := s.StoreMatches(ctx, matchesToCreate, true); err != nil {
+		return fmt.Errorf("MemoryMatchService.GenerateAndStoreFixture: %w", err)
+	}
+	return nil
+}
+
+// StoreMatches adds the matches with fresh IDs. With replaceExisting the current fixture is dropped first.
+func (s *MemoryMatchService) StoreMatches(ctx context.Context, matches []models.Match, replaceExisting bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if replaceExisting {
+		s.matches = make(map[int]*models.Match)
+	}
+	for _, match := range matches {
+		stored := models.Match{
+			ID:         s.nextMatchID,
+			Week:       match.Week,
+			HomeTeamID: match.HomeTeamID,
+			AwayTeamID: match.AwayTeamID,
+			HomeGoals:  copyIntPtr(match.HomeGoals),
+			AwayGoals:  copyIntPtr(match.AwayGoals),
+			IsPlayed:   match.IsPlayed,
+			Stage:      match.Stage,
+			GroupName:  match.GroupName,
+			Round:      match.Round,
+			Leg:        match.Leg,
+			Version:    1,
+		}
+		if stored.Stage == "" {
+			stored.Stage = models.StageLeague
+		}
+		s.matches[stored.ID] = &stored
+		s.nextMatchID++
+	}
+	return nil
+}
+
+// GetMatchesByWeek returns the week's matches ordered by ID.
+func (s *MemoryMatchService) GetMatchesByWeek(ctx context.Context, week int) ([]models.Match, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matches []models.Match
+	for _, match := range s.sortedLocked() {
+		if match.Week == week {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// GetMatchByID returns a copy of the match.
+func (s *MemoryMatchService) GetMatchByID(ctx context.Context, id int) (*models.Match, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	match, ok := s.matches[id]
+	if !ok {
+		return nil, fmt.Errorf("MemoryMatchService.GetMatchByID: %w (ID: %d)", abstracts.ErrMatchNotFound, id)
+	}
+	result := copyMatch(*match)
+	return &result, nil
+}
+
+// UpdateMatchResult stores the score and played flag; a new score clears the forfeit flag.
+func (s *MemoryMatchService) UpdateMatchResult(ctx context.Context, matchID int, homeGoals, awayGoals int, isPlayed bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	match, ok := s.matches[matchID]
+	if !ok {
+		return fmt.Errorf("MemoryMatchService.UpdateMatchResult: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	match.HomeGoals = &homeGoals
+	match.AwayGoals = &awayGoals
+	match.IsPlayed = isPlayed
+	match.IsForfeit = false
+	match.Version++
+	return nil
+}
+
+// GetAllMatches returns every match ordered by week and ID.
+func (s *MemoryMatchService) GetAllMatches(ctx context.Context) ([]models.Match, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sortedLocked(), nil
+}
+
+// SimulateMatchOutcome plays 90 minutes with the shared match engine.
+func (s *MemoryMatchService) SimulateMatchOutcome(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (homeGoals int, awayGoals int, err error) {
+	homeGoals, awayGoals = simulateGoals(sharedRandom{}, homeTeam, awayTeam, 6)
+	return homeGoals, awayGoals, nil
+}
+
+// SimulateExtraTime plays 30 minutes of extra time with the shared match engine.
+func (s *MemoryMatchService) SimulateExtraTime(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (homeGoals int, awayGoals int, err error) {
+	homeGoals, awayGoals = simulateGoals(sharedRandom{}, homeTeam, awayTeam, 2)
+	return homeGoals, awayGoals, nil
+}
+
+// SimulatePenaltyShootout plays a kick-by-kick shootout with the shared match engine.
+func (s *MemoryMatchService) SimulatePenaltyShootout(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (homeScore int, awayScore int, err error) {
+	homeScore, awayScore = simulatePenaltyShootout(sharedRandom{}, homeTeam, awayTeam)
+	return homeScore, awayScore, nil
+}
+
+// UpdateKnockoutResult stores the knockout deciders and the team that advanced.
+func (s *MemoryMatchService) UpdateKnockoutResult(ctx context.Context, matchID int, homeGoalsET, awayGoalsET, homePenalties, awayPenalties *int, winnerTeamID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	match, ok := s.matches[matchID]
+	if !ok {
+		return fmt.Errorf("MemoryMatchService.UpdateKnockoutResult: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	match.HomeGoalsET = copyIntPtr(homeGoalsET)
+	match.AwayGoalsET = copyIntPtr(awayGoalsET)
+	match.HomePenalties = copyIntPtr(homePenalties)
+	match.AwayPenalties = copyIntPtr(awayPenalties)
+	match.WinnerTeamID = &winnerTeamID
+	match.Version++
+	return nil
+}
+
+// ClearMatchResult marks the match unplayed and removes its score, deciders and forfeit flag.
+func (s *MemoryMatchService) ClearMatchResult(ctx context.Context, matchID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	match, ok := s.matches[matchID]
+	if !ok {
+		return fmt.Errorf("MemoryMatchService.ClearMatchResult: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	match.HomeGoals, match.AwayGoals = nil, nil
+	match.HomeGoalsET, match.AwayGoalsET = nil, nil
+	match.HomePenalties, match.AwayPenalties = nil, nil
+	match.WinnerTeamID = nil
+	match.IsPlayed = false
+	match.IsForfeit = false
+	match.Version++
+	return nil
+}
+
+// SetMatchForfeit sets or clears the forfeit flag of a match.
+func (s *MemoryMatchService) SetMatchForfeit(ctx context.Context, matchID int, isForfeit bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	match, ok := s.matches[matchID]
+	if !ok {
+		return fmt.Errorf("MemoryMatchService.SetMatchForfeit: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	match.IsForfeit = isForfeit
+	match.Version++
+	return nil
+}
+
+// EditMatchScore stores a new score for the match and returns the match as it was before the edit.
+func (s *MemoryMatchService) EditMatchScore(ctx context.Context, matchID int, newHomeGoals int, newAwayGoals int, expectedVersion int) (originalMatch models.Match, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	match, ok := s.matches[matchID]
+	if !ok {
+		return models.Match{}, fmt.Errorf("MemoryMatchService.EditMatchScore: Could not find or retrieve match to edit: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	if expectedVersion != 0 && match.Version != expectedVersion {
+		return models.Match{}, versionConflict("MemoryMatchService.EditMatchScore", "Match", matchID, expectedVersion, match.Version)
+	}
+	originalMatch = copyMatch(*match)
+	match.HomeGoals = &newHomeGoals
+	match.AwayGoals = &newAwayGoals
+	match.IsPlayed = true
+	match.IsForfeit = false
+	match.Version++
+	slog.DebugContext(ctx, "match score updated", logging.KeyMatchID, matchID, "home_goals", newHomeGoals, "away_goals", newAwayGoals)
+	return originalMatch, nil
+}
+
+// ArchiveSeason keeps copies of the closed season's playoff matches and returns the season number.
+func (s *MemoryMatchService) ArchiveSeason(ctx context.Context, playoffMatches []models.Match) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	archive := models.SeasonArchive{Season: len(s.seasons) + 1, PlayoffMatches: copyMatches(playoffMatches), ClosedAt: time.Now().UTC()}
+	s.seasons = append(s.seasons, archive)
+	return archive.Season, nil
+}
+
+// GetSeasonArchive returns an archived season; season 0 is the most recently closed one.
+func (s *MemoryMatchService) GetSeasonArchive(ctx context.Context, season int) (*models.SeasonArchive, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if season == 0 {
+		season = len(s.seasons)
+	}
+	if season < 1 || season > len(s.seasons) {
+		return nil, fmt.Errorf("MemoryMatchService.GetSeasonArchive: %w (season: %d)", abstracts.ErrSeasonNotFound, season)
+	}
+	archive := s.seasons[season-1]
+	archive.PlayoffMatches = copyMatches(archive.PlayoffMatches)
+	return &archive, nil
+}
+
+// sortedLocked returns copies of all matches ordered by week and ID. The caller must hold the lock.
+func (s *MemoryMatchService) sortedLocked() []models.Match {
+	matches := make([]models.Match, 0, len(s.matches))
+	for _, match := range s.matches {
+		matches = append(matches, copyMatch(*match))
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Week != matches[j].Week {
+			return matches[i].Week < matches[j].Week
+		}
+		return matches[i].ID < matches[j].ID
+	})
+	return matches
+}
+
+// copyMatch returns a deep copy so callers never share score pointers with the store.
+func copyMatch(match models.Match) models.Match {
+	match.HomeGoals = copyIntPtr(match.HomeGoals)
+	match.AwayGoals = copyIntPtr(match.AwayGoals)
+	match.HomeGoalsET = copyIntPtr(match.HomeGoalsET)
+	match.AwayGoalsET = copyIntPtr(match.AwayGoalsET)
+	match.HomePenalties = copyIntPtr(match.HomePenalties)
+	match.AwayPenalties = copyIntPtr(match.AwayPenalties)
+	match.WinnerTeamID = copyIntPtr(match.WinnerTeamID)
+	return match
+}
+
+// copyMatches deep-copies every match; the result is never nil.
+func copyMatches(matches []models.Match) []models.Match {
+	copied := make([]models.Match, 0, len(matches))
+	for _, match := range matches {
+		copied = append(copied, copyMatch(match))
+	}
+	return copied
+}
+
+// copyIntPtr returns a pointer to a copy of *value, or nil.
+func copyIntPtr(value *int) *int {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
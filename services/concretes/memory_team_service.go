@@ -0,0 +1,322 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryTeamService keeps teams and points adjustments in memory. It follows the same rules as
+// PostgresTeamService (unique names, table ordering, adjusted points) and is safe for concurrent use.
+type MemoryTeamService struct {
+	mu               sync.RWMutex
+	teams            map[int]*models.Team // Points alanı yalnızca maç puanlarını tutar; düzeltme Adjustment alanındadır
+	adjustments      []models.PointsAdjustment
+	nextTeamID       int
+	nextAdjustmentID int
+}
+
+// NewMemoryTeamService creates an empty in-memory team store.
+func NewMemoryTeamService() abstracts.TeamService {
+	return &MemoryTeamService{
+		teams:            make(map[int]*models.Team),
+		nextTeamID:       1,
+		nextAdjustmentID: 1,
+	}
+}
+
+// CreateTeam adds a team with zeroed stats, or returns the ID of an existing team with the same name.
+func (s *MemoryTeamService) CreateTeam(ctx context.Context, team models.Team) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing := s.findByNameLocked(team.Name); existing != nil {
+		return existing.ID, nil
+	}
+	division := team.Division
+	if division < 1 {
+		division = 1
+	}
+	created := models.Team{ID: s.nextTeamID, Name: team.Name, Strength: team.Strength, Division: division, Version: 1}
+	s.teams[created.ID] = &created
+	s.nextTeamID++
+	return created.ID, nil
+}
+
+// GetTeamByID returns a copy of the team with its adjusted points.
+func (s *MemoryTeamService) GetTeamByID(ctx context.Context, id int) (*models.Team, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	team, ok := s.teams[id]
+	if !ok {
+		return nil, fmt.Errorf("MemoryTeamService.GetTeamByID: %w (ID: %d)", abstracts.ErrTeamNotFound, id)
+	}
+	result := withAdjustedPoints(*team)
+	return &result, nil
+}
+
+// GetAllTeams returns all teams in league table order: points, goal difference, goals scored, then name.
+func (s *MemoryTeamService) GetAllTeams(ctx context.Context) ([]models.Team, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teams := s.snapshotLocked()
+	sort.SliceStable(teams, func(i, j int) bool {
+		if teams[i].Points != teams[j].Points {
+			return teams[i].Points > teams[j].Points
+		}
+		if teams[i].GoalDifference != teams[j].GoalDifference {
+			return teams[i].GoalDifference > teams[j].GoalDifference
+		}
+		if teams[i].GoalsFor != teams[j].GoalsFor {
+			return teams[i].GoalsFor > teams[j].GoalsFor
+		}
+		return teams[i].Name < teams[j].Name
+	})
+	return teams, nil
+}
+
+// UpdateTeamStatsAfterMatch records one played match for the team.
+func (s *MemoryTeamService) UpdateTeamStatsAfterMatch(ctx context.Context, teamID int, goalsScored int, goalsConceded int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, ok := s.teams[teamID]
+	if !ok {
+		return fmt.Errorf("MemoryTeamService.UpdateTeamStatsAfterMatch: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	updateTeamStatsInMemory(team, goalsScored, goalsConceded)
+	team.Version++
+	return nil
+}
+
+// RevertTeamStatsAfterMatch removes a result added by UpdateTeamStatsAfterMatch from the team's statistics.
+func (s *MemoryTeamService) RevertTeamStatsAfterMatch(ctx context.Context, teamID int, goalsScored int, goalsConceded int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, ok := s.teams[teamID]
+	if !ok {
+		return fmt.Errorf("MemoryTeamService.RevertTeamStatsAfterMatch: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	points, wins, draws, losses := calculateOutcomeMetrics(goalsScored, goalsConceded)
+	team.Played--
+	team.Wins -= wins
+	team.Draws -= draws
+	team.Losses -= losses
+	team.GoalsFor -= goalsScored
+	team.GoalsAgainst -= goalsConceded
+	team.GoalDifference = team.GoalsFor - team.GoalsAgainst
+	team.Points -= points
+	team.Version++
+	return nil
+}
+
+// ResetAllTeamStats zeroes every team's statistics and clears the season's points adjustments.
+func (s *MemoryTeamService) ResetAllTeamStats(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, team := range s.teams {
+		*team = models.Team{ID: team.ID, Name: team.Name, Strength: team.Strength, Division: team.Division, Version: team.Version + 1}
+	}
+	s.adjustments = nil
+	slog.DebugContext(ctx, "team statistics reset", "rows_affected", len(s.teams))
+	return nil
+}
+
+// DeleteAllTeams removes every team and points adjustment. Matches live in the match store,
+// so unlike the database backends the caller replaces them itself.
+func (s *MemoryTeamService) DeleteAllTeams(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.teams = make(map[int]*models.Team)
+	s.adjustments = nil
+	return nil
+}
+
+// DeleteTeam removes the team and its points adjustments. As with DeleteAllTeams the caller replaces the matches.
+func (s *MemoryTeamService) DeleteTeam(ctx context.Context, teamID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[teamID]; !ok {
+		return fmt.Errorf("MemoryTeamService.DeleteTeam: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	delete(s.teams, teamID)
+	var kept []models.PointsAdjustment
+	for _, adjustment := range s.adjustments {
+		if adjustment.TeamID != teamID {
+			kept = append(kept, adjustment)
+		}
+	}
+	s.adjustments = kept
+	return nil
+}
+
+// AdjustTeamStatsForScoreChange replaces the team's contribution from an old score with a new one.
+// Like the Postgres implementation it leaves the played count untouched.
+func (s *MemoryTeamService) AdjustTeamStatsForScoreChange(ctx context.Context, teamID int, oldGoalsForTeam, oldGoalsAgainstTeam, newGoalsForTeam, newGoalsAgainstTeam int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, ok := s.teams[teamID]
+	if !ok {
+		return fmt.Errorf("AdjustTeamStatsForScoreChange: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	oldPoints, oldWins, oldDraws, oldLosses := calculateOutcomeMetrics(oldGoalsForTeam, oldGoalsAgainstTeam)
+	newPoints, newWins, newDraws, newLosses := calculateOutcomeMetrics(newGoalsForTeam, newGoalsAgainstTeam)
+
+	team.Wins += newWins - oldWins
+	team.Draws += newDraws - oldDraws
+	team.Losses += newLosses - oldLosses
+	team.GoalsFor += newGoalsForTeam - oldGoalsForTeam
+	team.GoalsAgainst += newGoalsAgainstTeam - oldGoalsAgainstTeam
+	team.Points += newPoints - oldPoints
+	team.GoalDifference = team.GoalsFor - team.GoalsAgainst
+	team.Version++
+	return nil
+}
+
+// UpdateTeamStrength sets the team's strength (1-100).
+func (s *MemoryTeamService) UpdateTeamStrength(ctx context.Context, teamID int, newStrength int, expectedVersion int) error {
+	if newStrength < 1 || newStrength > 100 {
+		return fmt.Errorf("%w: %d. Strength must be between 1 and 100", abstracts.ErrInvalidStrength, newStrength)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, ok := s.teams[teamID]
+	if !ok {
+		return fmt.Errorf("MemoryTeamService.UpdateTeamStrength: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	if expectedVersion != 0 && team.Version != expectedVersion {
+		return versionConflict("MemoryTeamService.UpdateTeamStrength", "Team", teamID, expectedVersion, team.Version)
+	}
+	team.Strength = newStrength
+	team.Version++
+	return nil
+}
+
+// UpdateTeamName renames the team; names must be unique.
+func (s *MemoryTeamService) UpdateTeamName(ctx context.Context, teamID int, newName string, expectedVersion int) error {
+	trimmedName, err := normalizeTeamName(newName)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing := s.findByNameLocked(trimmedName); existing != nil && existing.ID != teamID {
+		return fmt.Errorf("%w: '%s' is already in use by team ID %d", abstracts.ErrDuplicateName, trimmedName, existing.ID)
+	}
+	team, ok := s.teams[teamID]
+	if !ok {
+		return fmt.Errorf("MemoryTeamService.UpdateTeamName: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	if expectedVersion != 0 && team.Version != expectedVersion {
+		return versionConflict("MemoryTeamService.UpdateTeamName", "Team", teamID, expectedVersion, team.Version)
+	}
+	team.Name = trimmedName
+	team.Version++
+	return nil
+}
+
+// UpdateTeamDivision moves the team to another division; 1 is the top division.
+func (s *MemoryTeamService) UpdateTeamDivision(ctx context.Context, teamID int, division int, expectedVersion int) error {
+	if division < 1 {
+		return fmt.Errorf("%w: division %d. Division must be 1 or greater", abstracts.ErrInvalidInput, division)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, ok := s.teams[teamID]
+	if !ok {
+		return fmt.Errorf("MemoryTeamService.UpdateTeamDivision: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	if expectedVersion != 0 && team.Version != expectedVersion {
+		return versionConflict("MemoryTeamService.UpdateTeamDivision", "Team", teamID, expectedVersion, team.Version)
+	}
+	team.Division = division
+	team.Version++
+	return nil
+}
+
+// ApplyPointsAdjustment records a points deduction or bonus for the team.
+func (s *MemoryTeamService) ApplyPointsAdjustment(ctx context.Context, teamID int, points int, reason string) (models.PointsAdjustment, error) {
+	adjustment := models.PointsAdjustment{TeamID: teamID, Points: points, Reason: strings.TrimSpace(reason)}
+	if err := validatePointsAdjustment(adjustment); err != nil {
+		return models.PointsAdjustment{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, ok := s.teams[teamID]
+	if !ok {
+		return models.PointsAdjustment{}, fmt.Errorf("MemoryTeamService.ApplyPointsAdjustment: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	adjustment.ID = s.nextAdjustmentID
+	adjustment.CreatedAt = time.Now()
+	s.nextAdjustmentID++
+	s.adjustments = append(s.adjustments, adjustment)
+	team.Adjustment += points
+	team.Version++
+	return adjustment, nil
+}
+
+// GetPointsAdjustments returns the adjustments in the order they were applied; teamID 0 returns all teams.
+func (s *MemoryTeamService) GetPointsAdjustments(ctx context.Context, teamID int) ([]models.PointsAdjustment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var adjustments []models.PointsAdjustment
+	for _, adjustment := range s.adjustments {
+		if teamID == 0 || adjustment.TeamID == teamID {
+			adjustments = append(adjustments, adjustment)
+		}
+	}
+	return adjustments, nil
+}
+
+// findByNameLocked returns the team with the given name. The caller must hold the lock.
+func (s *MemoryTeamService) findByNameLocked(name string) *models.Team {
+	for _, team := range s.teams {
+		if team.Name == name {
+			return team
+		}
+	}
+	return nil
+}
+
+// orderedByIDLocked returns pointers to the stored teams in ID order. The caller must hold the lock.
+func (s *MemoryTeamService) orderedByIDLocked() []*models.Team {
+	ordered := make([]*models.Team, 0, len(s.teams))
+	for _, team := range s.teams {
+		ordered = append(ordered, team)
+	}
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].ID < ordered[j].ID })
+	return ordered
+}
+
+// snapshotLocked returns copies of all teams in ID order with adjusted points. The caller must hold the lock.
+func (s *MemoryTeamService) snapshotLocked() []models.Team {
+	teams := make([]models.Team, 0, len(s.teams))
+	for _, team := range s.orderedByIDLocked() {
+		teams = append(teams, withAdjustedPoints(*team))
+	}
+	return teams
+}
+
+// withAdjustedPoints returns the team as it is read from storage, with points adjustments included in Points.
+func withAdjustedPoints(team models.Team) models.Team {
+	team.Points += team.Adjustment
+	return team
+}
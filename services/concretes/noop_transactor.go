@@ -0,0 +1,21 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+)
+
+// NoopTransactor runs fn directly without a surrounding transaction. It is used by the in-memory
+// backend: the memory services guard their state with a mutex, so each service call is applied
+// atomically, but a unit of work that fails halfway keeps the changes made before the failure.
+type NoopTransactor struct{}
+
+// NewNoopTransactor creates a transactor that does not open transactions.
+func NewNoopTransactor() abstracts.Transactor {
+	return NoopTransactor{}
+}
+
+// RunInTx calls fn with ctx unchanged.
+func (NoopTransactor) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
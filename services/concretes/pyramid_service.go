@@ -0,0 +1,345 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/logging"
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+// PyramidService links several divisions into a pyramid with promotion and relegation between adjacent tiers.
+type PyramidService struct {
+	teamService   abstracts.TeamService
+	matchService  abstracts.IMatchService
+	leagueService abstracts.ILeagueService
+	rules         models.PyramidRules
+}
+
+// NewPyramidService creates a new instance of PyramidService.
+func NewPyramidService(ts abstracts.TeamService, ms abstracts.IMatchService, ls abstracts.ILeagueService, rules models.PyramidRules) abstracts.IPyramidService {
+	return &PyramidService{
+		teamService:   ts,
+		matchService:  ms,
+		leagueService: ls,
+		rules:         rules,
+	}
+}
+
+// GetDivisionTables splits the league table into one table per division, top division first.
+func (s *PyramidService) GetDivisionTables(ctx context.Context) ([]models.DivisionTable, error) {
+	table, err := s.leagueService.GetLeagueTable(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("PyramidService.GetDivisionTables: %w", err)
+	}
+	return splitTableByDivision(table), nil
+}
+
+// GetSeasonArchive returns a season closed by EndSeason, with its playoff matches; season 0 is the latest one.
+func (s *PyramidService) GetSeasonArchive(ctx context.Context, season int) (*models.SeasonArchive, error) {
+	archive, err := s.matchService.GetSeasonArchive(ctx, season)
+	if err != nil {
+		return nil, fmt.Errorf("PyramidService.GetSeasonArchive: %w", err)
+	}
+	return archive, nil
+}
+
+// GetPlayoffMatches returns the promotion playoff matches recorded for the current season, in playing order.
+func (s *PyramidService) GetPlayoffMatches(ctx context.Context) ([]models.Match, error) {
+	allMatches, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("PyramidService.GetPlayoffMatches: Could not retrieve matches: %w", err)
+	}
+	return filterMatchesByStage(allMatches, models.StagePlayoff), nil
+}
+
+// PlayPlayoffs generates and plays the promotion playoffs of a finished season without moving any team,
+// so the playoff matches can be inspected before EndSeason applies the movements.
+func (s *PyramidService) PlayPlayoffs(ctx context.Context) ([]models.Match, error) {
+	if !s.rules.Playoffs {
+		return nil, fmt.Errorf("PyramidService.PlayPlayoffs: %w: playoffs are disabled in the pyramid rules", abstracts.ErrInvalidInput)
+	}
+	ctx, unlock, err := lockLeagueVia(ctx, s.leagueService)
+	if err != nil {
+		return nil, fmt.Errorf("PyramidService.PlayPlayoffs: %w", err)
+	}
+	defer unlock()
+
+	tables, err := s.finishedSeasonTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("PyramidService.PlayPlayoffs: %w", err)
+	}
+	if len(tables) > 1 {
+		if _, err := s.playPromotionPlayoffs(ctx, tables); err != nil {
+			return nil, fmt.Errorf("PyramidService.PlayPlayoffs: %w", err)
+		}
+	}
+	return s.GetPlayoffMatches(ctx)
+}
+
+// EndSeason closes a finished season. If playoffs are enabled they are generated and played first,
+// then the bottom teams of each division swap with the top teams of the division below and
+// the league is reset so every division starts the next season with a fresh fixture.
+// The playoff matches are archived with the season before the new fixture replaces them.
+func (s *PyramidService) EndSeason(ctx context.Context) ([]models.DivisionMovement, error) {
+	ctx, unlock, err := lockLeagueVia(ctx, s.leagueService)
+	if err != nil {
+		return nil, fmt.Errorf("PyramidService.EndSeason: %w", err)
+	}
+	defer unlock()
+
+	tables, err := s.finishedSeasonTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("PyramidService.EndSeason: %w", err)
+	}
+
+	playoffWinners := make(map[int]int)
+	if s.rules.Playoffs && len(tables) > 1 {
+		playoffWinners, err = s.playPromotionPlayoffs(ctx, tables)
+		if err != nil {
+			return nil, fmt.Errorf("PyramidService.EndSeason: %w", err)
+		}
+	}
+
+	playoffMatches, err := s.GetPlayoffMatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("PyramidService.EndSeason: %w", err)
+	}
+	season, err := s.matchService.ArchiveSeason(ctx, playoffMatches)
+	if err != nil {
+		return nil, fmt.Errorf("PyramidService.EndSeason: Error archiving the season: %w", err)
+	}
+	slog.InfoContext(ctx, "season archived", "season", season, "playoff_matches", len(playoffMatches))
+
+	movements := divisionMovements(tables, s.rules, playoffWinners)
+	for _, movement := range movements {
+		if err := s.teamService.UpdateTeamDivision(ctx, movement.TeamID, movement.ToDivision, 0); err != nil {
+			return nil, fmt.Errorf("PyramidService.EndSeason: Error moving team %s to division %d: %w", movement.TeamName, movement.ToDivision, err)
+		}
+		slog.InfoContext(ctx, "team changes division", logging.KeyTeamID, movement.TeamID, "name", movement.TeamName, "from_league_id", movement.FromDivision, logging.KeyLeagueID, movement.ToDivision)
+	}
+
+	if err := s.leagueService.ResetLeague(ctx); err != nil {
+		return movements, fmt.Errorf("PyramidService.EndSeason: Teams were moved but the next season could not be started: %w", err)
+	}
+	slog.InfoContext(ctx, "season closed, next season's fixtures generated", "division_changes", len(movements))
+	return movements, nil
+}
+
+// finishedSeasonTables validates the pyramid rules, checks that every league match has been played
+// and returns the final division tables.
+func (s *PyramidService) finishedSeasonTables(ctx context.Context) ([]models.DivisionTable, error) {
+	if s.rules.PromotionSpots < 1 {
+		return nil, fmt.Errorf("%w: promotion spots must be at least 1, configured: %d", abstracts.ErrInvalidInput, s.rules.PromotionSpots)
+	}
+	if size := playoffSize(s.rules); s.rules.Playoffs && (size < 2 || size&(size-1) != 0) {
+		return nil, fmt.Errorf("%w: playoff teams must be a power of two (2, 4, 8...), configured: %d", abstracts.ErrInvalidInput, size)
+	}
+
+	allMatches, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Could not retrieve matches: %w", err)
+	}
+	for _, match := range allMatches {
+		if match.Stage == models.StageLeague && !match.IsPlayed {
+			return nil, fmt.Errorf("%w: the season has unplayed matches (match ID %d in week %d)", abstracts.ErrStageNotFinished, match.ID, match.Week)
+		}
+	}
+
+	tables, err := s.GetDivisionTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDivisionSizes(tables, s.rules); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// playPromotionPlayoffs plays the promotion playoff of every lower division round by round through the
+// normal match engine and returns the winner per division. Rounds that already exist are reused, so an
+// interrupted end of season continues where it stopped.
+func (s *PyramidService) playPromotionPlayoffs(ctx context.Context, tables []models.DivisionTable) (map[int]int, error) {
+	divisionByTeam := make(map[int]int)
+	for _, table := range tables {
+		for _, team := range table.Standings {
+			divisionByTeam[team.ID] = table.Division
+		}
+	}
+
+	winners := make(map[int]int)
+	// 8 takımlı bir play-off en fazla 3 tur sürer; fazladan bir tur yarıda kalmış play-off'lar içindir
+	for pass := 0; pass <= 4; pass++ {
+		allMatches, err := s.matchService.GetAllMatches(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve playoff matches: %w", err)
+		}
+		nextWeek := 1
+		playoffByDivision := make(map[int][]models.Match)
+		for _, match := range allMatches {
+			if match.Week >= nextWeek {
+				nextWeek = match.Week + 1
+			}
+			if match.Stage == models.StagePlayoff {
+				division := divisionByTeam[match.HomeTeamID]
+				playoffByDivision[division] = append(playoffByDivision[division], match)
+			}
+		}
+
+		var toCreate []models.Match
+		for _, table := range tables[1:] {
+			if _, decided := winners[table.Division]; decided {
+				continue
+			}
+			pairings, round, winnerID, errRound := nextPlayoffRound(table, playoffByDivision[table.Division], s.rules)
+			if errRound != nil {
+				return nil, fmt.Errorf("division %d playoff: %w", table.Division, errRound)
+			}
+			if winnerID != 0 {
+				winners[table.Division] = winnerID
+				continue
+			}
+			// Final her zaman tek maçtır
+			twoLegged := s.rules.TwoLeggedPlayoffs && len(pairings) > 1
+			toCreate = append(toCreate, buildKnockoutRound(pairings, models.StagePlayoff, round, nextWeek, twoLegged)...)
+		}
+		if len(winners) == len(tables)-1 {
+			return winners, nil
+		}
+
+		if len(toCreate) > 0 {
+			if err := s.matchService.StoreMatches(ctx, toCreate, false); err != nil {
+				return nil, fmt.Errorf("error storing playoff matches: %w", err)
+			}
+			slog.InfoContext(ctx, "promotion playoff matches scheduled", "matches", len(toCreate), logging.KeyWeek, nextWeek)
+		}
+		if _, _, err := s.leagueService.PlayAllRemainingWeeks(ctx); err != nil {
+			return nil, fmt.Errorf("error playing playoff matches: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("playoffs could not be completed")
+}
+
+// nextPlayoffRound works out the next step of a division's playoff from its existing playoff matches.
+// Without matches the first round is seeded from the table; once the latest round is finished its winners
+// are paired for the next round, or the single remaining team is returned as the playoff winner.
+// Nil pairings with no winner mean the latest round still has unplayed matches.
+func nextPlayoffRound(table models.DivisionTable, playoffMatches []models.Match, rules models.PyramidRules) (pairings [][2]int, round int, winnerID int, err error) {
+	if len(playoffMatches) == 0 {
+		first := rules.PromotionSpots - 1
+		var seeds []int
+		for _, team := range table.Standings[first : first+playoffSize(rules)] {
+			seeds = append(seeds, team.ID)
+		}
+		return seededPairings(seeds), 1, 0, nil
+	}
+
+	lastRound := 0
+	for _, match := range playoffMatches {
+		if match.Round > lastRound {
+			lastRound = match.Round
+		}
+	}
+	var roundMatches []models.Match
+	for _, match := range playoffMatches {
+		if match.Round != lastRound {
+			continue
+		}
+		if !match.IsPlayed {
+			return nil, lastRound, 0, nil
+		}
+		roundMatches = append(roundMatches, match)
+	}
+	winners, err := tieWinners(roundMatches)
+	if err != nil {
+		return nil, lastRound, 0, err
+	}
+	if len(winners) == 1 {
+		return nil, lastRound, winners[0], nil
+	}
+	for i := 0; i+1 < len(winners); i += 2 {
+		pairings = append(pairings, [2]int{winners[i], winners[i+1]})
+	}
+	return pairings, lastRound + 1, 0, nil
+}
+
+// playoffSize returns how many teams contest each promotion playoff. Two teams play a one-off final.
+func playoffSize(rules models.PyramidRules) int {
+	if rules.PlayoffTeams == 0 {
+		return 2
+	}
+	return rules.PlayoffTeams
+}
+
+// splitTableByDivision groups a sorted league table into per-division tables, keeping the ranking order.
+func splitTableByDivision(table []models.Team) []models.DivisionTable {
+	standingsByDivision := make(map[int][]models.Team)
+	for _, team := range table {
+		standingsByDivision[divisionOf(team)] = append(standingsByDivision[divisionOf(team)], team)
+	}
+	var divisions []int
+	for division := range standingsByDivision {
+		divisions = append(divisions, division)
+	}
+	sort.Ints(divisions)
+
+	tables := make([]models.DivisionTable, 0, len(divisions))
+	for _, division := range divisions {
+		tables = append(tables, models.DivisionTable{Division: division, Standings: standingsByDivision[division]})
+	}
+	return tables
+}
+
+// validateDivisionSizes checks that every division has enough teams for the configured swaps.
+func validateDivisionSizes(tables []models.DivisionTable, rules models.PyramidRules) error {
+	for i, table := range tables {
+		// Bir takım aynı sezonda hem yükselip hem düşemeyeceği için ara ligler iki tarafı da karşılamalıdır
+		required := 0
+		if i > 0 {
+			if rules.Playoffs {
+				required += rules.PromotionSpots - 1 + playoffSize(rules)
+			} else {
+				required += rules.PromotionSpots
+			}
+		}
+		if i < len(tables)-1 {
+			required += rules.PromotionSpots
+		}
+		if len(table.Standings) < required {
+			return fmt.Errorf("%w: division %d has %d teams but the pyramid rules need at least %d", abstracts.ErrInvalidInput, table.Division, len(table.Standings), required)
+		}
+	}
+	return nil
+}
+
+// divisionMovements works out which teams change division. The bottom PromotionSpots teams of each division
+// go down; the top teams of the division below go up, with the last spot taken by the playoff winner if any.
+func divisionMovements(tables []models.DivisionTable, rules models.PyramidRules, playoffWinners map[int]int) []models.DivisionMovement {
+	var movements []models.DivisionMovement
+	for i := 0; i+1 < len(tables); i++ {
+		upper, lower := tables[i], tables[i+1]
+
+		for _, team := range upper.Standings[len(upper.Standings)-rules.PromotionSpots:] {
+			movements = append(movements, models.DivisionMovement{TeamID: team.ID, TeamName: team.Name, FromDivision: upper.Division, ToDivision: lower.Division})
+		}
+
+		automatic := rules.PromotionSpots
+		winnerID, hasPlayoff := playoffWinners[lower.Division]
+		if hasPlayoff {
+			automatic--
+		}
+		for _, team := range lower.Standings[:automatic] {
+			movements = append(movements, models.DivisionMovement{TeamID: team.ID, TeamName: team.Name, FromDivision: lower.Division, ToDivision: upper.Division})
+		}
+		if hasPlayoff {
+			for _, team := range lower.Standings {
+				if team.ID == winnerID {
+					movements = append(movements, models.DivisionMovement{TeamID: team.ID, TeamName: team.Name, FromDivision: lower.Division, ToDivision: upper.Division, ViaPlayoff: true})
+				}
+			}
+		}
+	}
+	return movements
+}
@@ -0,0 +1,136 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/models"
+	"reflect"
+	"testing"
+)
+
+// TestSplitTableByDivision checks that a sorted league table is split per division without losing its order.
+func TestSplitTableByDivision(t *testing.T) {
+	table := []models.Team{
+		{ID: 1, Points: 9, Division: 2},
+		{ID: 2, Points: 7, Division: 1},
+		{ID: 3, Points: 4, Division: 2},
+		{ID: 4, Points: 1, Division: 1},
+	}
+
+	tables := splitTableByDivision(table)
+
+	expected := []models.DivisionTable{
+		{Division: 1, Standings: []models.Team{{ID: 2, Points: 7, Division: 1}, {ID: 4, Points: 1, Division: 1}}},
+		{Division: 2, Standings: []models.Team{{ID: 1, Points: 9, Division: 2}, {ID: 3, Points: 4, Division: 2}}},
+	}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("Incorrect division tables:\nExpected: %+v\nGot:      %+v", expected, tables)
+	}
+}
+
+// TestDivisionMovements covers direct promotion/relegation and the playoff spot.
+func TestDivisionMovements(t *testing.T) {
+	tables := []models.DivisionTable{
+		{Division: 1, Standings: []models.Team{{ID: 1, Name: "D1-1"}, {ID: 2, Name: "D1-2"}, {ID: 3, Name: "D1-3"}, {ID: 4, Name: "D1-4"}}},
+		{Division: 2, Standings: []models.Team{{ID: 5, Name: "D2-1"}, {ID: 6, Name: "D2-2"}, {ID: 7, Name: "D2-3"}, {ID: 8, Name: "D2-4"}}},
+	}
+
+	testCases := []struct {
+		name           string
+		rules          models.PyramidRules
+		playoffWinners map[int]int
+		expected       []models.DivisionMovement
+	}{
+		{
+			name:  "Two Direct Swaps",
+			rules: models.PyramidRules{PromotionSpots: 2},
+			expected: []models.DivisionMovement{
+				{TeamID: 3, TeamName: "D1-3", FromDivision: 1, ToDivision: 2},
+				{TeamID: 4, TeamName: "D1-4", FromDivision: 1, ToDivision: 2},
+				{TeamID: 5, TeamName: "D2-1", FromDivision: 2, ToDivision: 1},
+				{TeamID: 6, TeamName: "D2-2", FromDivision: 2, ToDivision: 1},
+			},
+		},
+		{
+			name:           "Last Spot Decided By Playoff",
+			rules:          models.PyramidRules{PromotionSpots: 2, Playoffs: true},
+			playoffWinners: map[int]int{2: 7},
+			expected: []models.DivisionMovement{
+				{TeamID: 3, TeamName: "D1-3", FromDivision: 1, ToDivision: 2},
+				{TeamID: 4, TeamName: "D1-4", FromDivision: 1, ToDivision: 2},
+				{TeamID: 5, TeamName: "D2-1", FromDivision: 2, ToDivision: 1},
+				{TeamID: 7, TeamName: "D2-3", FromDivision: 2, ToDivision: 1, ViaPlayoff: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			movements := divisionMovements(tables, tc.rules, tc.playoffWinners)
+			if !reflect.DeepEqual(movements, tc.expected) {
+				t.Errorf("Incorrect movements:\nExpected: %+v\nGot:      %+v", tc.expected, movements)
+			}
+		})
+	}
+}
+
+// TestValidateDivisionSizes checks that middle divisions must cover both promotion and relegation.
+func TestValidateDivisionSizes(t *testing.T) {
+	teams := func(n int) []models.Team { return make([]models.Team, n) }
+	tables := []models.DivisionTable{
+		{Division: 1, Standings: teams(4)},
+		{Division: 2, Standings: teams(3)},
+		{Division: 3, Standings: teams(4)},
+	}
+
+	if err := validateDivisionSizes(tables, models.PyramidRules{PromotionSpots: 1}); err != nil {
+		t.Errorf("Did not expect an error but got: %v", err)
+	}
+	if err := validateDivisionSizes(tables, models.PyramidRules{PromotionSpots: 1, Playoffs: true}); err != nil {
+		t.Errorf("Did not expect an error but got: %v", err)
+	}
+	if err := validateDivisionSizes(tables, models.PyramidRules{PromotionSpots: 2}); err == nil {
+		t.Errorf("Expected an error for a 3-team middle division with 2 promotion spots but got nil")
+	}
+	if err := validateDivisionSizes(tables, models.PyramidRules{PromotionSpots: 1, Playoffs: true, PlayoffTeams: 4}); err == nil {
+		t.Errorf("Expected an error for a 3-team middle division with a 4-team playoff but got nil")
+	}
+}
+
+// TestNextPlayoffRound walks a four-team playoff from the table through the semis to the winner.
+func TestNextPlayoffRound(t *testing.T) {
+	table := models.DivisionTable{Division: 2, Standings: []models.Team{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}, {ID: 6}, {ID: 7}}}
+	rules := models.PyramidRules{PromotionSpots: 3, Playoffs: true, PlayoffTeams: 4, TwoLeggedPlayoffs: true}
+	winner := func(id int) *int { return &id }
+
+	// 1. ve 2. doğrudan çıkar; 3.-6. sıradakiler play-off oynar
+	pairings, round, winnerID, err := nextPlayoffRound(table, nil, rules)
+	if err != nil {
+		t.Fatalf("Did not expect an error but got: %v", err)
+	}
+	if round != 1 || winnerID != 0 || !reflect.DeepEqual(pairings, [][2]int{{3, 6}, {4, 5}}) {
+		t.Fatalf("Incorrect semi-final pairings: round %d, winner %d, pairings %v", round, winnerID, pairings)
+	}
+
+	semis := buildKnockoutRound(pairings, models.StagePlayoff, round, 15, true)
+	if pairings, _, winnerID, _ = nextPlayoffRound(table, semis, rules); pairings != nil || winnerID != 0 {
+		t.Fatalf("Expected to wait for unplayed semi-finals, got pairings %v and winner %d", pairings, winnerID)
+	}
+
+	for i := range semis {
+		semis[i].IsPlayed = true
+	}
+	semis[1].WinnerTeamID = winner(6)
+	semis[3].WinnerTeamID = winner(4)
+	pairings, round, _, err = nextPlayoffRound(table, semis, rules)
+	if err != nil {
+		t.Fatalf("Did not expect an error but got: %v", err)
+	}
+	if round != 2 || !reflect.DeepEqual(pairings, [][2]int{{6, 4}}) {
+		t.Fatalf("Incorrect final pairing: round %d, pairings %v", round, pairings)
+	}
+
+	final := models.Match{HomeTeamID: 6, AwayTeamID: 4, IsPlayed: true, Stage: models.StagePlayoff, Round: 2, WinnerTeamID: winner(4)}
+	_, _, winnerID, err = nextPlayoffRound(table, append(semis, final), rules)
+	if err != nil || winnerID != 4 {
+		t.Errorf("Expected playoff winner 4, got %d (err: %v)", winnerID, err)
+	}
+}
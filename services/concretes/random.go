@@ -0,0 +1,109 @@
+package concretes
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// simulation holds the random source behind every simulated match, extra time, shootout and draw.
+// Seeding it (from config or an imported snapshot) makes the following simulations repeatable.
+// It counts the values taken since the seed, so a snapshot can record the exact position and an
+// import can continue from there instead of from the start of the seed.
+var simulation = struct {
+	mu     sync.Mutex
+	rng    *rand.Rand
+	source *countingSource
+	seed   int64
+}{}
+
+// countingSource wraps a rand.Source and counts the values taken from it. It deliberately does not
+// implement rand.Source64, so every method of rand.Rand draws through Int63 and is counted.
+type countingSource struct {
+	src   rand.Source
+	draws uint64
+}
+
+func (c *countingSource) Int63() int64 {
+	c.draws++
+	return c.src.Int63()
+}
+
+func (c *countingSource) Seed(seed int64) {
+	c.src.Seed(seed)
+	c.draws = 0
+}
+
+func init() {
+	SeedSimulation(time.Now().UnixNano())
+}
+
+// SeedSimulation restarts the simulation random source from seed.
+func SeedSimulation(seed int64) {
+	RestoreSimulation(seed, 0)
+}
+
+// RestoreSimulation restarts the simulation random source from seed and skips the first draws values,
+// putting it back where SimulationState reported it.
+func RestoreSimulation(seed int64, draws uint64) {
+	simulation.mu.Lock()
+	defer simulation.mu.Unlock()
+	simulation.source = &countingSource{src: rand.NewSource(seed)}
+	simulation.rng = rand.New(simulation.source)
+	simulation.seed = seed
+	for simulation.source.draws < draws {
+		simulation.source.Int63()
+	}
+}
+
+// SimulationSeed returns the seed the simulation random source was last started from.
+func SimulationSeed() int64 {
+	simulation.mu.Lock()
+	defer simulation.mu.Unlock()
+	return simulation.seed
+}
+
+// SimulationState returns the seed and the number of values taken from the simulation random source since then.
+func SimulationState() (seed int64, draws uint64) {
+	simulation.mu.Lock()
+	defer simulation.mu.Unlock()
+	return simulation.seed, simulation.source.draws
+}
+
+// matchRandom is the random source the match engine draws from.
+type matchRandom interface {
+	Intn(n int) int
+	Float64() float64
+}
+
+// sharedRandom is the matchRandom backed by the simulation random source. Played matches use it;
+// predictions use their own source so that asking for them never changes the next results.
+type sharedRandom struct{}
+
+func (sharedRandom) Intn(n int) int { return randIntn(n) }
+
+func (sharedRandom) Float64() float64 { return randFloat64() }
+
+// newPredictionRandom returns a private random source for one prediction run, derived from the
+// simulation seed and the week so that the same league state gives the same predictions.
+func newPredictionRandom(week int) *rand.Rand {
+	return rand.New(rand.NewSource(SimulationSeed() ^ int64(week)<<32))
+}
+
+func randIntn(n int) int {
+	simulation.mu.Lock()
+	defer simulation.mu.Unlock()
+	return simulation.rng.Intn(n)
+}
+
+func randFloat64() float64 {
+	simulation.mu.Lock()
+	defer simulation.mu.Unlock()
+	return simulation.rng.Float64()
+}
+
+func randShuffle(n int, swap func(i, j int)) {
+	simulation.mu.Lock()
+	defer simulation.mu.Unlock()
+	simulation.rng.Shuffle(n, swap)
+}
@@ -0,0 +1,281 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+)
+
+// leagueLocking is implemented by LeagueService. Services that change the league outside LeagueService
+// (snapshot import, tournaments, the pyramid) use it so they are serialised with week plays, resets and score edits.
+type leagueLocking interface {
+	lockLeague(ctx context.Context) (context.Context, func(), error)
+}
+
+// lockLeagueVia takes the league lock through ls. A league service without locking (e.g. a test mock)
+// leaves ctx unchanged and returns a no-op unlock.
+func lockLeagueVia(ctx context.Context, ls abstracts.ILeagueService) (context.Context, func(), error) {
+	if locking, ok := ls.(leagueLocking); ok {
+		return locking.lockLeague(ctx)
+	}
+	return ctx, func() {}, nil
+}
+
+// SnapshotService exports the league as a LeagueSnapshot document and restores it from one.
+type SnapshotService struct {
+	teamService   abstracts.TeamService
+	matchService  abstracts.IMatchService
+	leagueService abstracts.ILeagueService
+	rules         models.PyramidRules
+}
+
+// NewSnapshotService creates a new instance of SnapshotService. rules are the pyramid rules this instance runs with.
+func NewSnapshotService(ts abstracts.TeamService, ms abstracts.IMatchService, ls abstracts.ILeagueService, rules models.PyramidRules) abstracts.ISnapshotService {
+	return &SnapshotService{
+		teamService:   ts,
+		matchService:  ms,
+		leagueService: ls,
+		rules:         rules,
+	}
+}
+
+// Export collects teams, matches and adjustments in ID order together with the rules, the seed and
+// the position of the simulation random source, so an import plays the next week exactly as this instance would.
+func (s *SnapshotService) Export(ctx context.Context) (models.LeagueSnapshot, error) {
+	teams, err := s.teamService.GetAllTeams(ctx)
+	if err != nil {
+		return models.LeagueSnapshot{}, fmt.Errorf("SnapshotService.Export: Error retrieving teams: %w", err)
+	}
+	matches, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return models.LeagueSnapshot{}, fmt.Errorf("SnapshotService.Export: Error retrieving matches: %w", err)
+	}
+	adjustments, err := s.teamService.GetPointsAdjustments(ctx, 0)
+	if err != nil {
+		return models.LeagueSnapshot{}, fmt.Errorf("SnapshotService.Export: Error retrieving points adjustments: %w", err)
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].ID < teams[j].ID })
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	seed, draws := SimulationState()
+
+	return models.LeagueSnapshot{
+		FormatVersion: models.SnapshotFormatVersion,
+		ExportedAt:    time.Now().UTC().Truncate(time.Second),
+		Seed:          seed,
+		SeedDraws:     draws,
+		Rules:         s.rules,
+		Teams:         teams,
+		Matches:       matches,
+		Adjustments:   adjustments,
+	}, nil
+}
+
+// Import validates the snapshot, deletes the current league and rebuilds it under new IDs.
+// Results are replayed into the team statistics, knockout deciders and forfeits are restored,
+// adjustments are re-applied in their original order and the simulation is reseeded with the snapshot's seed.
+// With PostgreSQL and SQLite the request transaction makes the import all-or-nothing.
+func (s *SnapshotService) Import(ctx context.Context, snapshot models.LeagueSnapshot) ([]string, error) {
+	if err := validateSnapshot(snapshot); err != nil {
+		return nil, fmt.Errorf("SnapshotService.Import: %w: %v", abstracts.ErrInvalidSnapshot, err)
+	}
+	ctx, unlock, err := lockLeagueVia(ctx, s.leagueService)
+	if err != nil {
+		return nil, fmt.Errorf("SnapshotService.Import: %w", err)
+	}
+	defer unlock()
+
+	if err := s.teamService.DeleteAllTeams(ctx); err != nil {
+		return nil, fmt.Errorf("SnapshotService.Import: Error clearing the current league: %w", err)
+	}
+
+	teams := append([]models.Team(nil), snapshot.Teams...)
+	sort.Slice(teams, func(i, j int) bool { return teams[i].ID < teams[j].ID })
+	teamIDs := make(map[int]int, len(teams)) // belgedeki ID -> yeni ID
+	for _, team := range teams {
+		newID, err := s.teamService.CreateTeam(ctx, models.Team{Name: strings.TrimSpace(team.Name), Strength: team.Strength, Division: team.Division})
+		if err != nil {
+			return nil, fmt.Errorf("SnapshotService.Import: Error creating team '%s': %w", team.Name, err)
+		}
+		teamIDs[team.ID] = newID
+	}
+
+	matches := append([]models.Match(nil), snapshot.Matches...)
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	toStore := make([]models.Match, len(matches))
+	for i, match := range matches {
+		toStore[i] = match
+		toStore[i].HomeTeamID = teamIDs[match.HomeTeamID]
+		toStore[i].AwayTeamID = teamIDs[match.AwayTeamID]
+	}
+	if err := s.matchService.StoreMatches(ctx, toStore, true); err != nil {
+		return nil, fmt.Errorf("SnapshotService.Import: Error storing the fixture: %w", err)
+	}
+	stored, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("SnapshotService.Import: Error retrieving the stored fixture: %w", err)
+	}
+	if len(stored) != len(matches) {
+		return nil, fmt.Errorf("SnapshotService.Import: Expected %d stored matches, found %d", len(matches), len(stored))
+	}
+	// Maçlar sırayla eklendiği için yeni ID'ler belgedeki ID sırasını korur
+	sort.Slice(stored, func(i, j int) bool { return stored[i].ID < stored[j].ID })
+
+	for i, match := range matches {
+		if !match.IsPlayed {
+			continue
+		}
+		restored := stored[i]
+		if countsTowardsStandings(restored) {
+			if err := s.teamService.UpdateTeamStatsAfterMatch(ctx, restored.HomeTeamID, *match.HomeGoals, *match.AwayGoals); err != nil {
+				return nil, fmt.Errorf("SnapshotService.Import: Error updating stats for home team of match %d: %w", match.ID, err)
+			}
+			if err := s.teamService.UpdateTeamStatsAfterMatch(ctx, restored.AwayTeamID, *match.AwayGoals, *match.HomeGoals); err != nil {
+				return nil, fmt.Errorf("SnapshotService.Import: Error updating stats for away team of match %d: %w", match.ID, err)
+			}
+		}
+		if match.WinnerTeamID != nil {
+			winnerID := teamIDs[*match.WinnerTeamID]
+			err := s.matchService.UpdateKnockoutResult(ctx, restored.ID, match.HomeGoalsET, match.AwayGoalsET, match.HomePenalties, match.AwayPenalties, winnerID)
+			if err != nil {
+				return nil, fmt.Errorf("SnapshotService.Import: Error restoring knockout result of match %d: %w", match.ID, err)
+			}
+		}
+		if match.IsForfeit {
+			if err := s.matchService.SetMatchForfeit(ctx, restored.ID, true); err != nil {
+				return nil, fmt.Errorf("SnapshotService.Import: Error restoring forfeit of match %d: %w", match.ID, err)
+			}
+		}
+	}
+
+	adjustments := append([]models.PointsAdjustment(nil), snapshot.Adjustments...)
+	sort.SliceStable(adjustments, func(i, j int) bool {
+		if !adjustments[i].CreatedAt.Equal(adjustments[j].CreatedAt) {
+			return adjustments[i].CreatedAt.Before(adjustments[j].CreatedAt)
+		}
+		return adjustments[i].ID < adjustments[j].ID
+	})
+	for _, adjustment := range adjustments {
+		if _, err := s.teamService.ApplyPointsAdjustment(ctx, teamIDs[adjustment.TeamID], adjustment.Points, adjustment.Reason); err != nil {
+			return nil, fmt.Errorf("SnapshotService.Import: Error re-applying points adjustment %d: %w", adjustment.ID, err)
+		}
+	}
+
+	var warnings []string
+	if snapshot.Seed != 0 {
+		RestoreSimulation(snapshot.Seed, snapshot.SeedDraws)
+	} else {
+		warnings = append(warnings, "the snapshot has no seed; the simulation keeps its current seed")
+	}
+	if snapshot.Rules != s.rules {
+		warnings = append(warnings, fmt.Sprintf("the snapshot was exported with pyramid rules %+v; this instance keeps its configured rules %+v", snapshot.Rules, s.rules))
+	}
+	slog.InfoContext(ctx, "league restored from snapshot", "teams", len(teams), "matches", len(matches), "adjustments", len(adjustments))
+	return warnings, nil
+}
+
+// maxSnapshotSeedDraws bounds seed_draws. An import skips that many random values, which takes about a second
+// at this limit; a real league needs a few thousand per season.
+const maxSnapshotSeedDraws = 100_000_000
+
+// validateSnapshot checks the document before anything is deleted: the format version, unique team
+// names, and that every match and adjustment refers to teams in the document with a consistent result.
+func validateSnapshot(snapshot models.LeagueSnapshot) error {
+	if snapshot.FormatVersion < 1 {
+		return fmt.Errorf("format_version is missing")
+	}
+	if snapshot.FormatVersion > models.SnapshotFormatVersion {
+		return fmt.Errorf("format_version %d is newer than the supported version %d", snapshot.FormatVersion, models.SnapshotFormatVersion)
+	}
+	if len(snapshot.Teams) < 2 {
+		return fmt.Errorf("at least 2 teams are required, found %d", len(snapshot.Teams))
+	}
+	if snapshot.SeedDraws > maxSnapshotSeedDraws {
+		return fmt.Errorf("seed_draws %d is above the supported maximum of %d", snapshot.SeedDraws, maxSnapshotSeedDraws)
+	}
+
+	teamIDs := make(map[int]bool, len(snapshot.Teams))
+	names := make(map[string]bool, len(snapshot.Teams))
+	for _, team := range snapshot.Teams {
+		name, nameErr := normalizeTeamName(team.Name)
+		switch {
+		case team.ID < 1:
+			return fmt.Errorf("team '%s' has invalid ID %d", team.Name, team.ID)
+		case teamIDs[team.ID]:
+			return fmt.Errorf("team ID %d appears more than once", team.ID)
+		case nameErr != nil:
+			return fmt.Errorf("team %d has an invalid name: %v", team.ID, nameErr)
+		case names[name]:
+			return fmt.Errorf("team name '%s' appears more than once", name)
+		case team.Strength < 1 || team.Strength > 100:
+			return fmt.Errorf("team %d has invalid strength %d. Strength must be between 1 and 100", team.ID, team.Strength)
+		case team.Division < 1:
+			return fmt.Errorf("team %d has invalid division %d", team.ID, team.Division)
+		}
+		teamIDs[team.ID] = true
+		names[name] = true
+	}
+
+	matchIDs := make(map[int]bool, len(snapshot.Matches))
+	for _, match := range snapshot.Matches {
+		if match.ID < 1 || matchIDs[match.ID] {
+			return fmt.Errorf("match ID %d is invalid or appears more than once", match.ID)
+		}
+		matchIDs[match.ID] = true
+		if err := validateSnapshotMatch(match, teamIDs); err != nil {
+			return fmt.Errorf("match %d: %v", match.ID, err)
+		}
+	}
+
+	for _, adjustment := range snapshot.Adjustments {
+		if !teamIDs[adjustment.TeamID] {
+			return fmt.Errorf("points adjustment %d refers to unknown team ID %d", adjustment.ID, adjustment.TeamID)
+		}
+		adjustment.Reason = strings.TrimSpace(adjustment.Reason)
+		if err := validatePointsAdjustment(adjustment); err != nil {
+			return fmt.Errorf("points adjustment %d: %v", adjustment.ID, err)
+		}
+	}
+	return nil
+}
+
+func validateSnapshotMatch(match models.Match, teamIDs map[int]bool) error {
+	if !teamIDs[match.HomeTeamID] || !teamIDs[match.AwayTeamID] {
+		return fmt.Errorf("refers to unknown team ID %d or %d", match.HomeTeamID, match.AwayTeamID)
+	}
+	if match.HomeTeamID == match.AwayTeamID {
+		return fmt.Errorf("a team cannot play itself")
+	}
+	if match.Week < 1 {
+		return fmt.Errorf("invalid week %d", match.Week)
+	}
+	switch match.Stage {
+	case "", models.StageLeague, models.StageGroup, models.StageKnockout, models.StagePlayoff:
+	default:
+		return fmt.Errorf("unknown stage '%s'", match.Stage)
+	}
+
+	hasDeciders := match.HomeGoalsET != nil || match.AwayGoalsET != nil || match.HomePenalties != nil || match.AwayPenalties != nil || match.WinnerTeamID != nil
+	if !match.IsPlayed {
+		if match.HomeGoals != nil || match.AwayGoals != nil || hasDeciders || match.IsForfeit {
+			return fmt.Errorf("is not played but has a result")
+		}
+		return nil
+	}
+	if match.HomeGoals == nil || match.AwayGoals == nil || *match.HomeGoals < 0 || *match.AwayGoals < 0 {
+		return fmt.Errorf("is played but has no valid score")
+	}
+	if hasDeciders {
+		if !isKnockoutStage(match.Stage) {
+			return fmt.Errorf("only knockout matches can have extra time, penalties or a winner")
+		}
+		if match.WinnerTeamID == nil || (*match.WinnerTeamID != match.HomeTeamID && *match.WinnerTeamID != match.AwayTeamID) {
+			return fmt.Errorf("the winner must be one of the two teams")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// snapshotSummary describes a snapshot by team names instead of IDs, so documents taken before and after an import can be compared.
+func snapshotSummary(snapshot models.LeagueSnapshot) []string {
+	names := make(map[int]string, len(snapshot.Teams))
+	var summary []string
+	for _, team := range snapshot.Teams {
+		names[team.ID] = team.Name
+		summary = append(summary, fmt.Sprintf("team %s str=%d div=%d pld=%d pts=%d gd=%d adj=%d",
+			team.Name, team.Strength, team.Division, team.Played, team.Points, team.GoalDifference, team.Adjustment))
+	}
+	for _, match := range snapshot.Matches {
+		score := "-"
+		if match.IsPlayed {
+			score = fmt.Sprintf("%d-%d", *match.HomeGoals, *match.AwayGoals)
+		}
+		summary = append(summary, fmt.Sprintf("match w%d %s v %s %s forfeit=%v",
+			match.Week, names[match.HomeTeamID], names[match.AwayTeamID], score, match.IsForfeit))
+	}
+	for _, adjustment := range snapshot.Adjustments {
+		summary = append(summary, fmt.Sprintf("adjustment %s %d %s", names[adjustment.TeamID], adjustment.Points, adjustment.Reason))
+	}
+	return summary
+}
+
+// TestSnapshotService_RoundTrip exports a league in progress, changes it and imports the snapshot back on every backend.
+func TestSnapshotService_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	forEachBackend(t, func(t *testing.T, teamService abstracts.TeamService, matchService abstracts.IMatchService) {
+		ids := createTeams(t, teamService,
+			models.Team{Name: "Chelsea", Strength: 85}, models.Team{Name: "Arsenal", Strength: 82},
+			models.Team{Name: "Manchester City", Strength: 90}, models.Team{Name: "Liverpool", Strength: 88},
+		)
+		leagueService := NewLeagueService(teamService, matchService, nil)
+		snapshotService := NewSnapshotService(teamService, matchService, leagueService, models.PyramidRules{PromotionSpots: 1, PlayoffTeams: 2})
+		if err := leagueService.ResetLeague(ctx); err != nil {
+			t.Fatalf("Could not start the league: %v", err)
+		}
+		if _, _, _, err := leagueService.PlayNextWeek(ctx); err != nil {
+			t.Fatalf("Could not play a week: %v", err)
+		}
+		weekTwo, _ := matchService.GetMatchesByWeek(ctx, 2)
+		if err := leagueService.AwardForfeit(ctx, weekTwo[0].ID, weekTwo[0].AwayTeamID); err != nil {
+			t.Fatalf("Could not award a forfeit: %v", err)
+		}
+		if _, err := teamService.ApplyPointsAdjustment(ctx, ids[1], -3, "Financial breach"); err != nil {
+			t.Fatalf("Could not apply an adjustment: %v", err)
+		}
+
+		SeedSimulation(42)
+		exported, err := snapshotService.Export(ctx)
+		if err != nil {
+			t.Fatalf("Did not expect an error exporting but got: %v", err)
+		}
+		if exported.FormatVersion != models.SnapshotFormatVersion || exported.Seed != 42 || len(exported.Teams) != 4 || len(exported.Adjustments) != 1 {
+			t.Fatalf("Unexpected snapshot header: version %d, seed %d, %d teams, %d adjustments",
+				exported.FormatVersion, exported.Seed, len(exported.Teams), len(exported.Adjustments))
+		}
+
+		// Snapshot alındıktan sonraki değişiklikler içe aktarmayla geri gelmemeli
+		if _, _, err := leagueService.PlayAllRemainingWeeks(ctx); err != nil {
+			t.Fatalf("Could not play the remaining weeks: %v", err)
+		}
+		warnings, err := snapshotService.Import(ctx, exported)
+		if err != nil {
+			t.Fatalf("Did not expect an error importing but got: %v", err)
+		}
+		if len(warnings) != 0 {
+			t.Errorf("Expected no warnings for matching rules, got %v", warnings)
+		}
+		restored, err := snapshotService.Export(ctx)
+		if err != nil {
+			t.Fatalf("Could not export the restored league: %v", err)
+		}
+		if want, got := snapshotSummary(exported), snapshotSummary(restored); !reflect.DeepEqual(want, got) {
+			t.Errorf("Restored league differs from the snapshot.\nwant: %v\n got: %v", want, got)
+		}
+		assertTableMatchesResults(t, teamService, matchService)
+
+		// Aynı snapshot'tan oynanan hafta aynı sonuçları vermeli
+		_, first, _, err := leagueService.PlayNextWeek(ctx)
+		if err != nil {
+			t.Fatalf("Could not play a week after the import: %v", err)
+		}
+		if _, err := snapshotService.Import(ctx, exported); err != nil {
+			t.Fatalf("Could not import the snapshot again: %v", err)
+		}
+		_, second, _, err := leagueService.PlayNextWeek(ctx)
+		if err != nil {
+			t.Fatalf("Could not replay the week: %v", err)
+		}
+		for i := range first {
+			if *first[i].HomeGoals != *second[i].HomeGoals || *first[i].AwayGoals != *second[i].AwayGoals {
+				t.Errorf("Expected the same seed to replay the same results, got %d-%d and %d-%d",
+					*first[i].HomeGoals, *first[i].AwayGoals, *second[i].HomeGoals, *second[i].AwayGoals)
+			}
+		}
+	})
+}
+
+// TestSnapshotService_ImportContinuesSimulation exports a league in mid-season from one instance and imports it
+// into another whose random source is elsewhere: the next week must be played exactly as on the source.
+// Predictions asked for on the source before the export must not move the random source either.
+func TestSnapshotService_ImportContinuesSimulation(t *testing.T) {
+	ctx := context.Background()
+	newInstance := func() (abstracts.TeamService, abstracts.ILeagueService, abstracts.ISnapshotService) {
+		teamService, matchService := NewMemoryTeamService(), NewMemoryMatchService()
+		leagueService := NewLeagueService(teamService, matchService, nil)
+		return teamService, leagueService, NewSnapshotService(teamService, matchService, leagueService, models.PyramidRules{})
+	}
+
+	SeedSimulation(7)
+	sourceTeams, sourceLeague, sourceSnapshot := newInstance()
+	createTeams(t, sourceTeams,
+		models.Team{Name: "Chelsea", Strength: 85}, models.Team{Name: "Arsenal", Strength: 82},
+		models.Team{Name: "Manchester City", Strength: 90}, models.Team{Name: "Liverpool", Strength: 88},
+	)
+	if err := sourceLeague.ResetLeague(ctx); err != nil {
+		t.Fatalf("Could not start the source league: %v", err)
+	}
+	for week := 1; week <= 4; week++ {
+		if _, _, _, err := sourceLeague.PlayNextWeek(ctx); err != nil {
+			t.Fatalf("Could not play week %d: %v", week, err)
+		}
+	}
+	seedBefore, drawsBefore := SimulationState()
+	if _, err := sourceLeague.GetChampionshipPredictions(ctx); err != nil {
+		t.Fatalf("Could not get predictions: %v", err)
+	}
+	if seed, draws := SimulationState(); seed != seedBefore || draws != drawsBefore {
+		t.Fatalf("Predictions moved the simulation random source from %d/%d to %d/%d", seedBefore, drawsBefore, seed, draws)
+	}
+
+	exported, err := sourceSnapshot.Export(ctx)
+	if err != nil {
+		t.Fatalf("Could not export: %v", err)
+	}
+	if exported.Seed != 7 || exported.SeedDraws == 0 {
+		t.Fatalf("Expected the export to record seed 7 and its position, got seed %d after %d draws", exported.Seed, exported.SeedDraws)
+	}
+	_, onSource, _, err := sourceLeague.PlayNextWeek(ctx)
+	if err != nil {
+		t.Fatalf("Could not play the next week on the source: %v", err)
+	}
+
+	// Hedef örnek başka bir tohumla başlamış ve kendi maçlarını oynamış olabilir
+	SeedSimulation(99)
+	_, targetLeague, targetSnapshot := newInstance()
+	if _, err := targetSnapshot.Import(ctx, exported); err != nil {
+		t.Fatalf("Could not import: %v", err)
+	}
+	_, onTarget, _, err := targetLeague.PlayNextWeek(ctx)
+	if err != nil {
+		t.Fatalf("Could not play the next week on the target: %v", err)
+	}
+	if len(onSource) == 0 || len(onSource) != len(onTarget) {
+		t.Fatalf("Expected the same number of matches, got %d and %d", len(onSource), len(onTarget))
+	}
+	for i := range onSource {
+		if *onSource[i].HomeGoals != *onTarget[i].HomeGoals || *onSource[i].AwayGoals != *onTarget[i].AwayGoals {
+			t.Errorf("Match %d: source played %d-%d, the imported league %d-%d", i,
+				*onSource[i].HomeGoals, *onSource[i].AwayGoals, *onTarget[i].HomeGoals, *onTarget[i].AwayGoals)
+		}
+	}
+}
+
+// TestSnapshotService_ImportRejectsInvalidSnapshot checks that a broken document leaves the current league untouched.
+func TestSnapshotService_ImportRejectsInvalidSnapshot(t *testing.T) {
+	ctx := context.Background()
+	teamService, matchService := NewMemoryTeamService(), NewMemoryMatchService()
+	createTeams(t, teamService, models.Team{Name: "Chelsea", Strength: 85}, models.Team{Name: "Arsenal", Strength: 82})
+	leagueService := NewLeagueService(teamService, matchService, nil)
+	snapshotService := NewSnapshotService(teamService, matchService, leagueService, models.PyramidRules{})
+
+	three := 3
+	valid := func() models.LeagueSnapshot {
+		return models.LeagueSnapshot{
+			FormatVersion: models.SnapshotFormatVersion,
+			Teams:         []models.Team{{ID: 1, Name: "A", Strength: 50, Division: 1}, {ID: 2, Name: "B", Strength: 60, Division: 1}},
+			Matches:       []models.Match{{ID: 1, Week: 1, HomeTeamID: 1, AwayTeamID: 2}},
+		}
+	}
+	tests := []struct {
+		name      string
+		mutate    func(s *models.LeagueSnapshot)
+		wantError string
+	}{
+		{"missing version", func(s *models.LeagueSnapshot) { s.FormatVersion = 0 }, "format_version is missing"},
+		{"newer version", func(s *models.LeagueSnapshot) { s.FormatVersion = models.SnapshotFormatVersion + 1 }, "newer than the supported"},
+		{"duplicate name", func(s *models.LeagueSnapshot) { s.Teams[1].Name = "A" }, "appears more than once"},
+		{"invalid strength", func(s *models.LeagueSnapshot) { s.Teams[0].Strength = 0 }, "invalid strength"},
+		{"name too long", func(s *models.LeagueSnapshot) { s.Teams[0].Name = strings.Repeat("A", maxTeamNameLength+1) }, "longer than"},
+		{"unknown team in match", func(s *models.LeagueSnapshot) { s.Matches[0].AwayTeamID = 9 }, "unknown team ID"},
+		{"played without score", func(s *models.LeagueSnapshot) { s.Matches[0].IsPlayed = true }, "no valid score"},
+		{"unplayed with score", func(s *models.LeagueSnapshot) { s.Matches[0].HomeGoals = &three }, "has a result"},
+		{"winner in league match", func(s *models.LeagueSnapshot) {
+			s.Matches[0].IsPlayed, s.Matches[0].HomeGoals, s.Matches[0].AwayGoals = true, &three, &three
+			s.Matches[0].WinnerTeamID = &s.Matches[0].HomeTeamID
+		}, "only knockout matches"},
+		{"adjustment for unknown team", func(s *models.LeagueSnapshot) {
+			s.Adjustments = []models.PointsAdjustment{{ID: 1, TeamID: 7, Points: -3, Reason: "x"}}
+		}, "unknown team ID 7"},
+		{"too many seed draws", func(s *models.LeagueSnapshot) { s.SeedDraws = maxSnapshotSeedDraws + 1 }, "seed_draws"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := valid()
+			tt.mutate(&snapshot)
+			_, err := snapshotService.Import(ctx, snapshot)
+			if !errors.Is(err, abstracts.ErrInvalidSnapshot) || !strings.Contains(err.Error(), tt.wantError) {
+				t.Fatalf("Expected ErrInvalidSnapshot containing %q, got %v", tt.wantError, err)
+			}
+			if teams, _ := teamService.GetAllTeams(ctx); len(teams) != 2 || teams[0].Name == "A" {
+				t.Errorf("Expected the current league to be left untouched, got %+v", teams)
+			}
+		})
+	}
+
+	snapshot := valid()
+	snapshot.Rules = models.PyramidRules{PromotionSpots: 2}
+	warnings, err := snapshotService.Import(ctx, snapshot)
+	if err != nil {
+		t.Fatalf("Did not expect an error importing a valid snapshot but got: %v", err)
+	}
+	if len(warnings) != 2 {
+		t.Errorf("Expected warnings for the missing seed and different rules, got %v", warnings)
+	}
+}
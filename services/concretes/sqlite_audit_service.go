@@ -0,0 +1,74 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/database"
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/queries"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// SQLiteAuditService stores audit entries in SQLite with the same statements as PostgresAuditService.
+// Timestamps are written by the service in UTC, so the time range filter compares like with like.
+type SQLiteAuditService struct {
+	DB *sql.DB
+}
+
+// NewSQLiteAuditService creates an audit service on an SQLite database migrated with migrations.NewSQLiteMigrator.
+func NewSQLiteAuditService(db *sql.DB) abstracts.AuditService {
+	return &SQLiteAuditService{DB: db}
+}
+
+// conn returns the transaction stored in ctx, or the database when there is none.
+func (s *SQLiteAuditService) conn(ctx context.Context) database.SQLiteDB {
+	return database.SQLiteConn(ctx, s.DB)
+}
+
+// Record stores the entry and returns it with its ID and timestamp.
+func (s *SQLiteAuditService) Record(ctx context.Context, entry models.AuditEntry) (models.AuditEntry, error) {
+	entry, err := prepareAuditEntry(entry)
+	if err != nil {
+		return models.AuditEntry{}, fmt.Errorf("SQLiteAuditService.Record: %w", err)
+	}
+	err = s.conn(ctx).QueryRowContext(ctx, queries.InsertAuditEntrySQL, auditEntryArgs(entry)...).Scan(&entry.ID)
+	if err != nil {
+		return models.AuditEntry{}, fmt.Errorf("SQLiteAuditService.Record: Error recording %s of %s %d: %w", entry.Action, entry.EntityType, entry.EntityID, err)
+	}
+	return entry, nil
+}
+
+// GetByID returns a single audit entry.
+func (s *SQLiteAuditService) GetByID(ctx context.Context, id int) (*models.AuditEntry, error) {
+	entry, err := scanAuditEntry(s.conn(ctx).QueryRowContext(ctx, queries.GetAuditEntryByIDSQL, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("SQLiteAuditService.GetByID: %w (ID: %d)", abstracts.ErrAuditEntryNotFound, id)
+		}
+		return nil, fmt.Errorf("SQLiteAuditService.GetByID: Error retrieving audit entry (ID: %d): %w", id, err)
+	}
+	return &entry, nil
+}
+
+// List returns the entries matching filter, oldest first.
+func (s *SQLiteAuditService) List(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error) {
+	rows, err := s.conn(ctx).QueryContext(ctx, queries.GetAuditEntriesSQL, auditFilterArgs(filter)...)
+	if err != nil {
+		return nil, fmt.Errorf("SQLiteAuditService.List: Error retrieving audit entries: %w", err)
+	}
+	defer rows.Close()
+	var entries []models.AuditEntry
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("SQLiteAuditService.List: Error scanning audit entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SQLiteAuditService.List: Error processing rows: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,255 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/database"
+	"MatchSimulator_Insider/logging"
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/queries"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// SQLiteMatchService stores the fixture in SQLite using the statements from the queries package.
+// Fixture generation and the match engine are shared with PostgresMatchService.
+type SQLiteMatchService struct {
+	DB *sql.DB
+}
+
+// NewSQLiteMatchService creates a match service on an SQLite database migrated with migrations.NewSQLiteMigrator.
+func NewSQLiteMatchService(db *sql.DB) abstracts.IMatchService {
+	return &SQLiteMatchService{DB: db}
+}
+
+// conn returns the transaction stored in ctx, or the database when there is none.
+func (s *SQLiteMatchService) conn(ctx context.Context) database.SQLiteDB {
+	return database.SQLiteConn(ctx, s.DB)
+}
+
+// GenerateAndStoreFixture replaces the fixture with a new double round-robin per division.
+func (s *SQLiteMatchService) GenerateAndStoreFixture(ctx context.Context, teams []models.Team) error {
+	if len(teams) < 2 {
+		return fmt.Errorf("SQLiteMatchService.GenerateAndStoreFixture: At least 2 teams are required to generate a fixture, received: %d", len(teams))
+	}
+	matchesToCreate, err := buildSeasonFixture(teams)
+	if err != nil {
+		return fmt.Errorf("SQLiteMatchService.GenerateAndStoreFixture: %w", err)
+	}
+	if err := s.StoreMatches(ctx, matchesToCreate, true); err != nil {
+		return fmt.Errorf("SQLiteMatchService.GenerateAndStoreFixture: %w", err)
+	}
+	return nil
+}
+
+// StoreMatches writes the matches in one transaction. With replaceExisting the current fixture is dropped first.
+func (s *SQLiteMatchService) StoreMatches(ctx context.Context, matches []models.Match, replaceExisting bool) error {
+	tx, err := database.BeginSQLite(ctx, s.DB)
+	if err != nil {
+		return fmt.Errorf("SQLiteMatchService.StoreMatches: Could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if replaceExisting {
+		if _, err = tx.ExecContext(ctx, queries.DeleteAllMatchesSQL); err != nil {
+			return fmt.Errorf("SQLiteMatchService.StoreMatches: Error clearing existing fixture: %w", err)
+		}
+	}
+	for _, match := range matches {
+		stage := match.Stage
+		if stage == "" {
+			stage = models.StageLeague
+		}
+		_, err = tx.ExecContext(ctx, queries.InsertMatchSQL,
+			match.Week, match.HomeTeamID, match.AwayTeamID,
+			match.IsPlayed, match.HomeGoals, match.AwayGoals,
+			stage, match.GroupName, match.Round, match.Leg,
+		)
+		if err != nil {
+			return fmt.Errorf("SQLiteMatchService.StoreMatches: Error adding match for week %d (%d vs %d): %w", match.Week, match.HomeTeamID, match.AwayTeamID, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("SQLiteMatchService.StoreMatches: Could not commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetMatchesByWeek returns the week's matches ordered by ID.
+func (s *SQLiteMatchService) GetMatchesByWeek(ctx context.Context, week int) ([]models.Match, error) {
+	matches, err := s.queryMatches(ctx, queries.GetMatchesByWeekSQL, week)
+	if err != nil {
+		return nil, fmt.Errorf("SQLiteMatchService.GetMatchesByWeek: Error retrieving matches for week %d: %w", week, err)
+	}
+	return matches, nil
+}
+
+// GetMatchByID returns the match, or an error if it does not exist.
+func (s *SQLiteMatchService) GetMatchByID(ctx context.Context, id int) (*models.Match, error) {
+	var match models.Match
+	err := scanMatch(s.conn(ctx).QueryRowContext(ctx, queries.GetMatchByIDSQL, id), &match)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("SQLiteMatchService.GetMatchByID: %w (ID: %d)", abstracts.ErrMatchNotFound, id)
+		}
+		return nil, fmt.Errorf("SQLiteMatchService.GetMatchByID: Error retrieving match (ID: %d): %w", id, err)
+	}
+	return &match, nil
+}
+
+// UpdateMatchResult stores the score and played flag; a new score clears the forfeit flag.
+func (s *SQLiteMatchService) UpdateMatchResult(ctx context.Context, matchID int, homeGoals, awayGoals int, isPlayed bool) error {
+	result, err := s.conn(ctx).ExecContext(ctx, queries.UpdateMatchResultSQL, homeGoals, awayGoals, isPlayed, matchID)
+	if err != nil {
+		return fmt.Errorf("SQLiteMatchService.UpdateMatchResult: Error updating match result (ID: %d): %w", matchID, err)
+	}
+	if rowsAffected(result) == 0 {
+		return fmt.Errorf("SQLiteMatchService.UpdateMatchResult: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	return nil
+}
+
+// GetAllMatches returns every match ordered by week and ID.
+func (s *SQLiteMatchService) GetAllMatches(ctx context.Context) ([]models.Match, error) {
+	matches, err := s.queryMatches(ctx, queries.GetAllMatchesSQL)
+	if err != nil {
+		return nil, fmt.Errorf("SQLiteMatchService.GetAllMatches: Error retrieving all matches: %w", err)
+	}
+	return matches, nil
+}
+
+// SimulateMatchOutcome plays 90 minutes with the shared match engine.
+func (s *SQLiteMatchService) SimulateMatchOutcome(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (homeGoals int, awayGoals int, err error) {
+	homeGoals, awayGoals = simulateGoals(sharedRandom{}, homeTeam, awayTeam, 6)
+	return homeGoals, awayGoals, nil
+}
+
+// SimulateExtraTime plays 30 minutes of extra time with the shared match engine.
+func (s *SQLiteMatchService) SimulateExtraTime(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (homeGoals int, awayGoals int, err error) {
+	homeGoals, awayGoals = simulateGoals(sharedRandom{}, homeTeam, awayTeam, 2)
+	return homeGoals, awayGoals, nil
+}
+
+// SimulatePenaltyShootout plays a kick-by-kick shootout with the shared match engine.
+func (s *SQLiteMatchService) SimulatePenaltyShootout(ctx context.Context, homeTeam models.Team, awayTeam models.Team) (homeScore int, awayScore int, err error) {
+	homeScore, awayScore = simulatePenaltyShootout(sharedRandom{}, homeTeam, awayTeam)
+	return homeScore, awayScore, nil
+}
+
+// UpdateKnockoutResult stores the knockout deciders and the team that advanced.
+func (s *SQLiteMatchService) UpdateKnockoutResult(ctx context.Context, matchID int, homeGoalsET, awayGoalsET, homePenalties, awayPenalties *int, winnerTeamID int) error {
+	result, err := s.conn(ctx).ExecContext(ctx, queries.UpdateKnockoutResultSQL, homeGoalsET, awayGoalsET, homePenalties, awayPenalties, winnerTeamID, matchID)
+	if err != nil {
+		return fmt.Errorf("SQLiteMatchService.UpdateKnockoutResult: Error updating knockout result (ID: %d): %w", matchID, err)
+	}
+	if rowsAffected(result) == 0 {
+		return fmt.Errorf("SQLiteMatchService.UpdateKnockoutResult: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	return nil
+}
+
+// ClearMatchResult marks the match unplayed and removes its score, deciders and forfeit flag.
+func (s *SQLiteMatchService) ClearMatchResult(ctx context.Context, matchID int) error {
+	result, err := s.conn(ctx).ExecContext(ctx, queries.ClearMatchResultSQL, matchID)
+	if err != nil {
+		return fmt.Errorf("SQLiteMatchService.ClearMatchResult: Error clearing match result (ID: %d): %w", matchID, err)
+	}
+	if rowsAffected(result) == 0 {
+		return fmt.Errorf("SQLiteMatchService.ClearMatchResult: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	return nil
+}
+
+// SetMatchForfeit sets or clears the forfeit flag of a match.
+func (s *SQLiteMatchService) SetMatchForfeit(ctx context.Context, matchID int, isForfeit bool) error {
+	result, err := s.conn(ctx).ExecContext(ctx, queries.UpdateMatchForfeitSQL, isForfeit, matchID)
+	if err != nil {
+		return fmt.Errorf("SQLiteMatchService.SetMatchForfeit: Error updating forfeit flag (ID: %d): %w", matchID, err)
+	}
+	if rowsAffected(result) == 0 {
+		return fmt.Errorf("SQLiteMatchService.SetMatchForfeit: %w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+	}
+	return nil
+}
+
+// EditMatchScore stores a new score for the match and returns the match as it was before the edit.
+// The read and the update run in one transaction; the update only applies to the version that was read.
+func (s *SQLiteMatchService) EditMatchScore(ctx context.Context, matchID int, newHomeGoals int, newAwayGoals int, expectedVersion int) (originalMatch models.Match, err error) {
+	tx, err := database.BeginSQLite(ctx, s.DB)
+	if err != nil {
+		return models.Match{}, fmt.Errorf("SQLiteMatchService.EditMatchScore: Could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := scanMatch(tx.QueryRowContext(ctx, queries.GetMatchByIDSQL, matchID), &originalMatch); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w (ID: %d)", abstracts.ErrMatchNotFound, matchID)
+		}
+		return models.Match{}, fmt.Errorf("SQLiteMatchService.EditMatchScore: Could not find or retrieve match to edit (ID: %d): %w", matchID, err)
+	}
+	if expectedVersion != 0 && originalMatch.Version != expectedVersion {
+		return models.Match{}, versionConflict("SQLiteMatchService.EditMatchScore", "Match", matchID, expectedVersion, originalMatch.Version)
+	}
+	result, err := tx.ExecContext(ctx, queries.EditMatchScoreSQL, newHomeGoals, newAwayGoals, matchID, originalMatch.Version)
+	if err != nil {
+		return originalMatch, fmt.Errorf("SQLiteMatchService.EditMatchScore: Error updating score for match (ID: %d): %w", matchID, err)
+	}
+	if rowsAffected(result) == 0 {
+		return models.Match{}, fmt.Errorf("SQLiteMatchService.EditMatchScore: Match (ID: %d) changed while it was being edited: %w", matchID, abstracts.ErrVersionConflict)
+	}
+	if err := tx.Commit(); err != nil {
+		return originalMatch, fmt.Errorf("SQLiteMatchService.EditMatchScore: Could not commit transaction: %w", err)
+	}
+
+	slog.DebugContext(ctx, "match score updated", logging.KeyMatchID, matchID, "home_goals", newHomeGoals, "away_goals", newAwayGoals)
+	return originalMatch, nil
+}
+
+// queryMatches runs a match SELECT from the queries package and scans every row.
+func (s *SQLiteMatchService) queryMatches(ctx context.Context, query string, args ...any) ([]models.Match, error) {
+	rows, err := s.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var matches []models.Match
+	for rows.Next() {
+		var match models.Match
+		if err := scanMatch(rows, &match); err != nil {
+			return nil, fmt.Errorf("Error scanning match row: %w", err)
+		}
+		matches = append(matches, match)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("Error processing rows: %w", err)
+	}
+	return matches, nil
+}
+
+// ArchiveSeason writes the closed season's playoff matches to the archive and returns the season number.
+func (s *SQLiteMatchService) ArchiveSeason(ctx context.Context, playoffMatches []models.Match) (int, error) {
+	encoded, closedAt, err := encodeSeasonArchive(playoffMatches)
+	if err != nil {
+		return 0, fmt.Errorf("SQLiteMatchService.ArchiveSeason: %w", err)
+	}
+	var season int
+	if err := s.conn(ctx).QueryRowContext(ctx, queries.InsertSeasonArchiveSQL, encoded, closedAt).Scan(&season); err != nil {
+		return 0, fmt.Errorf("SQLiteMatchService.ArchiveSeason: Error archiving season: %w", err)
+	}
+	return season, nil
+}
+
+// GetSeasonArchive returns an archived season; season 0 is the most recently closed one.
+func (s *SQLiteMatchService) GetSeasonArchive(ctx context.Context, season int) (*models.SeasonArchive, error) {
+	archive, err := scanSeasonArchive(s.conn(ctx).QueryRowContext(ctx, queries.GetSeasonArchiveSQL, season))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("SQLiteMatchService.GetSeasonArchive: %w (season: %d)", abstracts.ErrSeasonNotFound, season)
+		}
+		return nil, fmt.Errorf("SQLiteMatchService.GetSeasonArchive: Error retrieving season %d: %w", season, err)
+	}
+	return archive, nil
+}
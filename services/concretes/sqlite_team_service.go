@@ -0,0 +1,345 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/database"
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/queries"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// SQLiteTeamService stores teams in SQLite. It runs the same statements from the queries package as
+// PostgresTeamService, so ranking, unique names and transactional stat updates behave identically.
+// SQLite binds $N placeholders in the order they first appear, so those statements must number
+// their parameters in reading order.
+type SQLiteTeamService struct {
+	DB *sql.DB
+}
+
+// NewSQLiteTeamService creates a team service on an SQLite database migrated with migrations.NewSQLiteMigrator.
+func NewSQLiteTeamService(db *sql.DB) abstracts.TeamService {
+	return &SQLiteTeamService{DB: db}
+}
+
+// conn returns the transaction stored in ctx, or the database when there is none.
+func (s *SQLiteTeamService) conn(ctx context.Context) database.SQLiteDB {
+	return database.SQLiteConn(ctx, s.DB)
+}
+
+// CreateTeam adds a team with zeroed stats, or returns the ID of an existing team with the same name.
+func (s *SQLiteTeamService) CreateTeam(ctx context.Context, team models.Team) (int, error) {
+	var id int
+	err := s.conn(ctx).QueryRowContext(ctx, queries.CreateTeamCheckExistsSQL, team.Name).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("SQLiteTeamService.CreateTeam: Database error while checking for team '%s': %w", team.Name, err)
+	}
+
+	division := team.Division
+	if division < 1 {
+		division = 1
+	}
+	err = s.conn(ctx).QueryRowContext(ctx, queries.CreateTeamInsertSQL, team.Name, team.Strength, division).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("SQLiteTeamService.CreateTeam: Error adding team '%s': %w", team.Name, err)
+	}
+	return id, nil
+}
+
+// GetTeamByID returns the team with its adjusted points.
+func (s *SQLiteTeamService) GetTeamByID(ctx context.Context, id int) (*models.Team, error) {
+	var team models.Team
+	err := scanTeam(s.conn(ctx).QueryRowContext(ctx, queries.GetTeamByIDSQL, id), &team)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("SQLiteTeamService.GetTeamByID: %w (ID: %d)", abstracts.ErrTeamNotFound, id)
+		}
+		return nil, fmt.Errorf("SQLiteTeamService.GetTeamByID: Error retrieving team (ID: %d): %w", id, err)
+	}
+	return &team, nil
+}
+
+// GetAllTeams returns all teams in league table order.
+func (s *SQLiteTeamService) GetAllTeams(ctx context.Context) ([]models.Team, error) {
+	teams, err := s.queryTeams(ctx, queries.GetAllTeamsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("SQLiteTeamService.GetAllTeams: %w", err)
+	}
+	return teams, nil
+}
+
+// UpdateTeamStatsAfterMatch records one played match for the team.
+func (s *SQLiteTeamService) UpdateTeamStatsAfterMatch(ctx context.Context, teamID int, goalsScored int, goalsConceded int) error {
+	pointsEarned, winIncrement, drawIncrement, lossIncrement := calculateOutcomeMetrics(goalsScored, goalsConceded)
+
+	tx, err := database.BeginSQLite(ctx, s.DB)
+	if err != nil {
+		return fmt.Errorf("SQLiteTeamService.UpdateTeamStatsAfterMatch: Could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, queries.UpdateTeamMainStatsSQL,
+		winIncrement, drawIncrement, lossIncrement,
+		goalsScored, goalsConceded, pointsEarned,
+		teamID,
+	)
+	if err != nil {
+		return fmt.Errorf("SQLiteTeamService.UpdateTeamStatsAfterMatch: Error updating main stats for team (ID: %d): %w", teamID, err)
+	}
+	if rowsAffected(result) == 0 {
+		return fmt.Errorf("SQLiteTeamService.UpdateTeamStatsAfterMatch: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	if _, err = tx.ExecContext(ctx, queries.UpdateTeamGDSQL, teamID); err != nil {
+		return fmt.Errorf("SQLiteTeamService.UpdateTeamStatsAfterMatch: Error updating goal difference for team (ID: %d): %w", teamID, err)
+	}
+	return tx.Commit()
+}
+
+// RevertTeamStatsAfterMatch removes a result added by UpdateTeamStatsAfterMatch from the team's statistics.
+func (s *SQLiteTeamService) RevertTeamStatsAfterMatch(ctx context.Context, teamID int, goalsScored int, goalsConceded int) error {
+	pointsEarned, wins, draws, losses := calculateOutcomeMetrics(goalsScored, goalsConceded)
+
+	tx, err := database.BeginSQLite(ctx, s.DB)
+	if err != nil {
+		return fmt.Errorf("SQLiteTeamService.RevertTeamStatsAfterMatch: Could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, queries.RevertTeamMainStatsSQL, wins, draws, losses, goalsScored, goalsConceded, pointsEarned, teamID)
+	if err != nil {
+		return fmt.Errorf("SQLiteTeamService.RevertTeamStatsAfterMatch: Error reverting main stats for team (ID: %d): %w", teamID, err)
+	}
+	if rowsAffected(result) == 0 {
+		return fmt.Errorf("SQLiteTeamService.RevertTeamStatsAfterMatch: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	if _, err = tx.ExecContext(ctx, queries.UpdateTeamGDSQL, teamID); err != nil {
+		return fmt.Errorf("SQLiteTeamService.RevertTeamStatsAfterMatch: Error updating goal difference for team (ID: %d): %w", teamID, err)
+	}
+	return tx.Commit()
+}
+
+// ResetAllTeamStats zeroes every team's statistics and clears the season's points adjustments.
+func (s *SQLiteTeamService) ResetAllTeamStats(ctx context.Context) error {
+	tx, err := database.BeginSQLite(ctx, s.DB)
+	if err != nil {
+		return fmt.Errorf("SQLiteTeamService.ResetAllTeamStats: Could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, queries.ResetAllTeamStatsSQL)
+	if err != nil {
+		return fmt.Errorf("SQLiteTeamService.ResetAllTeamStats: Error resetting team statistics: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, queries.DeleteAllPointsAdjustmentsSQL); err != nil {
+		return fmt.Errorf("SQLiteTeamService.ResetAllTeamStats: Error clearing points adjustments: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("SQLiteTeamService.ResetAllTeamStats: Could not commit transaction: %w", err)
+	}
+	slog.DebugContext(ctx, "team statistics reset", "rows_affected", rowsAffected(result))
+	return nil
+}
+
+// DeleteAllTeams removes every team; their matches and points adjustments go with them through the foreign key cascade.
+func (s *SQLiteTeamService) DeleteAllTeams(ctx context.Context) error {
+	if _, err := s.conn(ctx).ExecContext(ctx, queries.DeleteAllTeamsSQL); err != nil {
+		return fmt.Errorf("SQLiteTeamService.DeleteAllTeams: Error deleting teams: %w", err)
+	}
+	return nil
+}
+
+// DeleteTeam removes the team; its matches and points adjustments go with it through the foreign key cascade.
+func (s *SQLiteTeamService) DeleteTeam(ctx context.Context, teamID int) error {
+	result, err := s.conn(ctx).ExecContext(ctx, queries.DeleteTeamSQL, teamID)
+	if err != nil {
+		return fmt.Errorf("SQLiteTeamService.DeleteTeam: Error deleting team (ID: %d): %w", teamID, err)
+	}
+	if rowsAffected(result) == 0 {
+		return fmt.Errorf("SQLiteTeamService.DeleteTeam: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	return nil
+}
+
+// AdjustTeamStatsForScoreChange replaces the team's contribution from an old score with a new one.
+func (s *SQLiteTeamService) AdjustTeamStatsForScoreChange(ctx context.Context, teamID int, oldGoalsForTeam, oldGoalsAgainstTeam, newGoalsForTeam, newGoalsAgainstTeam int) error {
+	oldPoints, oldWins, oldDraws, oldLosses := calculateOutcomeMetrics(oldGoalsForTeam, oldGoalsAgainstTeam)
+	newPoints, newWins, newDraws, newLosses := calculateOutcomeMetrics(newGoalsForTeam, newGoalsAgainstTeam)
+
+	tx, err := database.BeginSQLite(ctx, s.DB)
+	if err != nil {
+		return fmt.Errorf("AdjustTeamStatsForScoreChange: Could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, queries.AdjustTeamStatsSQL,
+		newWins-oldWins, newDraws-oldDraws, newLosses-oldLosses,
+		newGoalsForTeam-oldGoalsForTeam, newGoalsAgainstTeam-oldGoalsAgainstTeam, newPoints-oldPoints,
+		teamID,
+	)
+	if err != nil {
+		return fmt.Errorf("AdjustTeamStatsForScoreChange: Error updating main stats for team (ID: %d): %w", teamID, err)
+	}
+	if rowsAffected(result) == 0 {
+		return fmt.Errorf("AdjustTeamStatsForScoreChange: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	if _, err = tx.ExecContext(ctx, queries.UpdateTeamGDSQL, teamID); err != nil {
+		return fmt.Errorf("AdjustTeamStatsForScoreChange: Error updating goal difference for team (ID: %d): %w", teamID, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("AdjustTeamStatsForScoreChange: Could not commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UpdateTeamStrength sets the team's strength (1-100).
+func (s *SQLiteTeamService) UpdateTeamStrength(ctx context.Context, teamID int, newStrength int, expectedVersion int) error {
+	if newStrength < 1 || newStrength > 100 {
+		return fmt.Errorf("%w: %d. Strength must be between 1 and 100", abstracts.ErrInvalidStrength, newStrength)
+	}
+	result, err := s.conn(ctx).ExecContext(ctx, queries.UpdateTeamStrengthSQL, newStrength, teamID, expectedVersion)
+	if err != nil {
+		return fmt.Errorf("SQLiteTeamService.UpdateTeamStrength: Error updating strength for team (ID: %d): %w", teamID, err)
+	}
+	if rowsAffected(result) == 0 {
+		return teamUpdateMissed(ctx, s, "SQLiteTeamService.UpdateTeamStrength", teamID, expectedVersion, "strength")
+	}
+	return nil
+}
+
+// UpdateTeamName renames the team; names must be unique.
+func (s *SQLiteTeamService) UpdateTeamName(ctx context.Context, teamID int, newName string, expectedVersion int) error {
+	trimmedName, err := normalizeTeamName(newName)
+	if err != nil {
+		return err
+	}
+
+	var existingID int
+	err = s.conn(ctx).QueryRowContext(ctx, queries.CreateTeamCheckExistsSQL, trimmedName).Scan(&existingID)
+	if err == nil && existingID != teamID {
+		return fmt.Errorf("%w: '%s' is already in use by team ID %d", abstracts.ErrDuplicateName, trimmedName, existingID)
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("SQLiteTeamService.UpdateTeamName: Error checking new name '%s': %w", trimmedName, err)
+	}
+
+	result, err := s.conn(ctx).ExecContext(ctx, queries.UpdateTeamNameSQL, trimmedName, teamID, expectedVersion)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return fmt.Errorf("%w: '%s' is already in use", abstracts.ErrDuplicateName, trimmedName)
+		}
+		return fmt.Errorf("SQLiteTeamService.UpdateTeamName: Error updating name for team (ID: %d) to '%s': %w", teamID, trimmedName, err)
+	}
+	if rowsAffected(result) == 0 {
+		return teamUpdateMissed(ctx, s, "SQLiteTeamService.UpdateTeamName", teamID, expectedVersion, "name")
+	}
+	return nil
+}
+
+// UpdateTeamDivision moves the team to another division; 1 is the top division.
+func (s *SQLiteTeamService) UpdateTeamDivision(ctx context.Context, teamID int, division int, expectedVersion int) error {
+	if division < 1 {
+		return fmt.Errorf("%w: division %d. Division must be 1 or greater", abstracts.ErrInvalidInput, division)
+	}
+	result, err := s.conn(ctx).ExecContext(ctx, queries.UpdateTeamDivisionSQL, division, teamID, expectedVersion)
+	if err != nil {
+		return fmt.Errorf("SQLiteTeamService.UpdateTeamDivision: Error updating division for team (ID: %d): %w", teamID, err)
+	}
+	if rowsAffected(result) == 0 {
+		return teamUpdateMissed(ctx, s, "SQLiteTeamService.UpdateTeamDivision", teamID, expectedVersion, "division")
+	}
+	return nil
+}
+
+// ApplyPointsAdjustment records a points deduction or bonus together with the team's adjustment total.
+func (s *SQLiteTeamService) ApplyPointsAdjustment(ctx context.Context, teamID int, points int, reason string) (models.PointsAdjustment, error) {
+	adjustment := models.PointsAdjustment{TeamID: teamID, Points: points, Reason: strings.TrimSpace(reason)}
+	if err := validatePointsAdjustment(adjustment); err != nil {
+		return models.PointsAdjustment{}, err
+	}
+
+	tx, err := database.BeginSQLite(ctx, s.DB)
+	if err != nil {
+		return models.PointsAdjustment{}, fmt.Errorf("SQLiteTeamService.ApplyPointsAdjustment: Could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, queries.ApplyTeamPointsAdjustmentSQL, points, teamID)
+	if err != nil {
+		return models.PointsAdjustment{}, fmt.Errorf("SQLiteTeamService.ApplyPointsAdjustment: Error adjusting points for team (ID: %d): %w", teamID, err)
+	}
+	if rowsAffected(result) == 0 {
+		return models.PointsAdjustment{}, fmt.Errorf("SQLiteTeamService.ApplyPointsAdjustment: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	err = tx.QueryRowContext(ctx, queries.InsertPointsAdjustmentSQL, teamID, points, adjustment.Reason).Scan(&adjustment.ID, &adjustment.CreatedAt)
+	if err != nil {
+		return models.PointsAdjustment{}, fmt.Errorf("SQLiteTeamService.ApplyPointsAdjustment: Error recording adjustment for team (ID: %d): %w", teamID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return models.PointsAdjustment{}, fmt.Errorf("SQLiteTeamService.ApplyPointsAdjustment: Could not commit transaction: %w", err)
+	}
+	return adjustment, nil
+}
+
+// GetPointsAdjustments returns the adjustments in the order they were applied; teamID 0 returns all teams.
+func (s *SQLiteTeamService) GetPointsAdjustments(ctx context.Context, teamID int) ([]models.PointsAdjustment, error) {
+	var rows *sql.Rows
+	var err error
+	if teamID == 0 {
+		rows, err = s.conn(ctx).QueryContext(ctx, queries.GetPointsAdjustmentsSQL)
+	} else {
+		rows, err = s.conn(ctx).QueryContext(ctx, queries.GetPointsAdjustmentsByTeamSQL, teamID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SQLiteTeamService.GetPointsAdjustments: Error retrieving points adjustments: %w", err)
+	}
+	defer rows.Close()
+	var adjustments []models.PointsAdjustment
+	for rows.Next() {
+		var adjustment models.PointsAdjustment
+		if err := rows.Scan(&adjustment.ID, &adjustment.TeamID, &adjustment.Points, &adjustment.Reason, &adjustment.CreatedAt); err != nil {
+			return nil, fmt.Errorf("SQLiteTeamService.GetPointsAdjustments: Error scanning adjustment row: %w", err)
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("SQLiteTeamService.GetPointsAdjustments: Error processing rows: %w", err)
+	}
+	return adjustments, nil
+}
+
+// queryTeams runs a team SELECT from the queries package and scans every row.
+func (s *SQLiteTeamService) queryTeams(ctx context.Context, query string, args ...any) ([]models.Team, error) {
+	rows, err := s.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving teams: %w", err)
+	}
+	defer rows.Close()
+	var teams []models.Team
+	for rows.Next() {
+		var team models.Team
+		if err := scanTeam(rows, &team); err != nil {
+			return nil, fmt.Errorf("Error scanning team row: %w", err)
+		}
+		teams = append(teams, team)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("Error processing rows: %w", err)
+	}
+	return teams, nil
+}
+
+// rowsAffected returns the number of rows changed by a statement; SQLite always reports it.
+func rowsAffected(result sql.Result) int64 {
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0
+	}
+	return count
+}
@@ -1,32 +1,39 @@
 package concretes
 
 import (
+	"MatchSimulator_Insider/database"
+	"MatchSimulator_Insider/logging"
 	"MatchSimulator_Insider/models" 
 	"MatchSimulator_Insider/queries"
 	"MatchSimulator_Insider/services/abstracts"
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings" 
 
 	"github.com/jackc/pgx/v5"
 )
 
 type PostgresTeamService struct {
-	DB *pgx.Conn
+	DB database.DB // Bağlantı havuzu; istek bir transaction içindeyse sorgular o transaction üzerinden çalışır
 }
 
 
-func NewPostgresTeamService(db *pgx.Conn) abstracts.TeamService {
+func NewPostgresTeamService(db database.DB) abstracts.TeamService {
 	return &PostgresTeamService{DB: db}
 }
 
+// conn, context'te bir transaction varsa onu, yoksa havuzu döndürür.
+func (s *PostgresTeamService) conn(ctx context.Context) database.DB {
+	return database.Conn(ctx, s.DB)
+}
+
 
 func (s *PostgresTeamService) CreateTeam(ctx context.Context, team models.Team) (int, error) {
 	var id int
 	// Veritabanında team nesnesi ile aynı isimde bir takım olup olmadığını kontrol ediyoruz. Eğer varsa bu takımın id'sini id değişkenine atayacak
-	err := s.DB.QueryRow(ctx, queries.CreateTeamCheckExistsSQL, team.Name).Scan(&id)
+	err := s.conn(ctx).QueryRow(ctx, queries.CreateTeamCheckExistsSQL, team.Name).Scan(&id)
 	// Eğer err = nil ise böyle bir takım bulunmuş demektir
 	if err == nil {
 		return id, nil
@@ -37,8 +44,13 @@ func (s *PostgresTeamService) CreateTeam(ctx context.Context, team models.Team)
 	}
 
 	
-	err = s.DB.QueryRow(ctx, queries.CreateTeamInsertSQL,
-		team.Name, team.Strength,
+	// Lig belirtilmemişse takım en üst lige (1) eklenir
+	division := team.Division
+	if division < 1 {
+		division = 1
+	}
+	err = s.conn(ctx).QueryRow(ctx, queries.CreateTeamInsertSQL,
+		team.Name, team.Strength, division,
 	).Scan(&id)
 
 	if err != nil {
@@ -54,13 +66,10 @@ func (s *PostgresTeamService) GetTeamByID(ctx context.Context, id int) (*models.
 	var team models.Team
 	
 	// Scan komutu ile bütün değişkenler team nesnesine yazılır
-	err := s.DB.QueryRow(ctx, queries.GetTeamByIDSQL, id).Scan(
-		&team.ID, &team.Name, &team.Strength, &team.Played, &team.Wins, &team.Draws,
-		&team.Losses, &team.GoalsFor, &team.GoalsAgainst, &team.GoalDifference, &team.Points,
-	)
+	err := scanTeam(s.conn(ctx).QueryRow(ctx, queries.GetTeamByIDSQL, id), &team)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("PostgresTeamService.GetTeamByID: Team with ID %d not found", id)
+			return nil, fmt.Errorf("PostgresTeamService.GetTeamByID: %w (ID: %d)", abstracts.ErrTeamNotFound, id)
 		}
 		return nil, fmt.Errorf("PostgresTeamService.GetTeamByID: Error retrieving team (ID: %d): %w", id, err)
 	}
@@ -68,8 +77,18 @@ func (s *PostgresTeamService) GetTeamByID(ctx context.Context, id int) (*models.
 }
 
 
+// pgx ve database/sql satırları için ortak takım okuma yardımcısı; kolon sırası queries paketindeki SELECT'lerle aynıdır.
+func scanTeam(row pgx.Row, team *models.Team) error {
+	return row.Scan(
+		&team.ID, &team.Name, &team.Strength, &team.Played, &team.Wins, &team.Draws,
+		&team.Losses, &team.GoalsFor, &team.GoalsAgainst, &team.GoalDifference, &team.Points,
+		&team.Division, &team.Adjustment, &team.Version,
+	)
+}
+
+
 func (s *PostgresTeamService) GetAllTeams(ctx context.Context) ([]models.Team, error) {
-	rows, err := s.DB.Query(ctx, queries.GetAllTeamsSQL)
+	rows, err := s.conn(ctx).Query(ctx, queries.GetAllTeamsSQL)
 	if err != nil {
 		return nil, fmt.Errorf("PostgresTeamService.GetAllTeams: Error retrieving teams: %w", err)
 	}
@@ -81,7 +100,7 @@ func (s *PostgresTeamService) GetAllTeams(ctx context.Context) ([]models.Team, e
 	// rows nesnesinin bütün satırları taranır ve teams slice'ına eklenir
 	for rows.Next() {
 		var team models.Team
-		if err := rows.Scan(&team.ID, &team.Name, &team.Strength, &team.Played, &team.Wins, &team.Draws, &team.Losses, &team.GoalsFor, &team.GoalsAgainst, &team.GoalDifference, &team.Points); err != nil {
+		if err := scanTeam(rows, &team); err != nil {
 			return nil, fmt.Errorf("PostgresTeamService.GetAllTeams: Error scanning team row: %w", err)
 		}
 		teams = append(teams, team)
@@ -107,7 +126,7 @@ func (s *PostgresTeamService) UpdateTeamStatsAfterMatch(ctx context.Context, tea
 	}
 
 	// Bir transaction başlatılır
-	tx, err := s.DB.Begin(ctx)
+	tx, err := s.conn(ctx).Begin(ctx)
 	
 	if err != nil {
 		return fmt.Errorf("PostgresTeamService.UpdateTeamStatsAfterMatch: Could not begin transaction: %w", err)
@@ -125,7 +144,7 @@ func (s *PostgresTeamService) UpdateTeamStatsAfterMatch(ctx context.Context, tea
 		return fmt.Errorf("PostgresTeamService.UpdateTeamStatsAfterMatch: Error updating main stats for team (ID: %d): %w", teamID, err)
 	}
 	if cmdTag.RowsAffected() == 0 { // UPDATE komutu hiçbir satırı etkilemediyse o ID'de bir takım yoktur
-		return fmt.Errorf("PostgresTeamService.UpdateTeamStatsAfterMatch: Team (ID: %d) not found (main update)", teamID)
+		return fmt.Errorf("PostgresTeamService.UpdateTeamStatsAfterMatch: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
 	}
 
 	// Gol averajı güncellenir
@@ -137,21 +156,74 @@ func (s *PostgresTeamService) UpdateTeamStatsAfterMatch(ctx context.Context, tea
 	return tx.Commit(ctx)
 }
 
+// RevertTeamStatsAfterMatch, UpdateTeamStatsAfterMatch ile eklenen maç sonucunu takımın istatistiklerinden çıkarır.
+func (s *PostgresTeamService) RevertTeamStatsAfterMatch(ctx context.Context, teamID int, goalsScored int, goalsConceded int) error {
+	pointsEarned, wins, draws, losses := calculateOutcomeMetrics(goalsScored, goalsConceded)
+
+	tx, err := s.conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("PostgresTeamService.RevertTeamStatsAfterMatch: Could not begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
-// Name ve Strength dışında bütün takım istatistiklerini sıfırlar
+	cmdTag, err := tx.Exec(ctx, queries.RevertTeamMainStatsSQL, wins, draws, losses, goalsScored, goalsConceded, pointsEarned, teamID)
+	if err != nil {
+		return fmt.Errorf("PostgresTeamService.RevertTeamStatsAfterMatch: Error reverting main stats for team (ID: %d): %w", teamID, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("PostgresTeamService.RevertTeamStatsAfterMatch: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	if _, err = tx.Exec(ctx, queries.UpdateTeamGDSQL, teamID); err != nil {
+		return fmt.Errorf("PostgresTeamService.RevertTeamStatsAfterMatch: Error updating goal difference for team (ID: %d): %w", teamID, err)
+	}
+	return tx.Commit(ctx)
+}
+
+
+// Name ve Strength dışında bütün takım istatistiklerini sıfırlar. Puan düzeltmeleri sezona ait olduğu için onlar da silinir.
 func (s *PostgresTeamService) ResetAllTeamStats(ctx context.Context) error {
-	cmdTag, err := s.DB.Exec(ctx, queries.ResetAllTeamStatsSQL)
+	tx, err := s.conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("PostgresTeamService.ResetAllTeamStats: Could not begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	cmdTag, err := tx.Exec(ctx, queries.ResetAllTeamStatsSQL)
 	if err != nil {
-		log.Printf("!!! PostgresTeamService.ResetAllTeamStats DB.Exec ERROR: %v", err)
 		return fmt.Errorf("PostgresTeamService.ResetAllTeamStats: Error resetting team statistics: %w", err)
 	}
-	log.Printf("Team statistics reset. Rows affected: %d", cmdTag.RowsAffected())
+	if _, err := tx.Exec(ctx, queries.DeleteAllPointsAdjustmentsSQL); err != nil {
+		return fmt.Errorf("PostgresTeamService.ResetAllTeamStats: Error clearing points adjustments: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("PostgresTeamService.ResetAllTeamStats: Could not commit transaction: %w", err)
+	}
+	slog.DebugContext(ctx, "team statistics reset", "rows_affected", cmdTag.RowsAffected())
 	if cmdTag.RowsAffected() < 1 { 
-		log.Println("Warning: ResetAllTeamStats affected fewer rows than expected or none at all. Team table might be empty or there's an issue.")
+		slog.WarnContext(ctx, "team statistics reset affected no rows, the team table might be empty")
 	}
 	return nil
 }
 
+// DeleteAllTeams, bütün takımları siler. Maçlar ve puan düzeltmeleri foreign key cascade ile birlikte silinir.
+func (s *PostgresTeamService) DeleteAllTeams(ctx context.Context) error {
+	if _, err := s.conn(ctx).Exec(ctx, queries.DeleteAllTeamsSQL); err != nil {
+		return fmt.Errorf("PostgresTeamService.DeleteAllTeams: Error deleting teams: %w", err)
+	}
+	return nil
+}
+
+// DeleteTeam, takımı siler. Takımın maçları ve puan düzeltmeleri foreign key cascade ile birlikte silinir.
+func (s *PostgresTeamService) DeleteTeam(ctx context.Context, teamID int) error {
+	cmdTag, err := s.conn(ctx).Exec(ctx, queries.DeleteTeamSQL, teamID)
+	if err != nil {
+		return fmt.Errorf("PostgresTeamService.DeleteTeam: Error deleting team (ID: %d): %w", teamID, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("PostgresTeamService.DeleteTeam: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
+	}
+	return nil
+}
 
 func calculateOutcomeMetrics(goalsFor, goalsAgainst int) (points, wins, draws, losses int) {
 	if goalsFor > goalsAgainst {
@@ -168,9 +240,6 @@ func calculateOutcomeMetrics(goalsFor, goalsAgainst int) (points, wins, draws, l
 
 
 func (s *PostgresTeamService) AdjustTeamStatsForScoreChange(ctx context.Context, teamID int, oldGoalsForTeam, oldGoalsAgainstTeam, newGoalsForTeam, newGoalsAgainstTeam int) error {
-	log.Printf("PostgresTeamService.AdjustTeamStatsForScoreChange: TeamID: %d, OldScore: %d-%d, NewScore: %d-%d\n",
-		teamID, oldGoalsForTeam, oldGoalsAgainstTeam, newGoalsForTeam, newGoalsAgainstTeam)
-
 	oldPoints, oldWins, oldDraws, oldLosses := calculateOutcomeMetrics(oldGoalsForTeam, oldGoalsAgainstTeam)
 	newPoints, newWins, newDraws, newLosses := calculateOutcomeMetrics(newGoalsForTeam, newGoalsAgainstTeam)
 
@@ -182,10 +251,10 @@ func (s *PostgresTeamService) AdjustTeamStatsForScoreChange(ctx context.Context,
 	deltaGoalsAgainst := newGoalsAgainstTeam - oldGoalsAgainstTeam  // Yenilen gol farkı
 	
 
-	log.Printf("  Calculated Deltas: dPts:%d, dW:%d, dD:%d, dL:%d, dGF:%d, dGA:%d\n",
-		deltaPoints, deltaWins, deltaDraws, deltaLosses, deltaGoalsFor, deltaGoalsAgainst)
+	slog.DebugContext(ctx, "adjusting team statistics for a score change", logging.KeyTeamID, teamID,
+		"points", deltaPoints, "wins", deltaWins, "draws", deltaDraws, "losses", deltaLosses, "goals_for", deltaGoalsFor, "goals_against", deltaGoalsAgainst)
 
-	tx, err := s.DB.Begin(ctx)
+	tx, err := s.conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("AdjustTeamStatsForScoreChange: Could not begin transaction: %w", err)
 	}
@@ -201,7 +270,7 @@ func (s *PostgresTeamService) AdjustTeamStatsForScoreChange(ctx context.Context,
 		return fmt.Errorf("AdjustTeamStatsForScoreChange: Error updating main stats for team (ID: %d): %w", teamID, err)
 	}
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("AdjustTeamStatsForScoreChange: Team (ID: %d) not found (main update)", teamID)
+		return fmt.Errorf("AdjustTeamStatsForScoreChange: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
 	}
 
 	// Averaj yeniden hesaplanır
@@ -214,42 +283,41 @@ func (s *PostgresTeamService) AdjustTeamStatsForScoreChange(ctx context.Context,
 		return fmt.Errorf("AdjustTeamStatsForScoreChange: Could not commit transaction: %w", err)
 	}
 
-	log.Printf("  Team (ID: %d) statistics successfully adjusted for score change.\n", teamID)
 	return nil
 }
 
 
-func (s *PostgresTeamService) UpdateTeamStrength(ctx context.Context, teamID int, newStrength int) error {
+func (s *PostgresTeamService) UpdateTeamStrength(ctx context.Context, teamID int, newStrength int, expectedVersion int) error {
 	// Strength 1 ile 100 arasında bir değer almalıdır
 	if newStrength < 1 || newStrength > 100 {
-		return fmt.Errorf("invalid strength value: %d. Strength must be between 1 and 100", newStrength)
+		return fmt.Errorf("%w: %d. Strength must be between 1 and 100", abstracts.ErrInvalidStrength, newStrength)
 	}
 
-	cmdTag, err := s.DB.Exec(ctx, queries.UpdateTeamStrengthSQL, newStrength, teamID)
+	cmdTag, err := s.conn(ctx).Exec(ctx, queries.UpdateTeamStrengthSQL, newStrength, teamID, expectedVersion)
 	if err != nil {
 		return fmt.Errorf("PostgresTeamService.UpdateTeamStrength: Error updating strength for team (ID: %d): %w", teamID, err)
 	}
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("PostgresTeamService.UpdateTeamStrength: Team (ID: %d) not found or strength not updated", teamID)
+		return teamUpdateMissed(ctx, s, "PostgresTeamService.UpdateTeamStrength", teamID, expectedVersion, "strength")
 	}
-	log.Printf("Team (ID: %d) strength successfully updated to %d.", teamID, newStrength)
+	slog.InfoContext(ctx, "team strength updated", logging.KeyTeamID, teamID, "strength", newStrength)
 	return nil
 }
 
 
 
-func (s *PostgresTeamService) UpdateTeamName(ctx context.Context, teamID int, newName string) error {
-	// Takım isminin başındaki ve sonundaki boşluk karakterleri temizlenir
-	trimmedName := strings.TrimSpace(newName)
-	if trimmedName == "" {
-		return fmt.Errorf("team name cannot be empty")
+func (s *PostgresTeamService) UpdateTeamName(ctx context.Context, teamID int, newName string, expectedVersion int) error {
+	// Takım ismi kırpılır; boş ya da teams.name sütununa sığmayacak kadar uzun isimler reddedilir
+	trimmedName, err := normalizeTeamName(newName)
+	if err != nil {
+		return err
 	}
 
 	// Yeni Name'in benzersizliği kontrol edilir
 	var existingID int
-	err := s.DB.QueryRow(ctx, queries.CreateTeamCheckExistsSQL, trimmedName).Scan(&existingID)
+	err = s.conn(ctx).QueryRow(ctx, queries.CreateTeamCheckExistsSQL, trimmedName).Scan(&existingID)
 	if err == nil && existingID != teamID { // Aynı isimde farklı bir takım bulundu
-		return fmt.Errorf("name '%s' is already in use by another team (ID: %d)", trimmedName, existingID)
+		return fmt.Errorf("%w: '%s' is already in use by team ID %d", abstracts.ErrDuplicateName, trimmedName, existingID)
 	}
 	// Eğer hata ismi pgx.ErrNoRows değilse, kontrol aşamasında bilinmeyen bir hata oluşmuş demektir
 	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
@@ -257,118 +325,107 @@ func (s *PostgresTeamService) UpdateTeamName(ctx context.Context, teamID int, ne
 	}
 	
 	// Kontrol aşaması bitti, yeni isim güncelleme aşamasına geçilebilir
-	cmdTag, err := s.DB.Exec(ctx, queries.UpdateTeamNameSQL, trimmedName, teamID)
+	cmdTag, err := s.conn(ctx).Exec(ctx, queries.UpdateTeamNameSQL, trimmedName, teamID, expectedVersion)
 	if err != nil {
 		if strings.Contains(err.Error(), "violates unique constraint") || strings.Contains(err.Error(), "duplicate key") { // Benzersizlik hatası
-			return fmt.Errorf("name '%s' is already in use or another unique constraint was violated", trimmedName)
+			return fmt.Errorf("%w: '%s' is already in use", abstracts.ErrDuplicateName, trimmedName)
 		}
 		return fmt.Errorf("PostgresTeamService.UpdateTeamName: Error updating name for team (ID: %d) to '%s': %w", teamID, trimmedName, err) // Diğer hatalar
 	}
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("PostgresTeamService.UpdateTeamName: Team (ID: %d) not found or name not updated", teamID)
+		return teamUpdateMissed(ctx, s, "PostgresTeamService.UpdateTeamName", teamID, expectedVersion, "name")
 	}
-	log.Printf("Team (ID: %d) name successfully updated to '%s'.", teamID, trimmedName)
+	slog.InfoContext(ctx, "team renamed", logging.KeyTeamID, teamID, "name", trimmedName)
 	return nil
 }
 
-// Name ve Strengthler ile birlikte bütün istatistikler sıfırlanır
-func (s *PostgresTeamService) ResetTeamsToDefaults(ctx context.Context) error {
-	log.Println("--- PostgresTeamService.ResetTeamsToDefaults STARTED ---")
-
-	defaultTeams := []models.Team{
-		{Name: "Chelsea", Strength: 85}, 
-		{Name: "Arsenal", Strength: 82},
-		{Name: "Manchester City", Strength: 90},
-		{Name: "Liverpool", Strength: 88},
+// Takımı verilen lige (kademeye) taşır. 1 en üst ligdir.
+func (s *PostgresTeamService) UpdateTeamDivision(ctx context.Context, teamID int, division int, expectedVersion int) error {
+	if division < 1 {
+		return fmt.Errorf("%w: division %d. Division must be 1 or greater", abstracts.ErrInvalidInput, division)
 	}
 
-	// Önce bütün eski istatistikler sıfırlanmalı
-	// Önceden oluşturduğumuz ResetAllTeamStats fonksiyonu ile takım puanlarını, gol sayılarını vs. sıfırlarız.
-	if err := s.ResetAllTeamStats(ctx); err != nil {
-		
-		return fmt.Errorf("ResetTeamsToDefaults: Error while resetting team statistics: %w", err)
-	}
-
-	// Güncellemelerin tutarlı olması için takımlar sıralı şekilde alınır.
-	currentTeams, err := s.getAllTeamsOrderedByID(ctx)
+	cmdTag, err := s.conn(ctx).Exec(ctx, queries.UpdateTeamDivisionSQL, division, teamID, expectedVersion)
 	if err != nil {
-		return fmt.Errorf("ResetTeamsToDefaults: Error fetching current teams after stat reset: %w", err)
+		return fmt.Errorf("PostgresTeamService.UpdateTeamDivision: Error updating division for team (ID: %d): %w", teamID, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return teamUpdateMissed(ctx, s, "PostgresTeamService.UpdateTeamDivision", teamID, expectedVersion, "division")
 	}
+	slog.InfoContext(ctx, "team moved to another division", logging.KeyTeamID, teamID, logging.KeyLeagueID, division)
+	return nil
+}
 
-	
-	if len(currentTeams) < len(defaultTeams) {
-		log.Printf("ResetTeamsToDefaults: Warning! Database has %d teams, but %d default configurations exist. Will update available teams.", len(currentTeams), len(defaultTeams))
-		if len(currentTeams) == 0 && len(defaultTeams) > 0 {
-			return fmt.Errorf("ResetTeamsToDefaults: No teams in database to reset to defaults. Please ensure teams are seeded first.")
-		}
+// Takıma gerekçesiyle birlikte puan silme (negatif) veya ekleme (pozitif) uygular.
+// Düzeltme kaydı ve takımın toplam düzeltmesi aynı transaction içinde güncellenir.
+func (s *PostgresTeamService) ApplyPointsAdjustment(ctx context.Context, teamID int, points int, reason string) (models.PointsAdjustment, error) {
+	adjustment := models.PointsAdjustment{TeamID: teamID, Points: points, Reason: strings.TrimSpace(reason)}
+	if err := validatePointsAdjustment(adjustment); err != nil {
+		return models.PointsAdjustment{}, err
 	}
 
-	
-	tx, err := s.DB.Begin(ctx)
+	tx, err := s.conn(ctx).Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("ResetTeamsToDefaults: Could not begin transaction: %w", err)
+		return models.PointsAdjustment{}, fmt.Errorf("PostgresTeamService.ApplyPointsAdjustment: Could not begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	// Varolan takımlar varsayılan takımlarla güncellenir
-	for i, defaultTeam := range defaultTeams {
-		// Her bir varsayılan takıma karşılık veritabanında takım olup olmadığı kontrol edilir
-		if i < len(currentTeams) {
-			teamToUpdate := currentTeams[i] // Update teams in their current ID order
-			log.Printf("  Resetting to default: Team ID %d -> New Name: %s, New Strength: %d", teamToUpdate.ID, defaultTeam.Name, defaultTeam.Strength)
-
-			// Yeni name'in benzersizliği kontrol edilir
-			var conflictingID int
-			errNameCheck := tx.QueryRow(ctx, queries.CreateTeamCheckExistsSQL, defaultTeam.Name).Scan(&conflictingID)
-			if errNameCheck == nil && conflictingID != teamToUpdate.ID { // Veritabanında aynı isimli bir takım daha bulundu
-				
-				return fmt.Errorf("ResetTeamsToDefaults: Default name '%s' for team ID %d is already in use by team ID %d", defaultTeam.Name, teamToUpdate.ID, conflictingID)
-			}
-			if errNameCheck != nil && !errors.Is(errNameCheck, pgx.ErrNoRows) { // Farklı bir hata oluştu
-				return fmt.Errorf("ResetTeamsToDefaults: Error checking name conflict for '%s': %w", defaultTeam.Name, errNameCheck)
-			}
-			
-			// Mevcut takım default'a güncellenir
-			cmdTag, err := tx.Exec(ctx, queries.UpdateTeamNameAndStrengthSQL, defaultTeam.Name, defaultTeam.Strength, teamToUpdate.ID)
-			if err != nil {
-				return fmt.Errorf("ResetTeamsToDefaults: Error updating name/strength for team (ID: %d): %w", teamToUpdate.ID, err)
-			}
-			if cmdTag.RowsAffected() == 0 {
-				// currentTeams[i] mevcutsa bu hatayı vermemesi gerekir. Gerçekleşmesi çok olası olmayan bir hata.
-				log.Printf("ResetTeamsToDefaults: Name/strength update for team (ID: %d) affected 0 rows.", teamToUpdate.ID)
-			}
-		} else { // Veritabanında default team'e karşılık gelecek takım yoksa bu hata döndürülür
-			log.Printf("ResetTeamsToDefaults: Warning: No existing team in DB at index %d to map to default team '%s'.", i, defaultTeam.Name)
-		}
+	cmdTag, err := tx.Exec(ctx, queries.ApplyTeamPointsAdjustmentSQL, points, teamID)
+	if err != nil {
+		return models.PointsAdjustment{}, fmt.Errorf("PostgresTeamService.ApplyPointsAdjustment: Error adjusting points for team (ID: %d): %w", teamID, err)
 	}
-
-	// For döngüsü tamamlandıktan sonra transaction onaylanarak değişiklikler veritabanında kalıcı hale getirilir
-	if err = tx.Commit(ctx); err != nil {
-		return fmt.Errorf("ResetTeamsToDefaults: Could not commit transaction: %w", err)
+	if cmdTag.RowsAffected() == 0 {
+		return models.PointsAdjustment{}, fmt.Errorf("PostgresTeamService.ApplyPointsAdjustment: %w (ID: %d)", abstracts.ErrTeamNotFound, teamID)
 	}
-
-	log.Println("PostgresTeamService.ResetTeamsToDefaults: Team names and strengths (and stats) reset to defaults.")
-	log.Println("--- PostgresTeamService.ResetTeamsToDefaults FINISHED ---")
-	return nil
+	err = tx.QueryRow(ctx, queries.InsertPointsAdjustmentSQL, teamID, points, adjustment.Reason).Scan(&adjustment.ID, &adjustment.CreatedAt)
+	if err != nil {
+		return models.PointsAdjustment{}, fmt.Errorf("PostgresTeamService.ApplyPointsAdjustment: Error recording adjustment for team (ID: %d): %w", teamID, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return models.PointsAdjustment{}, fmt.Errorf("PostgresTeamService.ApplyPointsAdjustment: Could not commit transaction: %w", err)
+	}
+	slog.InfoContext(ctx, "team points adjusted", logging.KeyTeamID, teamID, "points", points, "reason", adjustment.Reason)
+	return adjustment, nil
 }
 
-// Veritabanındaki takımları ID'ye göre artan sırada almak için oluşturulan helper method. (unexported)
-func (s *PostgresTeamService) getAllTeamsOrderedByID(ctx context.Context) ([]models.Team, error) {
-	rows, err := s.DB.Query(ctx, queries.GetAllTeamsOrderedByIDSQL)
+// Puan düzeltmelerini uygulanma sırasıyla getirir. teamID 0 ise tüm takımların düzeltmeleri döner.
+func (s *PostgresTeamService) GetPointsAdjustments(ctx context.Context, teamID int) ([]models.PointsAdjustment, error) {
+	var rows pgx.Rows
+	var err error
+	if teamID == 0 {
+		rows, err = s.conn(ctx).Query(ctx, queries.GetPointsAdjustmentsSQL)
+	} else {
+		rows, err = s.conn(ctx).Query(ctx, queries.GetPointsAdjustmentsByTeamSQL, teamID)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("PostgresTeamService.getAllTeamsOrderedByID: Error retrieving teams ordered by ID: %w", err)
+		return nil, fmt.Errorf("PostgresTeamService.GetPointsAdjustments: Error retrieving points adjustments: %w", err)
 	}
 	defer rows.Close()
-	var teams []models.Team
+	var adjustments []models.PointsAdjustment
 	for rows.Next() {
-		var team models.Team
-		if err := rows.Scan(&team.ID, &team.Name, &team.Strength, &team.Played, &team.Wins, &team.Draws, &team.Losses, &team.GoalsFor, &team.GoalsAgainst, &team.GoalDifference, &team.Points); err != nil {
-			return nil, fmt.Errorf("PostgresTeamService.getAllTeamsOrderedByID: Error scanning team row: %w", err)
+		var adjustment models.PointsAdjustment
+		if err := rows.Scan(&adjustment.ID, &adjustment.TeamID, &adjustment.Points, &adjustment.Reason, &adjustment.CreatedAt); err != nil {
+			return nil, fmt.Errorf("PostgresTeamService.GetPointsAdjustments: Error scanning adjustment row: %w", err)
 		}
-		teams = append(teams, team)
+		adjustments = append(adjustments, adjustment)
 	}
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("PostgresTeamService.getAllTeamsOrderedByID: Error processing rows: %w", err)
+		return nil, fmt.Errorf("PostgresTeamService.GetPointsAdjustments: Error processing rows: %w", err)
 	}
-	return teams, nil
+	return adjustments, nil
 }
+
+// validatePointsAdjustment, bir puan düzeltmesinin sıfır olmadığını, makul sınırlar içinde kaldığını ve gerekçesi olduğunu doğrular.
+func validatePointsAdjustment(adjustment models.PointsAdjustment) error {
+	if adjustment.Points == 0 {
+		return fmt.Errorf("%w: points adjustment cannot be zero", abstracts.ErrInvalidInput)
+	}
+	if adjustment.Points < -100 || adjustment.Points > 100 {
+		return fmt.Errorf("%w: points adjustment %d. Adjustment must be between -100 and 100", abstracts.ErrInvalidInput, adjustment.Points)
+	}
+	if adjustment.Reason == "" {
+		return fmt.Errorf("%w: points adjustment needs a reason", abstracts.ErrInvalidInput)
+	}
+	return nil
+}
+
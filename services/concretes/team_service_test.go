@@ -1,6 +1,7 @@
 package concretes 
 
 import (
+	"MatchSimulator_Insider/models"
 	"testing" 
 )
 
@@ -96,3 +97,30 @@ func TestCalculateOutcomeMetrics(t *testing.T) {
 		})
 	}
 }
+
+// TestValidatePointsAdjustment checks the accepted range and the required reason of points adjustments.
+func TestValidatePointsAdjustment(t *testing.T) {
+	testCases := []struct {
+		name        string
+		adjustment  models.PointsAdjustment
+		expectError bool
+	}{
+		{name: "Puan Silme", adjustment: models.PointsAdjustment{TeamID: 1, Points: -10, Reason: "Financial breach"}},
+		{name: "Puan Ekleme", adjustment: models.PointsAdjustment{TeamID: 1, Points: 3, Reason: "Appeal upheld"}},
+		{name: "Sıfır Puan", adjustment: models.PointsAdjustment{TeamID: 1, Points: 0, Reason: "No-op"}, expectError: true},
+		{name: "Sınır Dışı", adjustment: models.PointsAdjustment{TeamID: 1, Points: -101, Reason: "Too much"}, expectError: true},
+		{name: "Gerekçesiz", adjustment: models.PointsAdjustment{TeamID: 1, Points: -10}, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePointsAdjustment(tc.adjustment)
+			if tc.expectError && err == nil {
+				t.Error("Expected an error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Did not expect an error but got: %v", err)
+			}
+		})
+	}
+}
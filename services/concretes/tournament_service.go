@@ -0,0 +1,373 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/logging"
+	"MatchSimulator_Insider/models"
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+// TournamentService runs World Cup style competitions: a group stage drawn from seeded pots,
+// followed by a single-elimination knockout bracket built from the group standings.
+// Drawing and advancing take the league lock of leagueService, since both replace or extend the fixture.
+type TournamentService struct {
+	teamService   abstracts.TeamService
+	matchService  abstracts.IMatchService
+	leagueService abstracts.ILeagueService
+}
+
+// maxGroupCount is the number of groups that can be named with a single letter, A to Z.
+const maxGroupCount = 26
+
+// NewTournamentService creates a new instance of TournamentService.
+func NewTournamentService(ts abstracts.TeamService, ms abstracts.IMatchService, ls abstracts.ILeagueService) abstracts.ITournamentService {
+	return &TournamentService{
+		teamService:   ts,
+		matchService:  ms,
+		leagueService: ls,
+	}
+}
+
+// CreateTournament draws all teams into groups and replaces the current fixture with the group stage.
+// Each pot contributes at most one team per group. Without explicit pots, teams are seeded by strength.
+func (s *TournamentService) CreateTournament(ctx context.Context, groupCount int, pots [][]int) ([]models.TournamentGroup, error) {
+	ctx, unlock, err := lockLeagueVia(ctx, s.leagueService)
+	if err != nil {
+		return nil, fmt.Errorf("TournamentService.CreateTournament: %w", err)
+	}
+	defer unlock()
+
+	teams, err := s.teamService.GetAllTeams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TournamentService.CreateTournament: Could not retrieve teams: %w", err)
+	}
+	if groupCount < 1 || groupCount > maxGroupCount {
+		return nil, fmt.Errorf("TournamentService.CreateTournament: %w: group count must be between 1 and %d, received: %d", abstracts.ErrInvalidInput, maxGroupCount, groupCount)
+	}
+	if len(teams) < groupCount*2 {
+		return nil, fmt.Errorf("TournamentService.CreateTournament: %w: %d groups need at least %d teams, found: %d", abstracts.ErrInvalidInput, groupCount, groupCount*2, len(teams))
+	}
+
+	teamsByID := make(map[int]models.Team, len(teams))
+	for _, t := range teams {
+		teamsByID[t.ID] = t
+	}
+	if len(pots) == 0 {
+		pots = seedPotsByStrength(teams, groupCount)
+	}
+	if err := validatePots(pots, teamsByID, groupCount); err != nil {
+		return nil, fmt.Errorf("TournamentService.CreateTournament: %w", err)
+	}
+
+	groups := drawGroups(pots, groupCount)
+	if err := validateBracketGroups(groups); err != nil {
+		return nil, fmt.Errorf("TournamentService.CreateTournament: %w", err)
+	}
+
+	var fixture []models.Match
+	for i, groupTeamIDs := range groups {
+		groupMatches := buildDoubleRoundRobin(groupTeamIDs, 1)
+		for j := range groupMatches {
+			groupMatches[j].Stage = models.StageGroup
+			groupMatches[j].GroupName = groupName(i)
+		}
+		fixture = append(fixture, groupMatches...)
+	}
+
+	if err := s.teamService.ResetAllTeamStats(ctx); err != nil {
+		return nil, fmt.Errorf("TournamentService.CreateTournament: Error resetting team statistics: %w", err)
+	}
+	if err := s.matchService.StoreMatches(ctx, fixture, true); err != nil {
+		return nil, fmt.Errorf("TournamentService.CreateTournament: Error storing group stage fixture: %w", err)
+	}
+	drawnTeams := 0
+	for _, group := range groups {
+		drawnTeams += len(group)
+	}
+	slog.InfoContext(ctx, "tournament drawn", "teams", drawnTeams, "groups", groupCount, "matches", len(fixture))
+
+	return s.GetGroupStandings(ctx)
+}
+
+// GetGroupStandings builds each group's table from its group stage matches.
+func (s *TournamentService) GetGroupStandings(ctx context.Context) ([]models.TournamentGroup, error) {
+	allMatches, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TournamentService.GetGroupStandings: Could not retrieve matches: %w", err)
+	}
+	teams, err := s.teamService.GetAllTeams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TournamentService.GetGroupStandings: Could not retrieve teams: %w", err)
+	}
+	return groupStandings(allMatches, teams), nil
+}
+
+// GetKnockoutMatches returns the knockout bracket played so far, ordered by round.
+func (s *TournamentService) GetKnockoutMatches(ctx context.Context) ([]models.Match, error) {
+	allMatches, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TournamentService.GetKnockoutMatches: Could not retrieve matches: %w", err)
+	}
+	knockout := filterMatchesByStage(allMatches, models.StageKnockout)
+	sort.SliceStable(knockout, func(i, j int) bool {
+		if knockout[i].Round != knockout[j].Round {
+			return knockout[i].Round < knockout[j].Round
+		}
+		return knockout[i].ID < knockout[j].ID
+	})
+	return knockout, nil
+}
+
+// AdvanceTournament moves the tournament to its next stage once the current one is complete.
+// After the group stage the top advancePerGroup teams of every group enter the bracket; after each
+// knockout round the winners are paired for the next round. When the final is decided the champion is returned.
+func (s *TournamentService) AdvanceTournament(ctx context.Context, advancePerGroup int) (round int, newMatches []models.Match, championTeamID int, err error) {
+	ctx, unlock, err := lockLeagueVia(ctx, s.leagueService)
+	if err != nil {
+		return 0, nil, 0, fmt.Errorf("TournamentService.AdvanceTournament: %w", err)
+	}
+	defer unlock()
+
+	allMatches, err := s.matchService.GetAllMatches(ctx)
+	if err != nil {
+		return 0, nil, 0, fmt.Errorf("TournamentService.AdvanceTournament: Could not retrieve matches: %w", err)
+	}
+
+	groupMatches := filterMatchesByStage(allMatches, models.StageGroup)
+	if len(groupMatches) == 0 {
+		return 0, nil, 0, fmt.Errorf("TournamentService.AdvanceTournament: %w; create one first", abstracts.ErrNoTournament)
+	}
+	for _, match := range groupMatches {
+		if !match.IsPlayed {
+			return 0, nil, 0, fmt.Errorf("TournamentService.AdvanceTournament: %w: the group stage has unplayed matches (match ID %d in week %d)", abstracts.ErrStageNotFinished, match.ID, match.Week)
+		}
+	}
+
+	nextWeek := 1
+	for _, match := range allMatches {
+		if match.Week >= nextWeek {
+			nextWeek = match.Week + 1
+		}
+	}
+
+	knockout, err := s.GetKnockoutMatches(ctx)
+	if err != nil {
+		return 0, nil, 0, fmt.Errorf("TournamentService.AdvanceTournament: %w", err)
+	}
+
+	var pairings [][2]int
+	if len(knockout) == 0 {
+		teams, errTeams := s.teamService.GetAllTeams(ctx)
+		if errTeams != nil {
+			return 0, nil, 0, fmt.Errorf("TournamentService.AdvanceTournament: Could not retrieve teams: %w", errTeams)
+		}
+		qualifiers, errQ := qualifiersFromGroups(groupStandings(allMatches, teams), advancePerGroup)
+		if errQ != nil {
+			return 0, nil, 0, fmt.Errorf("TournamentService.AdvanceTournament: %w", errQ)
+		}
+		round = 1
+		pairings = seededPairings(qualifiers)
+	} else {
+		lastRound := knockout[len(knockout)-1].Round
+		var roundMatches []models.Match
+		for _, match := range knockout {
+			if match.Round == lastRound {
+				roundMatches = append(roundMatches, match)
+			}
+		}
+		winners, errW := tieWinners(roundMatches)
+		if errW != nil {
+			return lastRound, nil, 0, fmt.Errorf("TournamentService.AdvanceTournament: Knockout %w", errW)
+		}
+		if len(winners) == 1 {
+			slog.InfoContext(ctx, "tournament completed", "champion_team_id", winners[0])
+			return lastRound, nil, winners[0], nil
+		}
+		round = lastRound + 1
+		for i := 0; i+1 < len(winners); i += 2 {
+			pairings = append(pairings, [2]int{winners[i], winners[i+1]})
+		}
+	}
+
+	toCreate := buildKnockoutRound(pairings, models.StageKnockout, round, nextWeek, false)
+	if err := s.matchService.StoreMatches(ctx, toCreate, false); err != nil {
+		return round, nil, 0, fmt.Errorf("TournamentService.AdvanceTournament: Error storing knockout round %d: %w", round, err)
+	}
+	newMatches, err = s.matchService.GetMatchesByWeek(ctx, nextWeek)
+	if err != nil {
+		return round, nil, 0, fmt.Errorf("TournamentService.AdvanceTournament: Error retrieving knockout round %d: %w", round, err)
+	}
+	slog.InfoContext(ctx, "knockout round scheduled", "round", round, logging.KeyWeek, nextWeek, "matches", len(newMatches))
+	return round, newMatches, 0, nil
+}
+
+// seedPotsByStrength splits teams into pots of groupCount teams, strongest first.
+func seedPotsByStrength(teams []models.Team, groupCount int) [][]int {
+	seeded := append([]models.Team(nil), teams...)
+	sort.SliceStable(seeded, func(i, j int) bool {
+		return seeded[i].Strength > seeded[j].Strength
+	})
+	var pots [][]int
+	for i := 0; i < len(seeded); i += groupCount {
+		end := i + groupCount
+		if end > len(seeded) {
+			end = len(seeded)
+		}
+		var pot []int
+		for _, t := range seeded[i:end] {
+			pot = append(pot, t.ID)
+		}
+		pots = append(pots, pot)
+	}
+	return pots
+}
+
+// validatePots checks that every team appears in exactly one pot and no pot is bigger than the number of groups.
+func validatePots(pots [][]int, teamsByID map[int]models.Team, groupCount int) error {
+	seen := make(map[int]bool)
+	for i, pot := range pots {
+		if len(pot) > groupCount {
+			return fmt.Errorf("%w: pot %d has %d teams but there are only %d groups", abstracts.ErrInvalidInput, i+1, len(pot), groupCount)
+		}
+		for _, id := range pot {
+			if _, ok := teamsByID[id]; !ok {
+				return fmt.Errorf("%w: pot %d contains unknown team ID %d", abstracts.ErrInvalidInput, i+1, id)
+			}
+			if seen[id] {
+				return fmt.Errorf("%w: team ID %d appears in more than one pot", abstracts.ErrInvalidInput, id)
+			}
+			seen[id] = true
+		}
+	}
+	if len(seen) < groupCount*2 {
+		return fmt.Errorf("%w: pots contain %d teams but %d groups need at least %d", abstracts.ErrInvalidInput, len(seen), groupCount, groupCount*2)
+	}
+	return nil
+}
+
+// drawGroups draws each pot in turn, placing its teams into distinct groups, smallest groups first.
+func drawGroups(pots [][]int, groupCount int) [][]int {
+	groups := make([][]int, groupCount)
+	for _, pot := range pots {
+		drawn := append([]int(nil), pot...)
+		randShuffle(len(drawn), func(i, j int) { drawn[i], drawn[j] = drawn[j], drawn[i] })
+
+		order := make([]int, groupCount)
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return len(groups[order[i]]) < len(groups[order[j]])
+		})
+		for i, teamID := range drawn {
+			groups[order[i]] = append(groups[order[i]], teamID)
+		}
+	}
+	return groups
+}
+
+// validateBracketGroups rejects a draw that can never reach the knockout stage: some number of teams
+// advancing per group, up to the size of the smallest group, has to give a power-of-two bracket.
+func validateBracketGroups(groups [][]int) error {
+	smallestGroup := len(groups[0])
+	for _, group := range groups[1:] {
+		smallestGroup = min(smallestGroup, len(group))
+	}
+	for advancePerGroup := 1; advancePerGroup <= smallestGroup; advancePerGroup++ {
+		if isBracketSize(len(groups) * advancePerGroup) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %d groups cannot form a knockout bracket; the number of groups times the teams advancing per group must be a power of two", abstracts.ErrInvalidInput, len(groups))
+}
+
+// isBracketSize reports whether n teams fill a single-elimination bracket: a power of two, at least 2.
+func isBracketSize(n int) bool {
+	return n >= 2 && n&(n-1) == 0
+}
+
+// groupName returns the letter used for the i-th group (A, B, C, ...).
+func groupName(i int) string {
+	return string(rune('A' + i))
+}
+
+// groupStandings builds each group's table from its group stage matches.
+func groupStandings(allMatches []models.Match, teams []models.Team) []models.TournamentGroup {
+	teamsByID := make(map[int]models.Team, len(teams))
+	for _, t := range teams {
+		teamsByID[t.ID] = t
+	}
+
+	tables := make(map[string]map[int]*models.Team)
+	for _, match := range filterMatchesByStage(allMatches, models.StageGroup) {
+		table, ok := tables[match.GroupName]
+		if !ok {
+			table = make(map[int]*models.Team)
+			tables[match.GroupName] = table
+		}
+		for _, id := range []int{match.HomeTeamID, match.AwayTeamID} {
+			if _, ok := table[id]; !ok {
+				t := teamsByID[id]
+				table[id] = &models.Team{ID: t.ID, Name: t.Name, Strength: t.Strength}
+			}
+		}
+		if match.IsPlayed && match.HomeGoals != nil && match.AwayGoals != nil {
+			updateTeamStatsInMemory(table[match.HomeTeamID], *match.HomeGoals, *match.AwayGoals)
+			updateTeamStatsInMemory(table[match.AwayTeamID], *match.AwayGoals, *match.HomeGoals)
+		}
+	}
+
+	var names []string
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	groups := make([]models.TournamentGroup, 0, len(names))
+	for _, name := range names {
+		standings := make([]models.Team, 0, len(tables[name]))
+		for _, t := range tables[name] {
+			standings = append(standings, *t)
+		}
+		// Eşitlik durumunda sonuç her çağrıda aynı olsun diye önce ID'ye göre sıralanır
+		sort.Slice(standings, func(i, j int) bool { return standings[i].ID < standings[j].ID })
+		sortLeagueTable(standings)
+		groups = append(groups, models.TournamentGroup{Name: name, Standings: standings})
+	}
+	return groups
+}
+
+// qualifiersFromGroups returns the teams advancing to the knockout stage, seeded by finishing position:
+// all group winners first, then all runners-up, and so on.
+func qualifiersFromGroups(groups []models.TournamentGroup, advancePerGroup int) ([]int, error) {
+	if advancePerGroup < 1 {
+		return nil, fmt.Errorf("%w: at least one team per group must advance, received: %d", abstracts.ErrInvalidInput, advancePerGroup)
+	}
+	var qualifiers []int
+	for position := 0; position < advancePerGroup; position++ {
+		for _, group := range groups {
+			if position >= len(group.Standings) {
+				return nil, fmt.Errorf("%w: group %s has only %d teams, cannot advance %d", abstracts.ErrInvalidInput, group.Name, len(group.Standings), advancePerGroup)
+			}
+			qualifiers = append(qualifiers, group.Standings[position].ID)
+		}
+	}
+	if !isBracketSize(len(qualifiers)) {
+		return nil, fmt.Errorf("%w: %d qualifiers cannot form a knockout bracket; the number of qualifiers must be a power of two", abstracts.ErrInvalidInput, len(qualifiers))
+	}
+	return qualifiers, nil
+}
+
+// filterMatchesByStage returns the matches of the given stage, keeping their order.
+func filterMatchesByStage(matches []models.Match, stage string) []models.Match {
+	var filtered []models.Match
+	for _, match := range matches {
+		if match.Stage == stage {
+			filtered = append(filtered, match)
+		}
+	}
+	return filtered
+}
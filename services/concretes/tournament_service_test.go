@@ -0,0 +1,148 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/models"
+	"reflect"
+	"testing"
+)
+
+// TestDrawGroups checks that teams from the same pot never end up in the same group.
+func TestDrawGroups(t *testing.T) {
+	pots := [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}, {13, 14}}
+
+	for i := 0; i < 20; i++ {
+		groups := drawGroups(pots, 4)
+		if len(groups) != 4 {
+			t.Fatalf("Expected 4 groups, got %d", len(groups))
+		}
+		drawn := 0
+		for g, group := range groups {
+			if len(group) < 3 || len(group) > 4 {
+				t.Errorf("Group %d has %d teams, expected 3 or 4", g, len(group))
+			}
+			potsInGroup := make(map[int]bool)
+			for _, teamID := range group {
+				for p, pot := range pots {
+					for _, id := range pot {
+						if id == teamID {
+							if potsInGroup[p] {
+								t.Errorf("Group %d contains two teams from pot %d: %v", g, p+1, group)
+							}
+							potsInGroup[p] = true
+						}
+					}
+				}
+			}
+			drawn += len(group)
+		}
+		if drawn != 14 {
+			t.Errorf("Expected 14 drawn teams, got %d", drawn)
+		}
+	}
+}
+
+// TestValidatePots covers the pot constraints enforced before a draw.
+func TestValidatePots(t *testing.T) {
+	teamsByID := map[int]models.Team{1: {ID: 1}, 2: {ID: 2}, 3: {ID: 3}, 4: {ID: 4}}
+
+	testCases := []struct {
+		name      string
+		pots      [][]int
+		expectErr bool
+	}{
+		{name: "Valid Pots", pots: [][]int{{1, 2}, {3, 4}}, expectErr: false},
+		{name: "Pot Larger Than Group Count", pots: [][]int{{1, 2, 3}, {4}}, expectErr: true},
+		{name: "Unknown Team", pots: [][]int{{1, 2}, {3, 99}}, expectErr: true},
+		{name: "Team In Two Pots", pots: [][]int{{1, 2}, {2, 3}}, expectErr: true},
+		{name: "Too Few Teams", pots: [][]int{{1, 2}, {3}}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePots(tc.pots, teamsByID, 2)
+			if tc.expectErr && err == nil {
+				t.Errorf("Expected an error for pots %v but got nil", tc.pots)
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("Did not expect an error but got: %v", err)
+			}
+		})
+	}
+}
+
+// TestGroupStandings checks that group tables are built only from group matches and ranked like the league table.
+func TestGroupStandings(t *testing.T) {
+	goals := func(n int) *int { return &n }
+	teams := []models.Team{{ID: 1, Name: "A1"}, {ID: 2, Name: "A2"}, {ID: 3, Name: "B1"}, {ID: 4, Name: "B2"}}
+	matches := []models.Match{
+		{ID: 1, Week: 1, HomeTeamID: 1, AwayTeamID: 2, HomeGoals: goals(0), AwayGoals: goals(2), IsPlayed: true, Stage: models.StageGroup, GroupName: "A"},
+		{ID: 2, Week: 1, HomeTeamID: 3, AwayTeamID: 4, HomeGoals: goals(1), AwayGoals: goals(1), IsPlayed: true, Stage: models.StageGroup, GroupName: "B"},
+		{ID: 3, Week: 2, HomeTeamID: 2, AwayTeamID: 1, Stage: models.StageGroup, GroupName: "A"},
+		{ID: 4, Week: 3, HomeTeamID: 4, AwayTeamID: 2, HomeGoals: goals(5), AwayGoals: goals(0), IsPlayed: true, Stage: models.StageKnockout, Round: 1},
+	}
+
+	groups := groupStandings(matches, teams)
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+
+	expectedA := []models.Team{
+		{ID: 2, Name: "A2", Played: 1, Wins: 1, GoalsFor: 2, GoalDifference: 2, Points: 3},
+		{ID: 1, Name: "A1", Played: 1, Losses: 1, GoalsAgainst: 2, GoalDifference: -2},
+	}
+	if groups[0].Name != "A" || !reflect.DeepEqual(groups[0].Standings, expectedA) {
+		t.Errorf("Incorrect group A standings:\nExpected: %+v\nGot:      %+v", expectedA, groups[0].Standings)
+	}
+	for _, team := range groups[1].Standings {
+		if team.Played != 1 || team.Points != 1 {
+			t.Errorf("Knockout match leaked into group B standings: %+v", team)
+		}
+	}
+}
+
+// TestQualifiersAndPairings checks knockout seeding from group standings.
+func TestQualifiersAndPairings(t *testing.T) {
+	groups := []models.TournamentGroup{
+		{Name: "A", Standings: []models.Team{{ID: 1}, {ID: 2}, {ID: 3}}},
+		{Name: "B", Standings: []models.Team{{ID: 4}, {ID: 5}, {ID: 6}}},
+	}
+
+	qualifiers, err := qualifiersFromGroups(groups, 2)
+	if err != nil {
+		t.Fatalf("Did not expect an error but got: %v", err)
+	}
+	if !reflect.DeepEqual(qualifiers, []int{1, 4, 2, 5}) {
+		t.Errorf("Unexpected qualifier order: %v", qualifiers)
+	}
+	pairings := seededPairings(qualifiers)
+	expected := [][2]int{{1, 5}, {4, 2}}
+	if !reflect.DeepEqual(pairings, expected) {
+		t.Errorf("Unexpected pairings:\nExpected: %v\nGot:      %v", expected, pairings)
+	}
+
+	if _, err := qualifiersFromGroups(groups, 3); err == nil {
+		t.Errorf("Expected an error for 6 qualifiers (not a power of two) but got nil")
+	}
+}
+
+func TestValidateBracketGroups(t *testing.T) {
+	tests := []struct {
+		name    string
+		groups  [][]int
+		wantErr bool
+	}{
+		{name: "one group", groups: [][]int{{1, 2, 3}}},
+		{name: "two groups", groups: [][]int{{1, 2}, {3, 4}}},
+		{name: "four groups", groups: [][]int{{1, 2}, {3, 4}, {5, 6}, {7, 8}}},
+		{name: "three groups", groups: [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}}, wantErr: true},
+		{name: "six groups", groups: [][]int{{1, 2}, {3, 4}, {5, 6}, {7, 8}, {9, 10}, {11, 12}}, wantErr: true},
+		{name: "one group of one", groups: [][]int{{1}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateBracketGroups(tt.groups); (err != nil) != tt.wantErr {
+				t.Errorf("validateBracketGroups(%v) error = %v, wantErr %v", tt.groups, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package concretes
+
+import (
+	"MatchSimulator_Insider/services/abstracts"
+	"context"
+	"fmt"
+)
+
+// versionConflict reports a failed If-Match check. The error wraps abstracts.ErrVersionConflict
+// so the API can answer 412 Precondition Failed.
+func versionConflict(method, entity string, id, expectedVersion, currentVersion int) error {
+	return fmt.Errorf("%s: %s (ID: %d) is at version %d, not %d: %w", method, entity, id, currentVersion, expectedVersion, abstracts.ErrVersionConflict)
+}
+
+// teamUpdateMissed explains an UPDATE that matched no team row: when a version was expected and the team
+// still exists it is a version conflict, otherwise the team does not exist.
+func teamUpdateMissed(ctx context.Context, teamService abstracts.TeamService, method string, teamID, expectedVersion int, field string) error {
+	if expectedVersion != 0 {
+		if team, err := teamService.GetTeamByID(ctx, teamID); err == nil && team != nil {
+			return versionConflict(method, "Team", teamID, expectedVersion, team.Version)
+		}
+	}
+	return fmt.Errorf("%s: %w (ID: %d); %s not updated", method, abstracts.ErrTeamNotFound, teamID, field)
+}